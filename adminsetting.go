@@ -7,6 +7,7 @@ type Setting struct {
 	RunScriptAfterEditUserProfile  string `json:"runscriptafteredituserprofile"`  // 사용자 정보 수정후 실행될 쉘스크립트
 	ExcludeProject                 string `json:"excludeproject"`                 // Search옵션에 제외할 프로젝트명, 마이그레이션 시 사용한다.
 	OCIOConfig                     string `json:"ocioconfig"`                     // OpenColorIO Config Path 설정
+	OCIOBakeLut                    string `json:"ociobakelut"`                    // ociobakelut 경로 셋팅. 리뷰 동영상에 OCIO 컬러 변환을 적용할 때 사용한다.
 	FFmpeg                         string `json:"ffmpeg"`                         // FFmpeg 경로 셋팅
 	FFmpegThreads                  int    `json:"ffmpegthreads"`                  // FFmpeg 연산 Thread 셋팅
 	RVPath                         string `json:"rvpath"`                         // RV 경로 셋팅
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// regexpVersionNumber 는 v001, 001, 1 형태의 버전 문자열에서 숫자를 찾는다.
+var regexpVersionNumber = regexp.MustCompile(`\d+`)
+
+// versionNumber 함수는 버전 문자열에서 숫자를 반환한다. 숫자가 없다면 -1을 반환한다.
+func versionNumber(s string) int {
+	n, err := strconv.Atoi(regexpVersionNumber.FindString(s))
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// publishMatchesReview 함수는 퍼블리쉬가 리뷰와 같은 버전인지 체크한다.
+// 경로가 같거나 메인버전이 같으면 같은 버전이다. 퍼블리쉬에 서브버전이 있다면 서브버전도 같아야 한다.
+func publishMatchesReview(p Publish, review Review) bool {
+	if p.Path != "" && p.Path == review.Path {
+		return true
+	}
+	if versionNumber(p.MainVersion) != review.MainVersion {
+		return false
+	}
+	if p.SubVersion != "" && versionNumber(p.SubVersion) != review.SubVersion {
+		return false
+	}
+	return true
+}
+
+// applyReviewApproval 함수는 최종 Stage에서 approve 된 리뷰를 아이템에 반영하고 바뀐 필드 리스트를 반환한다.
+// Task 상태는 Stage의 ApproveTaskStatus로, Task mov는 리뷰 경로로 바꾸고, 리뷰와 같은 버전의 Publish를 UseThis로 설정한다.
+// 같은 Primary Key에서 이전에 UseThis였던 Publish는 NotUse로 바꾼다. 값이 같다면 기록하지 않는다.
+func applyReviewApproval(item *Item, review Review, stage Stage, now string) ([]ItemHistory, error) {
+	task, found := item.Tasks[review.Task]
+	if !found {
+		return nil, fmt.Errorf("%s 에 %s task가 존재하지 않습니다", item.ID, review.Task)
+	}
+	var changes []ItemHistory
+	change := func(field string, old, new interface{}) {
+		changes = append(changes, ItemHistory{
+			Project: review.Project,
+			ItemID:  item.ID,
+			Field:   "tasks." + review.Task + "." + field,
+			Old:     old,
+			New:     new,
+			Time:    now,
+			Source:  "review approve: " + review.ID.Hex(),
+		})
+	}
+	if stage.ApproveTaskStatus != "" && task.StatusV2 != stage.ApproveTaskStatus {
+		change("statusv2", task.StatusV2, stage.ApproveTaskStatus)
+		task.StatusV2 = stage.ApproveTaskStatus
+	}
+	if review.Path != "" && task.Mov != review.Path {
+		change("mov", task.Mov, review.Path)
+		task.Mov = review.Path
+		task.Mdate = now
+	}
+	for key, publishes := range task.Publishes {
+		matched := -1
+		for i, p := range publishes {
+			if publishMatchesReview(p, review) {
+				matched = i
+			}
+		}
+		if matched == -1 {
+			continue
+		}
+		for i, p := range publishes {
+			status := p.Status
+			if i == matched {
+				status = "UseThis"
+			} else if p.Status == "UseThis" {
+				status = "NotUse"
+			}
+			if status == p.Status {
+				continue
+			}
+			change(fmt.Sprintf("publishes.%s.%d.status", key, i), p.Status, status)
+			publishes[i].Status = status
+		}
+	}
+	item.Tasks[review.Task] = task
+	return changes, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestApplyReviewApproval(t *testing.T) {
+	item := Item{
+		Project: "TEMP",
+		ID:      "SS_0010_org",
+		Tasks: map[string]Task{
+			"comp": {
+				StatusV2: "wip",
+				Mov:      "/show/TEMP/SS_0010_comp_v002.mov",
+				Publishes: map[string][]Publish{
+					"comp": {
+						{MainVersion: "v002", Status: "UseThis"},
+						{MainVersion: "v003", Status: "Working"},
+					},
+					"plate": {
+						{MainVersion: "v001", Status: "UseThis"},
+					},
+				},
+			},
+		},
+	}
+	review := Review{
+		ID:          bson.ObjectIdHex("5f87f82641a789486f3970d1"),
+		Project:     "TEMP",
+		Task:        "comp",
+		Path:        "/show/TEMP/SS_0010_comp_v003.mov",
+		MainVersion: 3,
+	}
+	stage := Stage{Final: true, ApproveTaskStatus: "done"}
+	changes, err := applyReviewApproval(&item, review, stage, "2020-10-15T10:00:00+09:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][2]interface{}{
+		"tasks.comp.statusv2":                {"wip", "done"},
+		"tasks.comp.mov":                     {"/show/TEMP/SS_0010_comp_v002.mov", review.Path},
+		"tasks.comp.publishes.comp.0.status": {"UseThis", "NotUse"},
+		"tasks.comp.publishes.comp.1.status": {"Working", "UseThis"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("TestApplyReviewApproval: 얻은 값 %v, 원하는 값 %v", changes, want)
+	}
+	for _, c := range changes {
+		w, ok := want[c.Field]
+		if !ok || c.Old != w[0] || c.New != w[1] {
+			t.Fatalf("TestApplyReviewApproval(%v): 얻은 값 %v -> %v, 원하는 값 %v", c.Field, c.Old, c.New, w)
+		}
+	}
+	if item.Tasks["comp"].Publishes["plate"][0].Status != "UseThis" {
+		t.Fatalf("TestApplyReviewApproval: 다른 버전의 Publish가 바뀌면 안됩니다")
+	}
+	// 같은 리뷰를 다시 approve 하면 바뀌는 값이 없어야 한다.
+	changes, err = applyReviewApproval(&item, review, stage, "2020-10-15T10:00:00+09:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("TestApplyReviewApproval: 얻은 값 %v, 원하는 값 없음", changes)
+	}
+}
@@ -0,0 +1,35 @@
+// capacityRequest 함수는 근무 캘린더 RestAPI를 호출하고 성공하면 페이지를 새로고침한다.
+function capacityRequest(url, data) {
+    let token = document.getElementById("token").value;
+    $.ajax({
+        url: url,
+        type: "post",
+        data: data,
+        headers: {
+            "Authorization": "Basic "+ token
+        },
+        dataType: "json",
+        success: function() {
+            location.reload();
+        },
+        error: function(request,status,error){
+            alert("code:"+request.status+"\n"+"status:"+status+"\n"+"Msg:"+request.responseText+"\n"+"error:"+error);
+        }
+    });
+}
+
+// addLeave 함수는 입력한 휴가 또는 회사 휴일을 근무 캘린더에 추가한다.
+function addLeave() {
+    capacityRequest("/api/addleave", {
+        user: document.getElementById("leave-user").value,
+        start: document.getElementById("leave-start").value,
+        end: document.getElementById("leave-end").value,
+        type: document.getElementById("leave-type").value,
+        note: document.getElementById("leave-note").value,
+    });
+}
+
+// rmLeave 함수는 근무 캘린더에서 start에 시작하는 휴가를 삭제한다.
+function rmLeave(user, start) {
+    capacityRequest("/api/rmleave", {user: user, start: start});
+}
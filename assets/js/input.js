@@ -2478,6 +2478,9 @@ function setTaskUser() {
                     } else {
                         document.getElementById(`${data.id}-task-${data.task}-user`).innerHTML = `<span class="mt-1 ml-1 badge badge-light">${data.username}</span>`;
                     }
+                    if (data.warning) {
+                        alert(data.warning);
+                    }
                 },
                 error: function(request,status,error){
                     alert("code:"+request.status+"\n"+"status:"+status+"\n"+"msg:"+request.responseText+"\n"+"error:"+error);
@@ -2505,6 +2508,9 @@ function setTaskUser() {
                 } else {
                     document.getElementById(`${data.id}-task-${data.task}-user`).innerHTML = `<span class="mt-1 ml-1 badge badge-light">${data.username}</span>`;
                 }
+                if (data.warning) {
+                    alert(data.warning);
+                }
             },
             error: function(request,status,error){
                 alert("code:"+request.status+"\n"+"status:"+status+"\n"+"msg:"+request.responseText+"\n"+"error:"+error);
@@ -4519,13 +4525,14 @@ function clickCommentButton() {
     addReviewComment()
 }
 
-function setReviewStatus(status) {
+function setReviewStatus(status, override=false) {
     $.ajax({
         url: "/api/setreviewstatus",
         type: "post",
         data: {
             status: status,
             id: document.getElementById("current-review-id").value,
+            override: override,
         },
         headers: {
             "Authorization": "Basic "+ document.getElementById("token").value
@@ -4539,7 +4546,7 @@ function setReviewStatus(status) {
             if (data.status === "approve") {
                 item.setAttribute("class","ml-1 badge badge-success")
                 addReviewCommentText("Approved " + data.stage + " Stage.") // comment를 남긴다.
-                setReviewNextStatus(data.id) // 다음 Status를 설정한다.
+                setReviewNextStatus(data.id, data.override) // 다음 Status를 설정한다.
                 setReviewNextStage(data.id) // 다음 Stage를 설정한다.
             } else if (data.status === "comment") {
                 item.setAttribute("class","ml-1 badge badge-warning")
@@ -4547,18 +4554,26 @@ function setReviewStatus(status) {
                 item.setAttribute("class","ml-1 badge badge-secondary")
             }
         },
-        error: function(request,status,error){
-            alert("code:"+request.status+"\n"+"status:"+status+"\n"+"msg:"+request.responseText+"\n"+"error:"+error);
+        error: function(request,s,error){
+            // 해결되지 않은 수정사항이 있다면 override 여부를 물어본다. override는 슈퍼바이저 이상 권한이 필요하다.
+            if (request.status === 409 && !override) {
+                if (confirm(request.responseText + "\n수정사항을 무시하고 approve 하시겠습니까?")) {
+                    setReviewStatus(status, true)
+                }
+                return
+            }
+            alert("code:"+request.status+"\n"+"status:"+s+"\n"+"msg:"+request.responseText+"\n"+"error:"+error);
         }
     });
 }
 
-function setReviewNextStatus(id) {
+function setReviewNextStatus(id, override=false) {
     $.ajax({
         url: "/api/setreviewnextstatus",
         type: "post",
         data: {
             id: id,
+            override: override,
         },
         headers: {
             "Authorization": "Basic "+ document.getElementById("token").value
@@ -4997,6 +5012,7 @@ function addReviewCommentText(text) {
         data: {
             id: document.getElementById("current-review-id").value,
             text: text,
+            resolved: true, // 상태를 기록하는 코멘트이므로 수정사항으로 취급하지 않는다.
         },
         headers: {
             "Authorization": "Basic "+ document.getElementById("token").value
@@ -5093,7 +5109,7 @@ function initCanvas() {
 }
 
 function selectReviewItem(id) {
-    let project, fps, ext, type;
+    let project, fps, ext, type, slated;
     let playerbox = document.getElementById("playerbox"); // player 캔버스를담을 div를 가지고 온다.
     let clientWidth = playerbox.clientWidth // 클라이언트 사용자의 가로 사이즈를 구한다.
     let clientHeight = playerbox.clientHeight // 클라이언트 사용자의 세로 사이즈를 구한다.
@@ -5129,6 +5145,7 @@ function selectReviewItem(id) {
             fps = data.fps
             ext = data.ext
             type = data.type
+            slated = data.slated
             for (let i = 0; i < data.sketches.length; i++) {
                 sketchesFrame.push(data.sketches[i].frame)
             }
@@ -5299,6 +5316,24 @@ function selectReviewItem(id) {
     video.loop = true;
     video.setAttribute("id", "currentvideo");
 
+    // 슬레이트 버튼을 클릭하면 클린 버전과 슬레이트 버전을 전환한다. 재생위치는 유지한다.
+    let slateButton = document.getElementById("player-slate");
+    slateButton.hidden = !slated;
+    slateButton.innerHTML = "CLEAN";
+    slateButton.onclick = function() {
+        let currentTime = video.currentTime;
+        let paused = video.paused;
+        let slate = slateButton.innerHTML === "CLEAN";
+        slateButton.innerHTML = slate ? "SLATE" : "CLEAN";
+        video.src = `/reviewdata?id=${id}&ext=${ext}&slate=${slate}`;
+        video.addEventListener("loadedmetadata", function() {
+            video.currentTime = currentTime;
+            if (paused) {
+                video.pause();
+            }
+        }, {once: true});
+    };
+
     // 이미지 객체를 생성한다.
     if (type === "image") {
         reviewImage = new Image();
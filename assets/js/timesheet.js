@@ -0,0 +1,45 @@
+// timesheetRequest 함수는 타임시트 RestAPI를 호출하고 성공하면 페이지를 새로고침한다.
+function timesheetRequest(url, data) {
+    let token = document.getElementById("token").value;
+    $.ajax({
+        url: url,
+        type: "post",
+        data: data,
+        headers: {
+            "Authorization": "Basic "+ token
+        },
+        dataType: "json",
+        success: function() {
+            location.reload();
+        },
+        error: function(request,status,error){
+            alert("code:"+request.status+"\n"+"status:"+status+"\n"+"Msg:"+request.responseText+"\n"+"error:"+error);
+        }
+    });
+}
+
+// addTimeLog 함수는 입력한 작업시간을 타임시트에 기록한다.
+function addTimeLog() {
+    timesheetRequest("/api/addtimelog", {
+        project: document.getElementById("timelog-project").value,
+        id: document.getElementById("timelog-id").value,
+        task: document.getElementById("timelog-task").value,
+        date: document.getElementById("timelog-date").value,
+        hours: document.getElementById("timelog-hours").value,
+        note: document.getElementById("timelog-note").value,
+    });
+}
+
+// rmTimeLog 함수는 타임시트를 삭제한다.
+function rmTimeLog(id) {
+    timesheetRequest("/api/rmtimelog", {id: id});
+}
+
+// approveTimesheet 함수는 보고있는 사용자의 주간 타임시트를 승인하거나 반려한다.
+function approveTimesheet(status) {
+    timesheetRequest("/api/approvetimesheet", {
+        user: document.getElementById("timesheet-user").value,
+        week: document.getElementById("timesheet-week").value,
+        status: status,
+    });
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Assignee 자료구조는 Task를 작업하는 아티스트 정보이다. 하나의 Task를 여러 아티스트가 나누어 작업할 수 있다.
+type Assignee struct {
+	User      string  `json:"user"`      // 아티스트. 예) khw7096(김한웅,2D1)
+	Role      string  `json:"role"`      // 역할. lead, support
+	Share     float64 `json:"share"`     // 작업 분담률. 0~100 %
+	Startdate string  `json:"startdate"` // 작업 시작일 RFC3339
+	Enddate   string  `json:"enddate"`   // 작업 종료일 RFC3339
+	ResultDay int     `json:"resultday"` // 아티스트의 실제 맨데이
+}
+
+// CheckError 메소드는 Assignee 자료구조의 에러를 체크한다.
+func (a Assignee) CheckError() error {
+	if a.User == "" {
+		return errors.New("user를 설정해주세요")
+	}
+	if a.Role != "lead" && a.Role != "support" {
+		return errors.New("role은 lead 또는 support 이어야 합니다")
+	}
+	if a.Share < 0 || a.Share > 100 {
+		return errors.New("share는 0~100 사이여야 합니다")
+	}
+	if a.ResultDay < 0 {
+		return errors.New("resultday는 0 이상이어야 합니다")
+	}
+	if a.Startdate != "" && a.Enddate != "" && a.Enddate < a.Startdate {
+		return errors.New("enddate가 startdate보다 빠릅니다")
+	}
+	return nil
+}
+
+// assigneeID 함수는 "khw7096(김한웅,2D1)" 형태의 아티스트 문자열에서 ID를 반환한다.
+func assigneeID(user string) string {
+	return strings.TrimSpace(strings.Split(user, "(")[0])
+}
+
+// Users 메소드는 Task를 작업하는 아티스트 리스트를 반환한다.
+// Assignees가 없다면 legacy User 값을 사용하고 "kim,lee" 형태로 입력된 값은 나누어 반환한다.
+func (t Task) Users() []string {
+	if len(t.Assignees) != 0 {
+		var users []string
+		for _, a := range t.Assignees {
+			users = append(users, a.User)
+		}
+		return users
+	}
+	return splitUsers(t.User)
+}
+
+// splitUsers 함수는 "kim(김,2D1),lee" 형태의 문자열을 아티스트 리스트로 나눈다. 괄호 안의 쉼표는 나누지 않는다.
+func splitUsers(str string) []string {
+	var users []string
+	depth := 0
+	start := 0
+	for n, r := range str + "," {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth > 0 {
+				continue
+			}
+			u := strings.TrimSpace(str[start:n])
+			start = n + 1
+			if u == "" {
+				continue
+			}
+			users = append(users, u)
+		}
+	}
+	return users
+}
+
+// Assignee 메소드는 user에 해당하는 Assignee를 반환한다.
+func (t Task) Assignee(user string) (Assignee, bool) {
+	for _, a := range t.Assignees {
+		if assigneeID(a.User) == assigneeID(user) {
+			return a, true
+		}
+	}
+	return Assignee{}, false
+}
+
+// initAssignees 메소드는 Assignees가 비어있다면 legacy User 값을 Assignees로 옮긴다. 첫번째 아티스트가 lead가 된다.
+func (t *Task) initAssignees() {
+	if len(t.Assignees) != 0 {
+		return
+	}
+	users := t.Users()
+	for n, u := range users {
+		a := Assignee{User: u, Role: "support", Share: 100 / float64(len(users))}
+		if n == 0 {
+			a.Role = "lead"
+			a.ResultDay = t.ResultDay
+			a.Startdate = t.Startdate
+		}
+		t.Assignees = append(t.Assignees, a)
+	}
+}
+
+// syncAssignees 메소드는 Assignees 정보를 legacy User, ResultDay 값에 반영한다.
+// User는 lead 아티스트, ResultDay는 아티스트별 실제 맨데이의 합이 된다.
+func (t *Task) syncAssignees() {
+	if len(t.Assignees) == 0 {
+		return
+	}
+	t.User = t.Assignees[0].User
+	t.ResultDay = 0
+	for _, a := range t.Assignees {
+		t.ResultDay += a.ResultDay
+	}
+	for _, a := range t.Assignees {
+		if a.Role == "lead" {
+			t.User = a.User
+			return
+		}
+	}
+}
+
+// SetAssignee 메소드는 Task에 아티스트를 설정한다. 이미 있는 아티스트라면 정보를 교체한다.
+// lead 아티스트를 설정하면 기존 lead 아티스트는 support가 된다.
+func (t *Task) SetAssignee(a Assignee) error {
+	err := a.CheckError()
+	if err != nil {
+		return err
+	}
+	t.initAssignees()
+	found := false
+	for n, old := range t.Assignees {
+		if assigneeID(old.User) == assigneeID(a.User) {
+			t.Assignees[n] = a
+			found = true
+			continue
+		}
+		if a.Role == "lead" && old.Role == "lead" {
+			t.Assignees[n].Role = "support"
+		}
+	}
+	if !found {
+		t.Assignees = append(t.Assignees, a)
+	}
+	var total float64
+	for _, a := range t.Assignees {
+		total += a.Share
+	}
+	if total > 100 {
+		return fmt.Errorf("아티스트 분담률의 합(%g%%)이 100%%를 넘습니다", total)
+	}
+	t.syncAssignees()
+	return nil
+}
+
+// RmAssignee 메소드는 Task에서 아티스트를 삭제한다. 모든 아티스트가 삭제되면 User는 빈 문자열이 된다.
+func (t *Task) RmAssignee(user string) error {
+	t.initAssignees()
+	assignees := []Assignee{}
+	for _, a := range t.Assignees {
+		if assigneeID(a.User) == assigneeID(user) {
+			continue
+		}
+		assignees = append(assignees, a)
+	}
+	if len(assignees) == len(t.Assignees) {
+		return fmt.Errorf("%s Task에 %s 아티스트가 존재하지 않습니다", t.Title, user)
+	}
+	t.Assignees = assignees
+	if len(t.Assignees) == 0 {
+		t.User = ""
+		t.ResultDay = 0
+		return nil
+	}
+	t.syncAssignees()
+	return nil
+}
+
+// SetLeadUser 메소드는 legacy User 값을 설정한다. Assignees가 있다면 lead 아티스트를 교체한다.
+func (t *Task) SetLeadUser(user string) {
+	if len(t.Assignees) == 0 {
+		t.User = user
+		return
+	}
+	if user == "" {
+		t.Assignees = nil
+		t.User = ""
+		return
+	}
+	// 이미 작업중인 아티스트라면 lead로 올리고 기존 lead 아티스트는 support가 된다. 같은 아티스트가 두번 등록되지 않는다.
+	if _, ok := t.Assignee(user); ok {
+		for n, a := range t.Assignees {
+			switch {
+			case assigneeID(a.User) == assigneeID(user):
+				t.Assignees[n].Role = "lead"
+			case a.Role == "lead":
+				t.Assignees[n].Role = "support"
+			}
+		}
+		t.syncAssignees()
+		return
+	}
+	for n, a := range t.Assignees {
+		if a.Role == "lead" {
+			t.Assignees[n].User = user
+			t.syncAssignees()
+			return
+		}
+	}
+	t.Assignees = append([]Assignee{{User: user, Role: "lead"}}, t.Assignees...)
+	t.syncAssignees()
+}
+
+// AssigneesText 메소드는 엑셀, 웹에서 보여줄 아티스트 문자열을 반환한다. 예) kim(lead 60%), lee(support 40%)
+func (t Task) AssigneesText() string {
+	if len(t.Assignees) == 0 {
+		return t.User
+	}
+	var texts []string
+	for _, a := range t.Assignees {
+		texts = append(texts, fmt.Sprintf("%s(%s %g%%)", assigneeID(a.User), a.Role, a.Share))
+	}
+	return strings.Join(texts, ", ")
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTaskUsers(t *testing.T) {
+	cases := []struct {
+		task Task
+		want []string
+	}{{
+		task: Task{User: "kim"},
+		want: []string{"kim"},
+	}, {
+		task: Task{User: "kim, lee"}, // legacy
+		want: []string{"kim", "lee"},
+	}, {
+		task: Task{User: "kim(김,2D1),lee(이,2D1)"},
+		want: []string{"kim(김,2D1)", "lee(이,2D1)"},
+	}, {
+		task: Task{User: "kim", Assignees: []Assignee{{User: "kim", Role: "lead"}, {User: "park", Role: "support"}}},
+		want: []string{"kim", "park"},
+	}, {
+		task: Task{},
+		want: nil,
+	}}
+	for _, c := range cases {
+		got := c.task.Users()
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("TestTaskUsers(%v): 얻은 값 %v, 원하는 값 %v", c.task, got, c.want)
+		}
+	}
+}
+
+func TestSetAssignee(t *testing.T) {
+	task := Task{Title: "comp", User: "kim(김,2D1)", ResultDay: 2}
+	err := task.SetAssignee(Assignee{User: "lee", Role: "lead", Share: 50, ResultDay: 3})
+	if err == nil {
+		t.Fatalf("TestSetAssignee(): 분담률 합이 100을 넘으면 에러가 나야 합니다")
+	}
+	task = Task{Title: "comp", User: "kim(김,2D1)", ResultDay: 2}
+	task.SetAssignee(Assignee{User: "kim", Role: "support", Share: 40, ResultDay: 2})
+	err = task.SetAssignee(Assignee{User: "lee", Role: "lead", Share: 60, ResultDay: 3})
+	if err != nil {
+		t.Fatalf("TestSetAssignee(): %v", err)
+	}
+	if task.User != "lee" || task.ResultDay != 5 || len(task.Assignees) != 2 {
+		t.Fatalf("TestSetAssignee(): 얻은 값 %v, 원하는 값 user lee, resultday 5", task)
+	}
+	err = task.RmAssignee("lee")
+	if err != nil || task.User != "kim" || task.ResultDay != 2 {
+		t.Fatalf("TestSetAssignee(rm): 얻은 값 %v, 원하는 값 user kim, resultday 2", task)
+	}
+}
+
+func TestSetLeadUser(t *testing.T) {
+	cases := []struct {
+		user  string
+		want  []Assignee
+		lead  string
+		total int
+	}{
+		// 새 아티스트는 lead 자리를 대신한다.
+		{user: "park", want: []Assignee{{User: "park", Role: "lead", Share: 60, ResultDay: 3}, {User: "lee", Role: "support", Share: 40, ResultDay: 2}}, lead: "park", total: 5},
+		// support 아티스트는 lead로 올라가고 기존 lead는 support가 된다.
+		{user: "lee(이,2D2)", want: []Assignee{{User: "kim", Role: "support", Share: 60, ResultDay: 3}, {User: "lee", Role: "lead", Share: 40, ResultDay: 2}}, lead: "lee", total: 5},
+		// 이미 lead라면 바뀌지 않는다.
+		{user: "kim", want: []Assignee{{User: "kim", Role: "lead", Share: 60, ResultDay: 3}, {User: "lee", Role: "support", Share: 40, ResultDay: 2}}, lead: "kim", total: 5},
+	}
+	for _, c := range cases {
+		task := Task{Title: "comp", Assignees: []Assignee{{User: "kim", Role: "lead", Share: 60, ResultDay: 3}, {User: "lee", Role: "support", Share: 40, ResultDay: 2}}}
+		task.SetLeadUser(c.user)
+		if !reflect.DeepEqual(task.Assignees, c.want) || task.User != c.lead || task.ResultDay != c.total {
+			t.Fatalf("TestSetLeadUser(%v): 얻은 값 %v, 원하는 값 %v", c.user, task, c.want)
+		}
+	}
+}
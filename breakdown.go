@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Breakdown 자료구조는 샷에서 사용하는 에셋과 샷별 사용정보이다. 브레이크다운은 샷 아이템에 저장한다.
+// 예) SS_0010 샷에서 stone 에셋을 damaged 베리에이션, LOD1로 사용한다.
+type Breakdown struct {
+	Asset       string `json:"asset"`       // 에셋 이름. 예) stone
+	Variant     string `json:"variant"`     // 베리에이션. 예) damaged
+	LOD         string `json:"lod"`         // LOD. 예) lod1
+	CrowdCount  int    `json:"crowdcount"`  // 군중 에셋일 때 샷에 등장하는 수
+	Note        string `json:"note"`        // 샷별 메모
+	Author      string `json:"author"`      // 작성자
+	Createtime  string `json:"createtime"`  // 생성시간 RFC3339
+	Flagged     bool   `json:"flagged"`     // 에셋의 상태가 바뀌어 샷에서 확인이 필요한지 여부
+	AssetStatus string `json:"assetstatus"` // 플래그가 설정될 때 바뀐 에셋의 상태
+	Flagtime    string `json:"flagtime"`    // 플래그가 설정된 시간 RFC3339
+}
+
+// CheckError 메소드는 Breakdown 자료구조의 에러를 체크한다. asset은 연결할 에셋 아이템이다.
+func (b Breakdown) CheckError(asset Item) error {
+	if b.Asset == "" {
+		return errors.New("asset을 설정해주세요")
+	}
+	if asset.Type != "asset" || asset.Name != b.Asset {
+		return fmt.Errorf("%s 는 에셋이 아닙니다", b.Asset)
+	}
+	if b.CrowdCount < 0 {
+		return errors.New("crowdcount는 0 이상이어야 합니다")
+	}
+	if b.CrowdCount != 0 && !asset.CrowdAsset {
+		return fmt.Errorf("%s 는 군중 에셋이 아니라서 crowdcount를 설정할 수 없습니다", b.Asset)
+	}
+	return nil
+}
+
+// SetBreakdown 메소드는 샷에 에셋 브레이크다운을 설정한다. 같은 에셋이 있다면 교체한다.
+func (i *Item) SetBreakdown(b Breakdown) error {
+	if i.Type != "org" && i.Type != "left" {
+		return fmt.Errorf("%s 는 샷이 아니라서 에셋을 연결할 수 없습니다", i.ID)
+	}
+	for n, old := range i.Breakdown {
+		if old.Asset == b.Asset {
+			i.Breakdown[n] = b
+			return nil
+		}
+	}
+	i.Breakdown = append(i.Breakdown, b)
+	return nil
+}
+
+// RmBreakdown 메소드는 샷에서 에셋 브레이크다운을 삭제한다.
+func (i *Item) RmBreakdown(asset string) error {
+	breakdown := []Breakdown{}
+	for _, b := range i.Breakdown {
+		if b.Asset == asset {
+			continue
+		}
+		breakdown = append(breakdown, b)
+	}
+	if len(breakdown) == len(i.Breakdown) {
+		return fmt.Errorf("%s 에 %s 에셋이 연결되어 있지 않습니다", i.ID, asset)
+	}
+	i.Breakdown = breakdown
+	return nil
+}
+
+// FlagBreakdown 메소드는 에셋의 상태가 바뀌었을 때 샷의 해당 브레이크다운에 플래그를 설정한다. 연결된 에셋이 없다면 false를 반환한다.
+func (i *Item) FlagBreakdown(asset, status, flagtime string) bool {
+	for n, b := range i.Breakdown {
+		if b.Asset != asset {
+			continue
+		}
+		i.Breakdown[n].Flagged = true
+		i.Breakdown[n].AssetStatus = status
+		i.Breakdown[n].Flagtime = flagtime
+		return true
+	}
+	return false
+}
+
+// ClearBreakdownFlag 메소드는 브레이크다운 플래그를 해제한다. asset이 빈 문자열이면 모든 플래그를 해제한다.
+func (i *Item) ClearBreakdownFlag(asset string) {
+	for n, b := range i.Breakdown {
+		if asset != "" && b.Asset != asset {
+			continue
+		}
+		i.Breakdown[n].Flagged = false
+	}
+}
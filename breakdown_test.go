@@ -0,0 +1,71 @@
+package main
+
+import "testing"
+
+func TestBreakdownCheckError(t *testing.T) {
+	stone := Item{Name: "stone", Type: "asset"}
+	crowd := Item{Name: "soldier", Type: "asset", CrowdAsset: true}
+	cases := []struct {
+		breakdown Breakdown
+		asset     Item
+		want      bool // 에러 여부
+	}{{
+		breakdown: Breakdown{Asset: "stone", Variant: "damaged", LOD: "lod1"},
+		asset:     stone,
+		want:      false,
+	}, {
+		breakdown: Breakdown{Asset: "stone", CrowdCount: 10},
+		asset:     stone,
+		want:      true,
+	}, {
+		breakdown: Breakdown{Asset: "soldier", CrowdCount: 10},
+		asset:     crowd,
+		want:      false,
+	}, {
+		breakdown: Breakdown{Asset: "soldier", CrowdCount: -1},
+		asset:     crowd,
+		want:      true,
+	}, {
+		breakdown: Breakdown{Asset: "SS_0010"},
+		asset:     Item{Name: "SS_0010", Type: "org"},
+		want:      true,
+	}}
+	for _, c := range cases {
+		got := c.breakdown.CheckError(c.asset) != nil
+		if got != c.want {
+			t.Fatalf("TestBreakdownCheckError(%v): 얻은 값 %v, 원하는 값 %v", c.breakdown, got, c.want)
+		}
+	}
+}
+
+func TestBreakdownFlag(t *testing.T) {
+	shot := Item{ID: "SS_0010_org", Type: "org"}
+	for _, asset := range []string{"stone", "tree"} {
+		err := shot.SetBreakdown(Breakdown{Asset: asset})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := shot.SetBreakdown(Breakdown{Asset: "stone", LOD: "lod2"})
+	if err != nil || len(shot.Breakdown) != 2 || shot.Breakdown[0].LOD != "lod2" {
+		t.Fatalf("TestBreakdownFlag(%v): 얻은 값 %v, 원하는 값 %v", "SetBreakdown", shot.Breakdown, "stone(lod2), tree")
+	}
+	if shot.FlagBreakdown("rock", "wip", "") {
+		t.Fatalf("TestBreakdownFlag(%v): 얻은 값 %v, 원하는 값 %v", "FlagBreakdown", true, false)
+	}
+	if !shot.FlagBreakdown("tree", "done", "") || !shot.Breakdown[1].Flagged || shot.Breakdown[1].AssetStatus != "done" {
+		t.Fatalf("TestBreakdownFlag(%v): 얻은 값 %v, 원하는 값 %v", "FlagBreakdown", shot.Breakdown[1], "tree done 플래그")
+	}
+	shot.ClearBreakdownFlag("")
+	if shot.Breakdown[1].Flagged {
+		t.Fatalf("TestBreakdownFlag(%v): 얻은 값 %v, 원하는 값 %v", "ClearBreakdownFlag", true, false)
+	}
+	err = shot.RmBreakdown("stone")
+	if err != nil || len(shot.Breakdown) != 1 {
+		t.Fatalf("TestBreakdownFlag(%v): 얻은 값 %v, 원하는 값 %v", "RmBreakdown", shot.Breakdown, "tree")
+	}
+	asset := Item{ID: "stone_asset", Type: "asset"}
+	if asset.SetBreakdown(Breakdown{Asset: "tree"}) == nil {
+		t.Fatalf("TestBreakdownFlag(%v): 얻은 값 %v, 원하는 값 %v", "SetBreakdown", nil, "샷이 아닌 아이템 에러")
+	}
+}
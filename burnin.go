@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// BurnIn 자료구조는 리뷰 슬레이트 버전에 새길 정보를 설정하는 자료구조이다.
+// 각 위치의 값은 Go 템플릿 문법을 사용하고 BurnInData 자료구조로 평가된다. 예) {{.Name}} {{.Task}} {{.Version}}
+type BurnIn struct {
+	TopLeft     string `json:"topleft"`     // 왼쪽 위
+	TopRight    string `json:"topright"`    // 오른쪽 위
+	BottomLeft  string `json:"bottomleft"`  // 왼쪽 아래
+	BottomRight string `json:"bottomright"` // 오른쪽 아래
+	FontSize    int    `json:"fontsize"`    // 폰트 사이즈. 0이면 24를 사용한다.
+	FontFile    string `json:"fontfile"`    // 폰트 파일 경로. 한글을 새기려면 한글 폰트를 설정해야 한다.
+}
+
+// BurnInData 자료구조는 BurnIn 템플릿을 평가할 때 사용하는 자료구조이다.
+type BurnInData struct {
+	Project       string  // 프로젝트
+	Name          string  // 샷, 에셋 이름
+	Task          string  // 태스크
+	Stage         string  // 리뷰 Stage
+	MainVersion   int     // Main Version
+	SubVersion    int     // Sub Version
+	Version       string  // 프로젝트 버전 자릿수로 표기한 버전. 예) v01
+	Author        string  // 작성자 ID
+	AuthorNameKor string  // 작성자 한글 이름
+	Fps           float64 // FPS
+	Date          string  // 리뷰 등록일. 예) 2020-10-15
+	Item          Item    // 리뷰 대상 아이템. 예) {{.Item.JustTimecodeIn}}, {{.Item.ScanIn}}
+	Frame         string  // 영상에 새겨질 프레임 카운터
+	Timecode      string  // 영상에 새겨질 타임코드. Item의 JustTimecodeIn부터 시작한다.
+}
+
+// 템플릿 평가 이후 drawtext 확장 문법으로 치환될 값이다.
+// 템플릿 평가 결과는 escapeDrawtext로 이스케이프 되기 때문에 확장 문법은 이스케이프 이후에 넣는다.
+const (
+	burnInFramePlaceholder    = "\ue000frame\ue001"
+	burnInTimecodePlaceholder = "\ue000timecode\ue001"
+)
+
+// defaultBurnIn 함수는 프로젝트에 BurnIn이 설정되어 있지 않을 때 사용하는 기본 BurnIn을 반환한다.
+func defaultBurnIn() BurnIn {
+	return BurnIn{
+		TopLeft:     "{{.Project}}",
+		TopRight:    "{{.Date}}",
+		BottomLeft:  "{{.Name}} {{.Task}} {{.Version}}",
+		BottomRight: "{{.Frame}}",
+	}
+}
+
+// IsEmpty 메소드는 BurnIn에 새길 정보가 없는지 체크한다.
+func (b BurnIn) IsEmpty() bool {
+	return b.TopLeft == "" && b.TopRight == "" && b.BottomLeft == "" && b.BottomRight == ""
+}
+
+// CheckError 메소드는 BurnIn 템플릿 문법 에러를 체크한다.
+func (b BurnIn) CheckError() error {
+	for _, t := range []string{b.TopLeft, b.TopRight, b.BottomLeft, b.BottomRight} {
+		_, err := template.New("burnin").Parse(t)
+		if err != nil {
+			return err
+		}
+	}
+	if b.FontSize < 0 {
+		return errors.New("BurnIn FontSize는 0보다 작을 수 없습니다")
+	}
+	return nil
+}
+
+// newBurnInData 함수는 리뷰, 아이템, 프로젝트 정보를 이용해서 BurnInData를 만든다.
+func newBurnInData(review Review, item Item, p Project) BurnInData {
+	d := BurnInData{
+		Project:       review.Project,
+		Name:          review.Name,
+		Task:          review.Task,
+		Stage:         review.Stage,
+		MainVersion:   review.MainVersion,
+		SubVersion:    review.SubVersion,
+		Version:       versionString(p, review.MainVersion),
+		Author:        review.Author,
+		AuthorNameKor: review.AuthorNameKor,
+		Fps:           review.Fps,
+		Item:          item,
+		Frame:         burnInFramePlaceholder,
+		Timecode:      burnInTimecodePlaceholder,
+	}
+	if t, err := time.Parse(time.RFC3339, review.Createtime); err == nil {
+		d.Date = t.Format("2006-01-02")
+	}
+	return d
+}
+
+// versionString 함수는 프로젝트의 버전 자릿수를 이용해서 v01 형태의 버전 문자열을 반환한다.
+func versionString(p Project, version int) string {
+	versionNum := p.VersionNum
+	if versionNum == 0 {
+		versionNum = 2
+	}
+	return fmt.Sprintf("v%0*d", versionNum, version)
+}
+
+// Filters 메소드는 BurnIn 템플릿을 평가해서 FFmpeg drawtext 필터 리스트를 만든다.
+// startFrame은 프레임 카운터의 시작 프레임, timecodeIn은 타임코드의 시작 타임코드이다.
+func (b BurnIn) Filters(data BurnInData, startFrame int, timecodeIn string) ([]string, error) {
+	fontsize := b.FontSize
+	if fontsize == 0 {
+		fontsize = 24
+	}
+	positions := []struct {
+		tmpl string
+		x    string
+		y    string
+	}{
+		{b.TopLeft, "10", "10"},
+		{b.TopRight, "w-tw-10", "10"},
+		{b.BottomLeft, "10", "h-th-10"},
+		{b.BottomRight, "w-tw-10", "h-th-10"},
+	}
+	var filters []string
+	for _, pos := range positions {
+		if pos.tmpl == "" {
+			continue
+		}
+		tmpl, err := template.New("burnin").Parse(pos.tmpl)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, data)
+		if err != nil {
+			return nil, err
+		}
+		text := strings.TrimSpace(buf.String())
+		if text == "" {
+			continue
+		}
+		text = escapeDrawtext(text)
+		text = strings.Replace(text, burnInFramePlaceholder, frameExpansion(startFrame), -1)
+		text = strings.Replace(text, burnInTimecodePlaceholder, timecodeExpansion(timecodeIn, data.Fps), -1)
+		filters = append(filters, drawtextFilter(text, pos.x, pos.y, fontsize, b.FontFile))
+	}
+	return filters, nil
+}
+
+// frameExpansion 함수는 startFrame부터 시작하는 프레임 카운터 drawtext 확장 문법을 반환한다.
+func frameExpansion(startFrame int) string {
+	return fmt.Sprintf("%%{eif:n+%d:d}", startFrame)
+}
+
+// timecodeExpansion 함수는 timecodeIn부터 시작하는 타임코드 drawtext 확장 문법을 반환한다.
+// 타임코드는 Non-Drop Frame으로 계산하며 23.976 같은 FPS는 반올림해서 타임코드 베이스로 사용한다.
+func timecodeExpansion(timecodeIn string, fps float64) string {
+	base := int(math.Round(fps))
+	if base <= 0 {
+		base = 24
+	}
+	start, err := timecodeToFrame(timecodeIn, base)
+	if err != nil {
+		start = 0
+	}
+	frame := fmt.Sprintf("(n+%d)", start)
+	return fmt.Sprintf("%%{eif:mod(trunc(%s/%d),24):d:2}:%%{eif:mod(trunc(%s/%d),60):d:2}:%%{eif:mod(trunc(%s/%d),60):d:2}:%%{eif:mod(%s,%d):d:2}",
+		frame, base*3600, frame, base*60, frame, base, frame, base)
+}
+
+// timecodeToFrame 함수는 00:00:00:00 형태의 타임코드를 프레임으로 바꾼다.
+func timecodeToFrame(timecode string, base int) (int, error) {
+	parts := strings.FieldsFunc(timecode, func(r rune) bool { return r == ':' || r == ';' })
+	if len(parts) != 4 {
+		return 0, fmt.Errorf("%s 값은 00:00:00:00 형태의 타임코드가 아닙니다", timecode)
+	}
+	var n [4]int
+	for i, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil {
+			return 0, err
+		}
+		n[i] = v
+	}
+	return ((n[0]*60+n[1])*60+n[2])*base + n[3], nil
+}
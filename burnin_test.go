@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTimecodeToFrame(t *testing.T) {
+	cases := []struct {
+		in   string
+		base int
+		want int
+	}{{
+		in:   "00:00:00:00",
+		base: 24,
+		want: 0,
+	}, {
+		in:   "01:00:00:00",
+		base: 24,
+		want: 86400,
+	}, {
+		in:   "00:00:01:12",
+		base: 24,
+		want: 36,
+	}, {
+		in:   "00:01:00;05",
+		base: 30,
+		want: 1805,
+	}}
+	for _, c := range cases {
+		got, err := timecodeToFrame(c.in, c.base)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("TestTimecodeToFrame(%v): 얻은 값 %v, 원하는 값 %v", c.in, got, c.want)
+		}
+	}
+	_, err := timecodeToFrame("1001", 24)
+	if err == nil {
+		t.Fatal("TestTimecodeToFrame: 타임코드 형태가 아니면 에러가 발생해야 합니다")
+	}
+}
+
+func TestBurnInFilters(t *testing.T) {
+	review := Review{Project: "circle", Name: "SS_0010", Task: "comp", MainVersion: 3, AuthorNameKor: "김한웅", Fps: 24}
+	data := newBurnInData(review, Item{ScanIn: 1001}, Project{VersionNum: 3})
+	b := BurnIn{
+		TopLeft:     "{{.Name}} {{.Task}} {{.Version}} 100%",
+		BottomLeft:  "scan {{.Item.ScanIn}}",
+		BottomRight: "{{.Frame}} {{.Timecode}}",
+		FontFile:    "/fonts/NanumGothic.ttf",
+	}
+	filters, err := b.Filters(data, 1001, "01:00:00:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filters) != 3 {
+		t.Fatalf("TestBurnInFilters: 얻은 필터 개수 %d, 원하는 개수 3", len(filters))
+	}
+	want := `drawtext=text=SS_0010 comp v003 100\\\\%:fontfile=/fonts/NanumGothic.ttf:x=10:y=10:fontsize=24:fontcolor=white:box=1:boxcolor=black@0.5`
+	if filters[0] != want {
+		t.Fatalf("TestBurnInFilters: 얻은 값 %v, 원하는 값 %v", filters[0], want)
+	}
+	if !strings.Contains(filters[1], "text=scan 1001:") {
+		t.Fatalf("TestBurnInFilters: Item 정보가 평가되지 않았습니다. %v", filters[1])
+	}
+	if !strings.Contains(filters[2], `%{eif\\:n+1001\\:d}`) || !strings.Contains(filters[2], `mod(trunc((n+86400)/86400)\,24)`) {
+		t.Fatalf("TestBurnInFilters: 프레임, 타임코드 확장 문법이 없습니다. %v", filters[2])
+	}
+	err = BurnIn{TopLeft: "{{.Name"}.CheckError()
+	if err == nil {
+		t.Fatal("TestBurnInFilters: 템플릿 문법 에러를 체크해야 합니다")
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// CompanyCalendarID 는 회사 전체 휴일을 저장하는 WorkCalendar의 UserID이다.
+const CompanyCalendarID = "company"
+
+// WorkCalendar 자료구조는 아티스트의 근무 캘린더이다. 파트타임, 휴가를 반영해서 작업 가능한 맨데이를 계산한다.
+type WorkCalendar struct {
+	UserID      string  `json:"userid"`      // 사용자 ID. company는 회사 전체 휴일이다.
+	HoursPerDay float64 `json:"hoursperday"` // 하루 근무시간. 0이면 WorkHoursPerDay를 사용한다. 파트타임은 4 처럼 입력한다.
+	WorkDays    []int   `json:"workdays"`    // 근무 요일. 0:일요일 ~ 6:토요일. 비어있으면 월~금이다.
+	Leaves      []Leave `json:"leaves"`      // 휴가, 휴일 리스트
+	Updatetime  string  `json:"updatetime"`  // 업데이트 시간 RFC3339
+}
+
+// Leave 자료구조는 휴가, 휴일 기간이다.
+type Leave struct {
+	Start string `json:"start"` // 시작일 2006-01-02
+	End   string `json:"end"`   // 종료일 2006-01-02
+	Type  string `json:"type"`  // holiday, leave, halfday
+	Note  string `json:"note"`
+}
+
+// CapacityRow 자료구조는 한 주 동안 아티스트(또는 팀)의 작업 가능 맨데이와 배정된 맨데이이다.
+type CapacityRow struct {
+	Week       string   `json:"week"`       // 주 시작일(월요일) 2006-01-02
+	User       string   `json:"user"`       // 아티스트 ID. 팀 합계라면 빈 문자열이다.
+	Team       string   `json:"team"`       // 팀 이름
+	Capacity   float64  `json:"capacity"`   // 작업 가능 맨데이
+	Load       float64  `json:"load"`       // 배정된 Task의 맨데이
+	Overbooked bool     `json:"overbooked"` // Load가 Capacity를 넘는지 여부
+	Tasks      []string `json:"tasks"`      // 이 주에 배정된 "project/id:task" 리스트
+}
+
+// UnassignedRow 자료구조는 한 주 동안 아티스트가 배정되지 않은 작업이다.
+type UnassignedRow struct {
+	Week  string   `json:"week"`  // 주 시작일(월요일) 2006-01-02
+	Load  float64  `json:"load"`  // 배정되지 않은 Task의 맨데이
+	Tasks []string `json:"tasks"` // "project/id:task" 리스트
+}
+
+// CapacityReport 자료구조는 주간 작업량 리포트이다.
+type CapacityReport struct {
+	Start      string          `json:"start"` // 리포트 시작 주(월요일)
+	Weeks      int             `json:"weeks"` // 주 수
+	Users      []CapacityRow   `json:"users"`
+	Teams      []CapacityRow   `json:"teams"`
+	Unassigned []UnassignedRow `json:"unassigned"`
+}
+
+// CheckError 메소드는 WorkCalendar 자료구조의 에러를 체크한다.
+func (c WorkCalendar) CheckError() error {
+	if c.UserID == "" {
+		return errors.New("userid를 설정해주세요")
+	}
+	if c.HoursPerDay < 0 || c.HoursPerDay > 24 {
+		return errors.New("hoursperday는 0~24 사이여야 합니다")
+	}
+	for _, d := range c.WorkDays {
+		if d < 0 || d > 6 {
+			return errors.New("workdays는 0(일요일)~6(토요일) 사이여야 합니다")
+		}
+	}
+	for _, l := range c.Leaves {
+		err := l.CheckError()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckError 메소드는 Leave 자료구조의 에러를 체크한다.
+func (l Leave) CheckError() error {
+	if _, err := time.Parse("2006-01-02", l.Start); err != nil {
+		return errors.New("start는 2006-01-02 형식이어야 합니다")
+	}
+	if _, err := time.Parse("2006-01-02", l.End); err != nil {
+		return errors.New("end는 2006-01-02 형식이어야 합니다")
+	}
+	if l.End < l.Start {
+		return errors.New("end가 start보다 빠릅니다")
+	}
+	if l.Type != "holiday" && l.Type != "leave" && l.Type != "halfday" {
+		return errors.New("type은 holiday, leave, halfday 중 하나여야 합니다")
+	}
+	return nil
+}
+
+// Capacity 메소드는 날짜(2006-01-02)에 작업 가능한 맨데이를 반환한다. company는 회사 전체 휴일 캘린더이다.
+func (c WorkCalendar) Capacity(date string, company WorkCalendar) float64 {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 0
+	}
+	workdays := c.WorkDays
+	if len(workdays) == 0 {
+		workdays = []int{1, 2, 3, 4, 5}
+	}
+	isWorkday := false
+	for _, d := range workdays {
+		if int(t.Weekday()) == d {
+			isWorkday = true
+			break
+		}
+	}
+	if !isWorkday {
+		return 0
+	}
+	hours := c.HoursPerDay
+	if hours == 0 {
+		hours = WorkHoursPerDay
+	}
+	day := hours / WorkHoursPerDay
+	for _, leaves := range [][]Leave{company.Leaves, c.Leaves} {
+		for _, l := range leaves {
+			if date < l.Start || date > l.End {
+				continue
+			}
+			if l.Type == "halfday" {
+				day /= 2
+				continue
+			}
+			return 0
+		}
+	}
+	return day
+}
+
+// capacityTask 자료구조는 작업량 계산에 사용하는 Task 정보이다.
+type capacityTask struct {
+	Key    string             // project/id:task
+	Start  string             // 2006-01-02
+	End    string             // 2006-01-02
+	Shares map[string]float64 // 아티스트 ID:맨데이. 비어있으면 배정되지 않은 Task이다.
+	Days   float64            // 전체 맨데이
+}
+
+// capacityTasks 함수는 아이템 리스트에서 작업량 계산에 사용할 Task 리스트를 만든다. 끝난 상태(done)의 Task는 제외한다.
+func capacityTasks(items map[string][]Item, done map[string]bool) []capacityTask {
+	var tasks []capacityTask
+	for project, list := range items {
+		for _, i := range list {
+			for name, t := range i.Tasks {
+				if done[t.StatusV2] || t.ExpectDay <= 0 {
+					continue
+				}
+				b, ok := newScheduleBar(project, i, name, t, nil)
+				if !ok {
+					continue
+				}
+				tasks = append(tasks, capacityTask{
+					Key:    project + "/" + b.key(),
+					Start:  b.Start,
+					End:    b.End,
+					Shares: expectDayShares(t),
+					Days:   float64(t.ExpectDay),
+				})
+			}
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Key < tasks[j].Key })
+	return tasks
+}
+
+// taskDayLoads 함수는 Task의 맨데이를 작업기간의 근무일에 나누어 날짜:맨데이 맵으로 반환한다.
+// 근무일이 없다면 작업기간의 모든 날짜에 나눈다.
+func taskDayLoads(start, end string, days float64, c, company WorkCalendar) map[string]float64 {
+	loads := make(map[string]float64)
+	var dates []string
+	var weights []float64
+	var total float64
+	s, err := time.Parse("2006-01-02", start)
+	if err != nil {
+		return loads
+	}
+	for d := s; d.Format("2006-01-02") <= end; d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		w := c.Capacity(date, company)
+		dates = append(dates, date)
+		weights = append(weights, w)
+		total += w
+	}
+	for n, date := range dates {
+		if total == 0 {
+			loads[date] = days / float64(len(dates))
+			continue
+		}
+		loads[date] = days * weights[n] / total
+	}
+	return loads
+}
+
+// buildCapacityReport 함수는 start가 속한 주부터 weeks 주 동안의 주간 작업량 리포트를 만든다.
+// calendars는 사용자 ID:근무 캘린더, teams는 사용자 ID:팀 이름 맵이다. users는 리포트에 포함할 아티스트 리스트이다.
+func buildCapacityReport(start string, weeks int, tasks []capacityTask, users []string, calendars map[string]WorkCalendar, teams map[string]string) (CapacityReport, error) {
+	monday, _, err := weekRange(start)
+	if err != nil {
+		return CapacityReport{}, err
+	}
+	if weeks < 1 {
+		return CapacityReport{}, errors.New("weeks는 1 이상이어야 합니다")
+	}
+	report := CapacityReport{Start: monday, Weeks: weeks, Users: []CapacityRow{}, Teams: []CapacityRow{}, Unassigned: []UnassignedRow{}}
+	end := addScheduleDays(monday, weeks*7-1)
+	company := calendars[CompanyCalendarID]
+	weekOf := func(date string) string {
+		w, _, _ := weekRange(date)
+		return w
+	}
+	// 아티스트별 주간 작업량을 계산한다.
+	rows := make(map[string]map[string]*CapacityRow) // user:week:row
+	unassigned := make(map[string]*UnassignedRow)
+	for _, u := range users {
+		rows[u] = make(map[string]*CapacityRow)
+		for w := 0; w < weeks; w++ {
+			week := addScheduleDays(monday, w*7)
+			row := &CapacityRow{Week: week, User: u, Team: teams[u], Tasks: []string{}}
+			for d := 0; d < 7; d++ {
+				row.Capacity += calendars[u].Capacity(addScheduleDays(week, d), company)
+			}
+			rows[u][week] = row
+		}
+	}
+	for _, t := range tasks {
+		if t.End < monday || t.Start > end {
+			continue
+		}
+		if len(t.Shares) == 0 {
+			for date, load := range taskDayLoads(t.Start, t.End, t.Days, WorkCalendar{}, company) {
+				if date < monday || date > end {
+					continue
+				}
+				week := weekOf(date)
+				if _, ok := unassigned[week]; !ok {
+					unassigned[week] = &UnassignedRow{Week: week, Tasks: []string{}}
+				}
+				unassigned[week].Load += load
+				if !inStrings(unassigned[week].Tasks, t.Key) {
+					unassigned[week].Tasks = append(unassigned[week].Tasks, t.Key)
+				}
+			}
+			continue
+		}
+		for u, days := range t.Shares {
+			if _, ok := rows[u]; !ok {
+				continue
+			}
+			for date, load := range taskDayLoads(t.Start, t.End, days, calendars[u], company) {
+				if date < monday || date > end {
+					continue
+				}
+				row := rows[u][weekOf(date)]
+				row.Load += load
+				if !inStrings(row.Tasks, t.Key) {
+					row.Tasks = append(row.Tasks, t.Key)
+				}
+			}
+		}
+	}
+	// 팀별 합계를 계산한다.
+	teamRows := make(map[string]map[string]*CapacityRow)
+	for _, u := range users {
+		for week, row := range rows[u] {
+			row.Overbooked = row.Load > row.Capacity+0.01
+			report.Users = append(report.Users, *row)
+			team := teams[u]
+			if team == "" {
+				continue
+			}
+			if _, ok := teamRows[team]; !ok {
+				teamRows[team] = make(map[string]*CapacityRow)
+			}
+			if _, ok := teamRows[team][week]; !ok {
+				teamRows[team][week] = &CapacityRow{Week: week, Team: team, Tasks: []string{}}
+			}
+			teamRows[team][week].Capacity += row.Capacity
+			teamRows[team][week].Load += row.Load
+			for _, key := range row.Tasks {
+				if !inStrings(teamRows[team][week].Tasks, key) {
+					teamRows[team][week].Tasks = append(teamRows[team][week].Tasks, key)
+				}
+			}
+		}
+	}
+	for _, byWeek := range teamRows {
+		for _, row := range byWeek {
+			row.Overbooked = row.Load > row.Capacity+0.01
+			report.Teams = append(report.Teams, *row)
+		}
+	}
+	for _, row := range unassigned {
+		report.Unassigned = append(report.Unassigned, *row)
+	}
+	sort.Slice(report.Users, func(i, j int) bool {
+		if report.Users[i].User != report.Users[j].User {
+			return report.Users[i].User < report.Users[j].User
+		}
+		return report.Users[i].Week < report.Users[j].Week
+	})
+	sort.Slice(report.Teams, func(i, j int) bool {
+		if report.Teams[i].Team != report.Teams[j].Team {
+			return report.Teams[i].Team < report.Teams[j].Team
+		}
+		return report.Teams[i].Week < report.Teams[j].Week
+	})
+	sort.Slice(report.Unassigned, func(i, j int) bool { return report.Unassigned[i].Week < report.Unassigned[j].Week })
+	return report, nil
+}
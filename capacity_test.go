@@ -0,0 +1,62 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWorkCalendarCapacity(t *testing.T) {
+	company := WorkCalendar{UserID: CompanyCalendarID, Leaves: []Leave{{Start: "2020-11-04", End: "2020-11-04", Type: "holiday"}}}
+	cases := []struct {
+		cal  WorkCalendar
+		date string
+		want float64
+	}{
+		{cal: WorkCalendar{}, date: "2020-11-02", want: 1}, // 월요일
+		{cal: WorkCalendar{}, date: "2020-11-07", want: 0}, // 토요일
+		{cal: WorkCalendar{}, date: "2020-11-04", want: 0}, // 회사 휴일
+		{cal: WorkCalendar{HoursPerDay: 4}, date: "2020-11-02", want: 0.5},
+		{cal: WorkCalendar{WorkDays: []int{2, 4}}, date: "2020-11-02", want: 0},
+		{cal: WorkCalendar{Leaves: []Leave{{Start: "2020-11-02", End: "2020-11-03", Type: "leave"}}}, date: "2020-11-03", want: 0},
+		{cal: WorkCalendar{Leaves: []Leave{{Start: "2020-11-05", End: "2020-11-05", Type: "halfday"}}}, date: "2020-11-05", want: 0.5},
+	}
+	for _, c := range cases {
+		got := c.cal.Capacity(c.date, company)
+		if got != c.want {
+			t.Fatalf("TestWorkCalendarCapacity(%v, %v): 얻은 값 %v, 원하는 값 %v", c.cal, c.date, got, c.want)
+		}
+	}
+}
+
+func TestBuildCapacityReport(t *testing.T) {
+	tasks := []capacityTask{
+		{Key: "TEMP/SS_0010_org:comp", Start: "2020-11-02", End: "2020-11-06", Shares: map[string]float64{"kim": 5}, Days: 5},
+		{Key: "TEMP/SS_0020_org:comp", Start: "2020-11-05", End: "2020-11-10", Shares: map[string]float64{"kim": 2, "lee": 2}, Days: 4},
+		{Key: "TEMP/SS_0030_org:comp", Start: "2020-11-02", End: "2020-11-03", Days: 2},
+	}
+	calendars := map[string]WorkCalendar{"lee": {UserID: "lee", HoursPerDay: 4}}
+	teams := map[string]string{"kim": "comp1", "lee": "comp1"}
+	report, err := buildCapacityReport("2020-11-04", 2, tasks, []string{"kim", "lee"}, calendars, teams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		row        CapacityRow
+		capacity   float64
+		load       float64
+		overbooked bool
+	}{
+		{row: report.Users[0], capacity: 5, load: 6, overbooked: true},    // kim 첫째 주: 5 + 2일 중 2/4
+		{row: report.Users[1], capacity: 5, load: 1, overbooked: false},   // kim 둘째 주
+		{row: report.Users[2], capacity: 2.5, load: 1, overbooked: false}, // lee 파트타임
+		{row: report.Teams[0], capacity: 7.5, load: 7, overbooked: false},
+	}
+	for _, c := range cases {
+		if c.row.Capacity != c.capacity || math.Abs(c.row.Load-c.load) > 0.001 || c.row.Overbooked != c.overbooked {
+			t.Fatalf("TestBuildCapacityReport(%v %v %v): 얻은 값 %v/%v(%v), 원하는 값 %v/%v(%v)", c.row.User, c.row.Team, c.row.Week, c.row.Load, c.row.Capacity, c.row.Overbooked, c.load, c.capacity, c.overbooked)
+		}
+	}
+	if len(report.Unassigned) != 1 || report.Unassigned[0].Load != 2 {
+		t.Fatalf("TestBuildCapacityReport(unassigned): 얻은 값 %v, 원하는 값 %v", report.Unassigned, 2)
+	}
+}
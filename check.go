@@ -47,6 +47,9 @@ var regexpRollMedia = regexp.MustCompile(`^\d+_[A-Z0-9]+_\d+_[A-Z0-9]+$`)
 // Timecode 정규식: 00:00:00:00 또는 00:00:00;00 형태 ref: https://en.wikipedia.org/wiki/SMPTE_timecode
 var regexpTimecode = regexp.MustCompile(`^\d{2}[:;.]\d{2}[:;.]\d{2}[:;.]\d{2}$`)
 
+// 재스캔, 소스 타입 정규식: org1, left2, src, src1 형태. 원본 샷의 플레이트 버전으로 관리된다.
+var regexpRescanType = regexp.MustCompile(`^(org|left)\d+$|^src\d*$`)
+
 // Rnum 정규식: A0001~Z9999
 var regexpRnum = regexp.MustCompile(`^[A-Z]\d{4}$`)
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"fmt"
 	"html/template"
 	"log"
 	"os/user"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 )
 
 func addProjectCmd(name string) {
@@ -53,6 +55,10 @@ func addShotItemCmd(project, name, typ, platesize, scanname, scantimecodein, sca
 		log.Fatal(err)
 	}
 	defer session.Close()
+	author, err := user.Current()
+	if err != nil {
+		log.Fatal(err)
+	}
 	initStatusID, err := GetInitStatusID(session)
 	if err != nil {
 		log.Fatal(err)
@@ -189,7 +195,7 @@ func addShotItemCmd(project, name, typ, platesize, scanname, scantimecodein, sca
 		i.Rollmedia = rollmedia
 	}
 
-	err = addItem(session, project, i)
+	err = addItem(session, project, author.Username, i)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -209,6 +215,10 @@ func addAssetItemCmd(project, name, typ, assettype, assettags string) {
 		log.Fatal(err)
 	}
 	defer session.Close()
+	author, err := user.Current()
+	if err != nil {
+		log.Fatal(err)
+	}
 	initStatusID, err := GetInitStatusID(session)
 	if err != nil {
 		log.Fatal(err)
@@ -255,7 +265,7 @@ func addAssetItemCmd(project, name, typ, assettype, assettags string) {
 		}
 		i.Assettags = append(i.Assettags, tag)
 	}
-	err = addItem(session, project, i)
+	err = addItem(session, project, author.Username, i)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -272,6 +282,10 @@ func addOtherItemCmd(project, name, typ, platesize, scanname, scantimecodein, sc
 		log.Fatal(err)
 	}
 	defer session.Close()
+	author, err := user.Current()
+	if err != nil {
+		log.Fatal(err)
+	}
 	admin, err := GetAdminSetting(session)
 	if err != nil {
 		log.Fatal(err)
@@ -365,7 +379,7 @@ func addOtherItemCmd(project, name, typ, platesize, scanname, scantimecodein, sc
 		i.Rollmedia = rollmedia
 	}
 
-	err = addItem(session, project, i)
+	err = addItem(session, project, author.Username, i)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -386,7 +400,7 @@ func addOtherItemCmd(project, name, typ, platesize, scanname, scantimecodein, sc
 	// org1, left1 형태의 아이템이 처리되면 org, left 아이템의 .UseType을 추가해준다.
 	// 이 값은 썸네일을 업데이트하고, 아티스트가 재스캔 되었을 때 사용할 타입의 알람으로 사용된다.
 	if strings.Contains(typ, "org") || strings.Contains(typ, "left") {
-		err = SetUseType(session, project, i.ID, typ)
+		err = SetUseType(session, project, i.ID, "scantool", typ)
 		if err != nil {
 			log.Println(err)
 		}
@@ -406,7 +420,123 @@ func rmItemCmd(project, name, typ string) {
 		log.Fatal(err)
 	}
 	defer session.Close()
-	err = rmItem(session, project, name, typ)
+	err = rmItem(session, project, name, user.Username, typ)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// addPlateCmd 함수는 org1, left1, src1 형태의 재스캔, 소스 플레이트를 원본 샷의 플레이트 버전으로 추가한다.
+// active가 true이면 추가한 플레이트를 사용하도록 설정하고 썸네일 mov 경로를 업데이트한다.
+func addPlateCmd(project, name, typ, platesize, scanname, scantimecodein, scantimecodeout, justtimecodein, justtimecodeout string, scanframe, scanin, scanout, platein, plateout, justin, justout int, active bool) {
+	if !regexpShotname.MatchString(name) {
+		log.Fatal("소스, 재스캔 이름 규칙이 아닙니다.")
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer session.Close()
+	admin, err := GetAdminSetting(session)
+	if err != nil {
+		log.Fatal(err)
+	}
+	id := name + "_" + plateParentType(typ)
+	// 입력하지 않은 프레임(-1)은 0으로 설정한다.
+	frame := func(n int) int {
+		if n == -1 {
+			return 0
+		}
+		return n
+	}
+	p := Plate{
+		Type:            typ,
+		Scanname:        scanname,
+		Dataname:        scanname, // 일반적인 프로젝트는 스캔네임과 데이터네임이 같다.
+		Platesize:       platesize,
+		Platepath:       *flagPlatePath,
+		Scantime:        time.Now().Format(time.RFC3339),
+		ScanFrame:       scanframe,
+		ScanTimecodeIn:  scantimecodein,
+		ScanTimecodeOut: scantimecodeout,
+		JustTimecodeIn:  justtimecodein,
+		JustTimecodeOut: justtimecodeout,
+		ScanIn:          frame(scanin),
+		ScanOut:         frame(scanout),
+		PlateIn:         frame(platein),
+		PlateOut:        frame(plateout),
+		JustIn:          frame(platein),
+		JustOut:         frame(plateout),
+	}
+	if justin != -1 {
+		p.JustIn = justin
+	}
+	if justout != -1 {
+		p.JustOut = justout
+	}
+	if p.Platepath == "" {
+		// 만약 빈값이라면 adminSetting의 설정값을 이용해서 설정한다. 플레이트 경로는 재스캔 타입 기준이다.
+		var platePath bytes.Buffer
+		platePathTmpl, err := template.New("platePath").Parse(admin.PlatePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		i := Item{Project: project, Name: name, Type: typ, ID: name + "_" + typ, Season: *flagSeason, Episode: *flagEpisode}
+		i.SetSeq()
+		err = platePathTmpl.Execute(&platePath, i)
+		if err != nil {
+			log.Fatal(err)
+		}
+		p.Platepath = platePath.String()
+	}
+	item, err := AddPlate(session, project, id, "scantool", p, active)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !active {
+		return
+	}
+	// 사용하는 플레이트가 바뀌었으므로 adminsetting 값을 가지고와서 Thumbnailmov 값을 설정한다.
+	thummov := *flagThumbnailMovPath
+	if thummov == "" {
+		var thumbnailMovPath bytes.Buffer
+		thumbnailMovPathTmpl, err := template.New("thumbnailMovPath").Parse(admin.ThumbnailMovPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = thumbnailMovPathTmpl.Execute(&thumbnailMovPath, item)
+		if err != nil {
+			log.Fatal(err)
+		}
+		thummov = thumbnailMovPath.String()
+	}
+	err = updateItem(session, project, id, "scantool", "AddPlate", bson.M{"$set": bson.M{"thummov": thummov}})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// migratePlatesCmd 함수는 프로젝트의 org1, left1, src1 형태의 아이템을 원본 샷의 플레이트 버전으로 옮긴다.
+func migratePlatesCmd(project string) {
+	user, err := user.Current()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if user.Username != "root" {
+		log.Fatal("루트계정이 아닙니다.")
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer session.Close()
+	migrated, skipped, err := MigratePlates(session, project, user.Username)
+	for _, id := range migrated {
+		fmt.Printf("%s: 플레이트 버전으로 옮겼습니다.\n", id)
+	}
+	for _, reason := range skipped {
+		fmt.Printf("%s: 옮기면 데이터를 잃기 때문에 옮기지 않았습니다.\n", reason)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
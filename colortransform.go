@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ColorTransform 자료구조는 리뷰 동영상을 만들 때 적용된 컬러 변환 정보이다.
+type ColorTransform struct {
+	Type            string `json:"type"`            // 적용된 변환: none, ocio, lut, ocio+lut
+	InColorspace    string `json:"incolorspace"`    // 리뷰 소스의 컬러스페이스
+	OutColorspace   string `json:"outcolorspace"`   // 리뷰 동영상의 컬러스페이스
+	LutInColorspace string `json:"lutincolorspace"` // ocio+lut 일 때 LUT 적용 전 OCIO로 변환한 컬러스페이스
+	Lut             string `json:"lut"`             // 적용된 LUT 파일 경로
+	OCIOConfig      string `json:"ocioconfig"`      // 사용된 OCIO config 경로
+	Warning         string `json:"warning"`         // 변환을 적용하지 못한 이유
+}
+
+// ffmpegLutExts 는 FFmpeg lut3d 필터가 직접 읽을 수 있는 LUT 확장자이다.
+var ffmpegLutExts = []string{".cube", ".3dl", ".dat", ".m3d", ".csp"}
+
+// validColorspace 함수는 컬러스페이스 값이 변환에 사용할 수 있는 값인지 체크한다.
+// editProject 페이지의 "default" 값은 컬러스페이스가 설정되지 않은 것으로 본다.
+func validColorspace(cs string) bool {
+	return cs != "" && cs != "default"
+}
+
+// planColorTransform 함수는 프로젝트 설정과 리뷰 소스의 컬러스페이스를 이용해서 적용할 컬러 변환을 결정한다.
+// 프로젝트에 LUT가 설정되어 있다면 LUT를 적용하고, 소스 컬러스페이스가 LUT IN 컬러스페이스와 다르다면 OCIO 변환을 먼저 적용한다.
+// LUT가 없다면 컨펌용 MOV 포멧의 IN, OUT 컬러스페이스를 이용해서 OCIO 변환을 적용한다.
+func planColorTransform(admin Setting, p Project, inColorspace string) ColorTransform {
+	if !validColorspace(inColorspace) {
+		inColorspace = p.OutputMov.InColorspace
+	}
+	ct := ColorTransform{Type: "none", InColorspace: inColorspace}
+	hasOCIO := admin.OCIOConfig != "" && admin.OCIOBakeLut != ""
+	if p.Lut != "" {
+		ct.Type = "lut"
+		ct.Lut = p.Lut
+		ct.OutColorspace = p.LutOutColorspace
+		if validColorspace(inColorspace) && validColorspace(p.LutInColorspace) && inColorspace != p.LutInColorspace {
+			if !hasOCIO {
+				ct.Warning = "OCIOConfig, OCIOBakeLut 설정이 없어서 " + inColorspace + " > " + p.LutInColorspace + " 변환 없이 LUT만 적용합니다"
+				return ct
+			}
+			ct.Type = "ocio+lut"
+			ct.LutInColorspace = p.LutInColorspace
+			ct.OCIOConfig = admin.OCIOConfig
+		}
+		if !hasExt(p.Lut, ffmpegLutExts) && !hasOCIO {
+			return ColorTransform{Type: "none", InColorspace: inColorspace, Warning: "FFmpeg에서 지원하지 않는 LUT 포멧이고 OCIOBakeLut 설정이 없습니다: " + p.Lut}
+		}
+		return ct
+	}
+	ct.OutColorspace = p.OutputMov.OutColorspace
+	if !validColorspace(inColorspace) || !validColorspace(ct.OutColorspace) || inColorspace == ct.OutColorspace {
+		ct.OutColorspace = ""
+		return ct
+	}
+	if !hasOCIO {
+		ct.Warning = "OCIOConfig, OCIOBakeLut 설정이 없어서 " + inColorspace + " > " + ct.OutColorspace + " 변환을 적용하지 못했습니다"
+		ct.OutColorspace = ""
+		return ct
+	}
+	ct.Type = "ocio"
+	ct.OCIOConfig = admin.OCIOConfig
+	return ct
+}
+
+// hasExt 함수는 경로의 확장자가 exts에 포함되어 있는지 체크한다. 대소문자는 구분하지 않는다.
+func hasExt(path string, exts []string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range exts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// String 메소드는 리뷰 로그에 남길 컬러 변환 정보를 반환한다.
+func (c ColorTransform) String() string {
+	switch c.Type {
+	case "ocio":
+		return "ocio: " + c.InColorspace + " > " + c.OutColorspace
+	case "lut":
+		return "lut: " + c.Lut
+	case "ocio+lut":
+		return "ocio: " + c.InColorspace + " > " + c.LutInColorspace + ", lut: " + c.Lut
+	}
+	if c.Warning != "" {
+		return "none: " + c.Warning
+	}
+	return "none"
+}
+
+// Filters 메소드는 컬러 변환을 FFmpeg lut3d 필터 리스트로 만든다.
+// OCIO 변환이 필요하면 ociobakelut 명령어로 dir 경로에 .cube 파일을 생성한다. 생성된 파일은 렌더링 이후 dir와 함께 삭제한다.
+func (c ColorTransform) Filters(admin Setting, dir string) ([]string, error) {
+	var filters []string
+	if c.Type == "ocio" || c.Type == "ocio+lut" {
+		out := c.OutColorspace
+		if c.Type == "ocio+lut" {
+			out = c.LutInColorspace
+		}
+		cube := filepath.Join(dir, "ocio.cube")
+		err := bakeLut(admin, []string{"--iconfig", c.OCIOConfig, "--inputspace", c.InColorspace, "--outputspace", out}, cube)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, lut3dFilter(cube))
+	}
+	if c.Type == "lut" || c.Type == "ocio+lut" {
+		if _, err := os.Stat(c.Lut); err != nil {
+			return nil, err
+		}
+		lut := c.Lut
+		if !hasExt(lut, ffmpegLutExts) {
+			// FFmpeg가 읽지 못하는 LUT 포멧은 .cube로 변환한다.
+			lut = filepath.Join(dir, "lut.cube")
+			err := bakeLut(admin, []string{"--lut", c.Lut}, lut)
+			if err != nil {
+				return nil, err
+			}
+		}
+		filters = append(filters, lut3dFilter(lut))
+	}
+	return filters, nil
+}
+
+// bakeLut 함수는 ociobakelut 명령어를 이용해서 .cube 파일을 생성한다.
+func bakeLut(admin Setting, args []string, output string) error {
+	if _, err := os.Stat(admin.OCIOBakeLut); os.IsNotExist(err) {
+		return errors.New("ociobakelut가 존재하지 않습니다")
+	}
+	args = append(args, "--format", "resolve_cube", "--cubesize", "65", output)
+	out, err := exec.Command(admin.OCIOBakeLut, args...).CombinedOutput()
+	if err != nil {
+		return errors.New(err.Error() + ": " + lastLines(string(out), 5))
+	}
+	return nil
+}
+
+// lut3dFilter 함수는 LUT 파일을 적용하는 FFmpeg lut3d 필터를 반환한다.
+func lut3dFilter(path string) string {
+	return "lut3d=" + escapeFFmpeg("file="+escapeFFmpeg(path, `\':`), `\'[],;`)
+}
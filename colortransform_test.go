@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestPlanColorTransform(t *testing.T) {
+	ocio := Setting{OCIOConfig: "/ocio/config.ocio", OCIOBakeLut: "/usr/bin/ociobakelut"}
+	confirm := Mov{InColorspace: "ACES - ACEScg", OutColorspace: "Output - Rec.709"}
+	cases := []struct {
+		name  string
+		admin Setting
+		p     Project
+		in    string
+		want  string
+	}{{
+		name: "no setting",
+		p:    Project{},
+		want: "none",
+	}, {
+		name:  "ocio",
+		admin: ocio,
+		p:     Project{OutputMov: confirm},
+		want:  "ocio: ACES - ACEScg > Output - Rec.709",
+	}, {
+		name:  "review colorspace",
+		admin: ocio,
+		p:     Project{OutputMov: confirm},
+		in:    "Input - ARRI - V3 LogC (EI800) - Wide Gamut",
+		want:  "ocio: Input - ARRI - V3 LogC (EI800) - Wide Gamut > Output - Rec.709",
+	}, {
+		name: "ocio without ociobakelut",
+		p:    Project{OutputMov: confirm},
+		want: "none: OCIOConfig, OCIOBakeLut 설정이 없어서 ACES - ACEScg > Output - Rec.709 변환을 적용하지 못했습니다",
+	}, {
+		name: "lut",
+		p:    Project{Lut: "/show/lut/show.cube", LutInColorspace: "ACES - ACEScg", OutputMov: confirm},
+		want: "lut: /show/lut/show.cube",
+	}, {
+		name:  "ocio+lut",
+		admin: ocio,
+		p:     Project{Lut: "/show/lut/show.cube", LutInColorspace: "ACES - ACEScct", OutputMov: confirm},
+		want:  "ocio: ACES - ACEScg > ACES - ACEScct, lut: /show/lut/show.cube",
+	}, {
+		name: "unsupported lut",
+		p:    Project{Lut: "/show/lut/show.spi3d"},
+		want: "none: FFmpeg에서 지원하지 않는 LUT 포멧이고 OCIOBakeLut 설정이 없습니다: /show/lut/show.spi3d",
+	}}
+	for _, c := range cases {
+		got := planColorTransform(c.admin, c.p, c.in).String()
+		if got != c.want {
+			t.Fatalf("TestPlanColorTransform(%v): 얻은 값 %v, 원하는 값 %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestLut3dFilter(t *testing.T) {
+	got := lut3dFilter("/show/lut/show's,lut.cube")
+	want := `lut3d=file=/show/lut/show\\\'s\,lut.cube`
+	if got != want {
+		t.Fatalf("TestLut3dFilter: 얻은 값 %v, 원하는 값 %v", got, want)
+	}
+}
@@ -1,13 +1,103 @@
 package main
 
+import (
+	"errors"
+	"fmt"
+)
+
 // Comment 자료구조는 코맨트 글을 작성할 때 사용하는 자료구조이다.
 type Comment struct {
-	Date       string `json:"date"`       // 코맨트 등록시간 RFC3339
-	Author     string `json:"author"`     // 작성자 ID
-	AuthorName string `json:"authorname"` // 작성자 표기명
-	Text       string `json:"text"`       // 내용
-	Media      string `json:"media"`      // media 경로
-	MediaTitle string `json:"mediatitle"` // media 제목
-	Stage      string `json:"stage"`      // 코멘트가 달린 리뷰 Stage
-	Frame      int    `json:"frame"`      // 코맨트 프레임수
+	ID           string `json:"id"`           // 코멘트 ID. 이전에 작성된 코멘트는 ID가 없고 Date로 구분한다.
+	Date         string `json:"date"`         // 코맨트 등록시간 RFC3339
+	Author       string `json:"author"`       // 작성자 ID
+	AuthorName   string `json:"authorname"`   // 작성자 표기명
+	Text         string `json:"text"`         // 내용
+	Media        string `json:"media"`        // media 경로
+	MediaTitle   string `json:"mediatitle"`   // media 제목
+	Stage        string `json:"stage"`        // 코멘트가 달린 리뷰 Stage
+	Frame        int    `json:"frame"`        // 코맨트 프레임수
+	Playlist     string `json:"playlist"`     // 데일리 세션에서 작성된 코멘트라면 플레이리스트 ID
+	Region       Region `json:"region"`       // 프레임에서 코멘트가 가리키는 영역
+	Parent       string `json:"parent"`       // 답글이라면 부모 코멘트 ID
+	Resolved     bool   `json:"resolved"`     // 수정사항 해결 여부
+	ResolvedBy   string `json:"resolvedby"`   // 해결 처리한 사용자 ID
+	ResolvedTime string `json:"resolvedtime"` // 해결 처리한 시간 RFC3339
+}
+
+// Region 자료구조는 코멘트가 가리키는 프레임의 영역이다.
+// 좌표는 해상도에 상관없이 사용할 수 있도록 왼쪽 위를 0, 오른쪽 아래를 1로 정규화한 값이다.
+type Region struct {
+	Type   string  `json:"type"`   // 영역 타입: point, rect. 빈 문자열이면 영역이 없는 코멘트이다.
+	X      float64 `json:"x"`      // 점의 x 좌표 또는 사각형 왼쪽 위의 x 좌표
+	Y      float64 `json:"y"`      // 점의 y 좌표 또는 사각형 왼쪽 위의 y 좌표
+	Width  float64 `json:"width"`  // 사각형 너비
+	Height float64 `json:"height"` // 사각형 높이
+}
+
+// CommentThread 자료구조는 코멘트와 답글 리스트이다.
+type CommentThread struct {
+	Comment
+	Replies []Comment `json:"replies"`
+}
+
+// Key 메소드는 코멘트를 구분하는 값을 반환한다. ID가 없는 이전 코멘트는 Date를 사용한다.
+func (c Comment) Key() string {
+	if c.ID != "" {
+		return c.ID
+	}
+	return c.Date
+}
+
+// IsOpenNote 메소드는 해결되지 않은 수정사항인지 체크한다.
+// 답글과 ID가 없는 이전 코멘트는 수정사항으로 취급하지 않는다.
+func (c Comment) IsOpenNote() bool {
+	return c.ID != "" && c.Parent == "" && !c.Resolved
+}
+
+// CheckError 메소드는 Region 자료구조의 에러를 체크한다.
+func (r Region) CheckError() error {
+	inRange := func(v float64) bool {
+		return v >= 0 && v <= 1
+	}
+	switch r.Type {
+	case "":
+		return nil
+	case "point":
+		if !inRange(r.X) || !inRange(r.Y) {
+			return errors.New("point 좌표는 0과 1 사이의 값이어야 합니다")
+		}
+	case "rect":
+		if !inRange(r.X) || !inRange(r.Y) || r.Width <= 0 || r.Height <= 0 || !inRange(r.X+r.Width) || !inRange(r.Y+r.Height) {
+			return errors.New("rect 영역은 0과 1 사이에 있어야 하고 width, height는 0보다 커야 합니다")
+		}
+	default:
+		return fmt.Errorf("%s 는 사용할 수 없는 영역 타입입니다. point, rect 타입만 사용가능합니다", r.Type)
+	}
+	return nil
+}
+
+// commentThreads 함수는 코멘트 리스트를 부모 코멘트와 답글로 묶는다. 부모 코멘트는 작성 순서를 유지한다.
+// 부모 코멘트가 삭제된 답글은 별도의 스레드로 취급한다.
+func commentThreads(comments []Comment) []CommentThread {
+	index := make(map[string]int)
+	var threads []CommentThread
+	for _, c := range comments {
+		if c.Parent != "" {
+			continue
+		}
+		index[c.Key()] = len(threads)
+		threads = append(threads, CommentThread{Comment: c, Replies: []Comment{}})
+	}
+	for _, c := range comments {
+		if c.Parent == "" {
+			continue
+		}
+		i, ok := index[c.Parent]
+		if !ok {
+			threads = append(threads, CommentThread{Comment: c, Replies: []Comment{}})
+			continue
+		}
+		threads[i].Replies = append(threads[i].Replies, c)
+	}
+	return threads
 }
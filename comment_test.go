@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestRegionCheckError(t *testing.T) {
+	cases := []struct {
+		in  Region
+		err bool
+	}{{
+		in: Region{},
+	}, {
+		in: Region{Type: "point", X: 0.5, Y: 1},
+	}, {
+		in:  Region{Type: "point", X: 1.2, Y: 0.5},
+		err: true,
+	}, {
+		in: Region{Type: "rect", X: 0.2, Y: 0.3, Width: 0.5, Height: 0.7},
+	}, {
+		in:  Region{Type: "rect", X: 0.8, Y: 0.3, Width: 0.5, Height: 0.2},
+		err: true,
+	}, {
+		in:  Region{Type: "rect", X: 0.2, Y: 0.3},
+		err: true,
+	}, {
+		in:  Region{Type: "circle", X: 0.2, Y: 0.3},
+		err: true,
+	}}
+	for _, c := range cases {
+		err := c.in.CheckError()
+		if (err != nil) != c.err {
+			t.Fatalf("TestRegionCheckError(%v): 얻은 값 %v, 원하는 에러여부 %v", c.in, err, c.err)
+		}
+	}
+}
+
+func TestCommentThreads(t *testing.T) {
+	comments := []Comment{
+		{ID: "a", Text: "note a"},
+		{Date: "2020-10-15T10:00:00+09:00", Text: "legacy"},
+		{ID: "b", Parent: "a", Text: "reply a"},
+		{ID: "c", Parent: "2020-10-15T10:00:00+09:00", Text: "reply legacy"},
+		{ID: "d", Parent: "removed", Text: "orphan"},
+	}
+	threads := commentThreads(comments)
+	if len(threads) != 3 {
+		t.Fatalf("TestCommentThreads: 얻은 스레드 개수 %v, 원하는 값 %v", len(threads), 3)
+	}
+	if len(threads[0].Replies) != 1 || threads[0].Replies[0].ID != "b" {
+		t.Fatalf("TestCommentThreads: 얻은 값 %v", threads[0])
+	}
+	if len(threads[1].Replies) != 1 || threads[1].Replies[0].ID != "c" {
+		t.Fatalf("TestCommentThreads: 얻은 값 %v", threads[1])
+	}
+	if threads[2].ID != "d" {
+		t.Fatalf("TestCommentThreads: 얻은 값 %v", threads[2])
+	}
+}
+
+func TestCheckApprove(t *testing.T) {
+	open := Review{Comments: []Comment{{ID: "a"}, {ID: "b", Parent: "a"}, {Date: "legacy"}}}
+	resolved := Review{Comments: []Comment{{ID: "a", Resolved: true}, {ID: "b", Parent: "a"}}}
+	cases := []struct {
+		review   Review
+		override bool
+		level    AccessLevel
+		err      bool
+	}{{
+		review: resolved,
+		level:  ArtistAccessLevel,
+	}, {
+		review: open,
+		level:  SupervisorAccessLevel,
+		err:    true,
+	}, {
+		review:   open,
+		override: true,
+		level:    LeadAccessLevel,
+		err:      true,
+	}, {
+		review:   open,
+		override: true,
+		level:    SupervisorAccessLevel,
+	}}
+	for n, c := range cases {
+		err := c.review.CheckApprove(c.override, c.level)
+		if (err != nil) != c.err {
+			t.Fatalf("TestCheckApprove(%d): 얻은 값 %v, 원하는 에러여부 %v", n, err, c.err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CompareSourceFrame 자료구조는 비교 리뷰의 특정 프레임에 대응되는 원본 리뷰의 프레임 정보이다.
+type CompareSourceFrame struct {
+	ID          string  `json:"id"`          // 원본 리뷰 ID
+	Panel       int     `json:"panel"`       // 화면 위치. sidebyside는 왼쪽부터 0, 1, 2.., wipe는 0: 왼쪽, 1: 오른쪽
+	MainVersion int     `json:"mainversion"` // Main Version
+	SubVersion  int     `json:"subversion"`  // Sub Version
+	Fps         float64 `json:"fps"`         // 원본 리뷰 FPS
+	Frame       int     `json:"frame"`       // 원본 리뷰 동영상 기준 프레임. 1부터 시작한다.
+	SourceFrame int     `json:"sourceframe"` // sequence 리뷰라면 FrameIn을 더한 원본 프레임 번호. 아니라면 Frame과 같다.
+	Ended       bool    `json:"ended"`       // 원본이 비교 리뷰보다 짧아서 마지막 프레임이 유지되고 있는지 여부
+}
+
+// checkCompareSources 함수는 비교 리뷰에 사용할 원본 리뷰들이 같은 프로젝트, 이름, 태스크를 가지고 있고 연산이 끝났는지 체크한다.
+func checkCompareSources(mode string, sources []Review) error {
+	if len(sources) < 2 {
+		return errors.New("비교 리뷰는 2개 이상의 리뷰가 필요합니다")
+	}
+	switch mode {
+	case "sidebyside":
+	case "wipe":
+		if len(sources) != 2 {
+			return errors.New("wipe 비교 리뷰는 2개의 리뷰만 사용할 수 있습니다")
+		}
+	default:
+		return errors.New("mode는 sidebyside, wipe 값만 사용가능합니다")
+	}
+	first := sources[0]
+	for _, s := range sources {
+		if s.Project != first.Project || s.Name != first.Name || s.Task != first.Task {
+			return fmt.Errorf("%s 리뷰는 %s %s %s 리뷰가 아닙니다", s.ID.Hex(), first.Project, first.Name, first.Task)
+		}
+		if !(s.Type == "clip" || s.Type == "sequence") {
+			return fmt.Errorf("%s 리뷰는 %s 타입입니다. clip, sequence 타입만 비교할 수 있습니다", s.ID.Hex(), s.Type)
+		}
+		if s.ProcessStatus != "done" {
+			return fmt.Errorf("%s 리뷰는 아직 연산이 끝나지 않았습니다", s.ID.Hex())
+		}
+	}
+	return nil
+}
+
+// compareFilterGraph 함수는 원본 리뷰 동영상을 합성하는 FFmpeg 필터그래프를 만든다.
+// 각 입력은 같은 FPS, 높이로 맞추고 왼쪽 위에 버전 라벨을 새긴다.
+// sidebyside는 입력을 가로로 붙이고, wipe는 두번째 입력을 첫번째 입력 크기로 맞춘 뒤 wipe 위치부터 오른쪽을 두번째 입력으로 채운다.
+func compareFilterGraph(mode string, labels []string, height int, fps, wipe float64, fontfile string) string {
+	r := strconv.FormatFloat(fps, 'f', -1, 64)
+	var chains []string
+	var pads []string
+	for i, label := range labels {
+		x := "10"
+		if mode == "wipe" && i == 1 {
+			x = "w-tw-10"
+		}
+		chain := fmt.Sprintf("[%d:v]fps=%s,scale=-2:%d,setsar=1,format=yuv420p,%s[v%d]", i, r, height, drawtextFilter(escapeDrawtext(label), x, "10", 24, fontfile), i)
+		chains = append(chains, chain)
+		pads = append(pads, fmt.Sprintf("[v%d]", i))
+	}
+	if mode == "wipe" {
+		p := strconv.FormatFloat(wipe, 'f', -1, 64)
+		chains = append(chains,
+			"[v1][v0]scale2ref[b][a]",
+			fmt.Sprintf("[b]crop=iw*(1-%s):ih:iw*%s:0[bc]", p, p),
+			fmt.Sprintf("[a][bc]overlay=W*%s:0,drawbox=x=iw*%s-1:y=0:w=2:h=ih:color=white@0.8:t=fill", p, p),
+		)
+		return strings.Join(chains, ";")
+	}
+	chains = append(chains, fmt.Sprintf("%shstack=inputs=%d", strings.Join(pads, ""), len(labels)))
+	return strings.Join(chains, ";")
+}
+
+// compareFrames 함수는 비교 리뷰의 frame에 대응되는 원본 리뷰들의 프레임 정보를 반환한다.
+// 비교 리뷰와 원본 리뷰의 FPS가 다를 수 있으므로 시간을 기준으로 프레임을 계산한다. frame은 1부터 시작한다.
+func compareFrames(compare Review, sources []Review, frame int) []CompareSourceFrame {
+	fps := compare.Fps
+	if fps <= 0 {
+		fps = 24
+	}
+	t := float64(frame-1) / fps
+	var results []CompareSourceFrame
+	for i, s := range sources {
+		sfps := s.Fps
+		if sfps <= 0 {
+			sfps = fps
+		}
+		f := CompareSourceFrame{
+			ID:          s.ID.Hex(),
+			Panel:       i,
+			MainVersion: s.MainVersion,
+			SubVersion:  s.SubVersion,
+			Fps:         s.Fps,
+			Frame:       int(math.Floor(t*sfps+1e-6)) + 1,
+		}
+		// 원본 길이를 알고 있다면 마지막 프레임을 넘어가지 않는다. FFmpeg는 짧은 입력의 마지막 프레임을 유지한다.
+		total := reviewTotalFrame(s)
+		if total > 0 && f.Frame > total {
+			f.Frame = total
+			f.Ended = true
+		}
+		f.SourceFrame = f.Frame
+		if s.Type == "sequence" {
+			f.SourceFrame = s.FrameIn + f.Frame - 1
+		}
+		results = append(results, f)
+	}
+	return results
+}
+
+// reviewTotalFrame 함수는 리뷰 동영상의 전체 프레임수를 반환한다. 알 수 없다면 0을 반환한다.
+func reviewTotalFrame(r Review) int {
+	if r.Type == "sequence" && r.FrameOut >= r.FrameIn {
+		return r.FrameOut - r.FrameIn + 1
+	}
+	if r.Duration > 0 && r.Fps > 0 {
+		return int(math.Round(r.Duration * r.Fps))
+	}
+	return 0
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCompareFilterGraph(t *testing.T) {
+	cases := []struct {
+		mode string
+		want string
+	}{{
+		mode: "sidebyside",
+		want: "[0:v]fps=24,scale=-2:1080,setsar=1,format=yuv420p,drawtext=text=v03_0:x=10:y=10:fontsize=24:fontcolor=white:box=1:boxcolor=black@0.5[v0];" +
+			"[1:v]fps=24,scale=-2:1080,setsar=1,format=yuv420p,drawtext=text=v05_1:x=10:y=10:fontsize=24:fontcolor=white:box=1:boxcolor=black@0.5[v1];" +
+			"[v0][v1]hstack=inputs=2",
+	}, {
+		mode: "wipe",
+		want: "[0:v]fps=24,scale=-2:1080,setsar=1,format=yuv420p,drawtext=text=v03_0:x=10:y=10:fontsize=24:fontcolor=white:box=1:boxcolor=black@0.5[v0];" +
+			"[1:v]fps=24,scale=-2:1080,setsar=1,format=yuv420p,drawtext=text=v05_1:x=w-tw-10:y=10:fontsize=24:fontcolor=white:box=1:boxcolor=black@0.5[v1];" +
+			"[v1][v0]scale2ref[b][a];[b]crop=iw*(1-0.5):ih:iw*0.5:0[bc];" +
+			"[a][bc]overlay=W*0.5:0,drawbox=x=iw*0.5-1:y=0:w=2:h=ih:color=white@0.8:t=fill",
+	}}
+	for _, c := range cases {
+		got := compareFilterGraph(c.mode, []string{"v03_0", "v05_1"}, 1080, 24, 0.5, "")
+		if got != c.want {
+			t.Fatalf("TestCompareFilterGraph(%v): 얻은 값 %v, 원하는 값 %v", c.mode, got, c.want)
+		}
+	}
+}
+
+func TestCompareFrames(t *testing.T) {
+	compare := Review{Fps: 24}
+	sources := []Review{
+		{Type: "clip", Fps: 24, Duration: 2},                       // 48 프레임
+		{Type: "clip", Fps: 48, Duration: 4},                       // 192 프레임
+		{Type: "sequence", Fps: 24, FrameIn: 1001, FrameOut: 1010}, // 10 프레임
+	}
+	cases := []struct {
+		frame  int
+		frames []int
+		source int
+		ended  bool
+	}{{
+		frame:  1,
+		frames: []int{1, 1, 1},
+		source: 1001,
+	}, {
+		frame:  10,
+		frames: []int{10, 19, 10},
+		source: 1010,
+	}, {
+		frame:  60,
+		frames: []int{48, 119, 10},
+		source: 1010,
+		ended:  true,
+	}}
+	for _, c := range cases {
+		got := compareFrames(compare, sources, c.frame)
+		for i, f := range c.frames {
+			if got[i].Frame != f {
+				t.Fatalf("TestCompareFrames(%d): %d번 원본 얻은 값 %v, 원하는 값 %v", c.frame, i, got[i].Frame, f)
+			}
+		}
+		if got[2].SourceFrame != c.source || got[2].Ended != c.ended {
+			t.Fatalf("TestCompareFrames(%d): 얻은 값 %v, 원하는 값 %v %v", c.frame, got[2], c.source, c.ended)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bytes"
 	"errors"
 	"flag"
 	"fmt"
@@ -56,9 +55,13 @@ var (
 	flagCertFullchanin = flag.String("certfullchanin", fmt.Sprintf("/etc/letsencrypt/live/%s/fullchain.pem", DNS), "certification fullchain path")
 	flagCertPrivkey    = flag.String("certprivkey", fmt.Sprintf("/etc/letsencrypt/live/%s/privkey.pem", DNS), "certification privkey path")
 	// Process
-	flagProcessBufferSize = flag.Int("processbuffersize", 100, "process buffer size") // 최대 대기 리스트
-	flagMaxProcessNum     = flag.Int("maxprocessnum", 4, "max process number")        // 최대 연산 갯수
-	flagReviewRender      = flag.Bool("reviewrender", false, "ffmpeg를 이용해서 리뷰 렌더링을 허용하는 옵션")
+	flagProcessBufferSize    = flag.Int("processbuffersize", 100, "process buffer size") // legacy: 리뷰 작업큐가 DB에 저장되면서 사용하지 않는다.
+	flagMaxProcessNum        = flag.Int("maxprocessnum", 4, "max process number")        // 최대 연산 갯수
+	flagReviewRender         = flag.Bool("reviewrender", false, "ffmpeg를 이용해서 리뷰 렌더링을 허용하는 옵션")
+	flagReviewJobMaxAttempts = flag.Int("reviewjobmaxattempts", 3, "리뷰 연산이 실패했을 때 최대 시도 횟수")
+	// Digest
+	flagDigest = flag.Bool("digest", false, "사용자 다이제스트 알림(daily, weekly)을 보내는 스케줄러를 실행하는 옵션")
+	flagSMTP   = flag.String("smtp", "127.0.0.1:25", "다이제스트 메일을 보낼 SMTP 서버 주소")
 
 	// RV
 	flagRVPath = flag.String("rvpath", "/opt/rv-Linux-x86-64-7.0.0/bin/rv", "rvplayer path")
@@ -102,7 +105,8 @@ var (
 	flagJustout         = flag.Int("justout", -1, "Just Out Frame")
 	flagPlatein         = flag.Int("platein", -1, "플레이트 In Frame")
 	flagPlateout        = flag.Int("plateout", -1, "플레이트 Out Frame")
-	flagUpdateParent    = flag.Bool("updateparent", false, "org1,org2 형태의 재스캔 항목이라면 원본 org 정보를 업데이트 한다.")
+	flagUpdateParent    = flag.Bool("updateparent", false, "org1,src1 형태의 재스캔, 소스 플레이트를 원본 샷이 사용할 플레이트로 설정한다.")
+	flagMigratePlates   = flag.Bool("migrateplates", false, "org1,src1 형태로 등록된 재스캔, 소스 아이템을 원본 샷의 플레이트 버전으로 옮긴다.")
 )
 
 func main() {
@@ -265,6 +269,20 @@ func main() {
 			dilog.Add(*flagDBIP, ip, fmt.Sprintf("에셋타입 : %s, 에셋태그 : %s", *flagAssettype, *flagAssettags), *flagProject, *flagName+"_"+*flagType, "csi3", user.Username, 180)
 			return
 		default: //소스, 재스캔 추가
+			// org1, left1, src1 형태의 재스캔, 소스는 원본 샷의 플레이트 버전으로 추가한다.
+			if regexpRescanType.MatchString(*flagType) {
+				addPlateCmd(*flagProject, *flagName, *flagType, *flagPlatesize, *flagScanname, *flagScantimecodein, *flagScantimecodeout, *flagJusttimecodein, *flagJusttimecodeout, *flagScanframe, *flagScanin, *flagScanout, *flagPlatein, *flagPlateout, *flagJustin, *flagJustout, *flagUpdateParent)
+				logString := fmt.Sprintf("Add Plate: %s, Scanname: %s, ScanTimecode: %s(%d) / %s(%d) (Total:%df), Plate Range: %d - %d, Platesize: %s, Active: %t",
+					*flagType,
+					*flagScanname,
+					*flagScantimecodein, *flagScanin, *flagScantimecodeout, *flagScanout, *flagScanframe,
+					*flagPlatein, *flagPlateout,
+					*flagPlatesize,
+					*flagUpdateParent,
+				)
+				dilog.Add(*flagDBIP, ip, logString, *flagProject, *flagName+"_"+plateParentType(*flagType), "csi3", user.Username, 180)
+				return
+			}
 			addOtherItemCmd(*flagProject, *flagName, *flagType, *flagPlatesize, *flagScanname, *flagScantimecodein, *flagScantimecodeout, *flagJusttimecodein, *flagJusttimecodeout, *flagScanframe, *flagScanin, *flagScanout, *flagPlatein, *flagPlateout, *flagJustin, *flagJustout)
 			logString := fmt.Sprintf("Create Item: %s_%s, Scanname: %s, ScanTimecode: %s(%d) / %s(%d) (Total:%df), Plate Range: %d - %d, Platesize: %s",
 				*flagName,
@@ -275,76 +293,14 @@ func main() {
 				*flagPlatesize,
 			)
 			dilog.Add(*flagDBIP, ip, logString, *flagProject, *flagName, "csi3", user.Username, 180)
-			if *flagUpdateParent {
-				// updateParent 옵션이 활성화되어있고, org, left가 재스캔이라면.. 원본플레이트의 정보를 업데이트한다.
-				if (*flagType != "org" && strings.Contains(*flagType, "org")) || (*flagType != "left" && strings.Contains(*flagType, "left")) {
-					session, err := mgo.Dial(*flagDBIP)
-					if err != nil {
-						log.Fatal(err)
-					}
-					defer session.Close()
-					typ := "org"
-					if strings.Contains(*flagType, "left") {
-						typ = "left"
-					}
-					item, err := getItem(session, *flagProject, *flagName+"_"+typ)
-					if err != nil {
-						log.Fatal(err)
-					}
-					item.Platesize = *flagPlatesize
-					item.ScanTimecodeIn = *flagScantimecodein
-					item.ScanTimecodeOut = *flagScantimecodeout
-					item.JustTimecodeIn = *flagJusttimecodein
-					item.JustTimecodeOut = *flagJusttimecodeout
-					item.ScanIn = *flagScanin
-					item.ScanOut = *flagScanout
-					item.ScanFrame = *flagScanframe
-					item.Scanname = *flagScanname
-					item.PlateIn = *flagPlatein
-					item.PlateOut = *flagPlateout
-					item.JustIn = *flagJustin
-					item.JustOut = *flagJustout
-					item.UseType = *flagType
-					// adminsetting 값을 가지고와서 Thumbnailmov 값을 설정한다.
-					admin, err := GetAdminSetting(session)
-					if err != nil {
-						log.Fatal(err)
-					}
-					var thumbnailMovPath bytes.Buffer
-					thumbnailMovPathTmpl, err := template.New("thumbnailMovPath").Parse(admin.ThumbnailMovPath)
-					if err != nil {
-						log.Fatal(err)
-					}
-					err = thumbnailMovPathTmpl.Execute(&thumbnailMovPath, item)
-					if err != nil {
-						log.Fatal(err)
-					}
-					item.Thummov = thumbnailMovPath.String()
-
-					err = setItem(session, *flagProject, item)
-					if err != nil {
-						log.Fatal(err)
-					}
-					// log
-					logString := fmt.Sprintf("Scanname: %s, ScanTimecode: %s(%d) / %s(%d) Total: %df\nPlate Range: %d - %d\nPlatesize: %s",
-						*flagScanname,
-						*flagScantimecodein,
-						*flagScanin,
-						*flagScantimecodeout,
-						*flagScanout,
-						*flagScanframe,
-						*flagPlatein,
-						*flagPlateout,
-						*flagPlatesize,
-					)
-					dilog.Add(*flagDBIP, ip, logString, *flagProject, *flagName, "csi3", user.Username, 180)
-				}
-			}
 			return
 		}
 	} else if *flagRm == "item" && *flagName != "" && *flagProject != "" && *flagType != "" { //아이템 삭제
 		rmItemCmd(*flagProject, *flagName, *flagType)
 		return
+	} else if *flagMigratePlates && *flagProject != "" { // 재스캔, 소스 아이템을 플레이트 버전으로 옮긴다.
+		migratePlatesCmd(*flagProject)
+		return
 	} else if *flagHTTPPort != "" {
 		// 만약 프로젝트가 하나도 없다면 "TEMP" 프로젝트를 생성한다. 프로젝트가 있어야 템플릿이 작동하기 때문이다.
 		session, err := mgo.DialWithTimeout(*flagDBIP, 2*time.Second)
@@ -396,6 +352,10 @@ func main() {
 		if *flagReviewRender {
 			go ProcessMain() // 연산(Review데이터 등등)이 필요한 것들이 있다면 연산을 시작한다.
 		}
+		if *flagDigest {
+			go digestScheduler()
+		}
+		go webhookDispatcher() // 등록된 웹훅 구독으로 이벤트를 보낸다.
 		webserver(*flagHTTPPort)
 	} else if MatchNormalTime.MatchString(*flagDate) {
 		// date 값이 데일리 형식이면 해당 날짜에 업로드된 mov를 RV를 통해 플레이한다.
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// taskUserQuery 함수는 Task의 legacy User 또는 Assignees 중 user와 일치하는 아티스트가 있는 아이템을 찾는 쿼리를 반환한다.
+func taskUserQuery(task, user string) bson.M {
+	return bson.M{"$or": []bson.M{
+		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		bson.M{"tasks." + task + ".assignees.user": &bson.RegEx{Pattern: user, Options: "i"}},
+	}}
+}
+
+// SetTaskAssignee 함수는 아이템(id)의 Task에 아티스트를 설정한다.
+func SetTaskAssignee(session *mgo.Session, project, id, userID, task string, a Assignee) (Task, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return Task{}, err
+	}
+	i, err := getItem(session, project, id)
+	if err != nil {
+		return Task{}, err
+	}
+	t, ok := i.Tasks[task]
+	if !ok {
+		return Task{}, fmt.Errorf("%s 에 %s Task가 존재하지 않습니다", id, task)
+	}
+	err = t.SetAssignee(a)
+	if err != nil {
+		return Task{}, err
+	}
+	err = updateTaskAssignees(session, project, id, userID, task, t, "SetTaskAssignee")
+	if err != nil {
+		return Task{}, err
+	}
+	return t, nil
+}
+
+// RmTaskAssignee 함수는 아이템(id)의 Task에서 아티스트를 삭제한다.
+func RmTaskAssignee(session *mgo.Session, project, id, userID, task, user string) (Task, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return Task{}, err
+	}
+	i, err := getItem(session, project, id)
+	if err != nil {
+		return Task{}, err
+	}
+	t, ok := i.Tasks[task]
+	if !ok {
+		return Task{}, fmt.Errorf("%s 에 %s Task가 존재하지 않습니다", id, task)
+	}
+	err = t.RmAssignee(user)
+	if err != nil {
+		return Task{}, err
+	}
+	err = updateTaskAssignees(session, project, id, userID, task, t, "RmTaskAssignee")
+	if err != nil {
+		return Task{}, err
+	}
+	return t, nil
+}
+
+// updateTaskAssignees 함수는 Task의 Assignees와 Assignees에서 계산되는 User, ResultDay 값을 DB에 저장한다.
+func updateTaskAssignees(session *mgo.Session, project, id, userID, task string, t Task, source string) error {
+	return updateItem(session, project, id, userID, source, bson.M{"$set": bson.M{
+		"tasks." + task + ".assignees": t.Assignees,
+		"tasks." + task + ".user":      t.User,
+		"tasks." + task + ".resultday": t.ResultDay,
+		"updatetime":                   time.Now().Format(time.RFC3339),
+	}})
+}
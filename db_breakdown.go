@@ -0,0 +1,156 @@
+package main
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// BreakdownShot 자료구조는 에셋을 사용하는 샷과 샷별 사용정보이다.
+type BreakdownShot struct {
+	ID        string    `json:"id"`       // 샷 ID
+	Name      string    `json:"name"`     // 샷 이름
+	StatusV2  string    `json:"statusv2"` // 샷 상태
+	Breakdown Breakdown `json:"breakdown"`
+}
+
+// BreakdownAsset 자료구조는 샷에서 사용하는 에셋과 샷별 사용정보이다.
+type BreakdownAsset struct {
+	ID        string    `json:"id"`       // 에셋 ID
+	Name      string    `json:"name"`     // 에셋 이름
+	StatusV2  string    `json:"statusv2"` // 에셋 상태
+	Breakdown Breakdown `json:"breakdown"`
+}
+
+// SetBreakdown 함수는 샷(id)에 에셋을 연결한다. 이미 연결된 에셋이라면 사용정보를 교체한다.
+func SetBreakdown(session *mgo.Session, project, id, userID string, b Breakdown) error {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return err
+	}
+	shot, err := getItem(session, project, id)
+	if err != nil {
+		return err
+	}
+	asset, err := Asset(session, project, b.Asset)
+	if err != nil {
+		return err
+	}
+	err = b.CheckError(asset)
+	if err != nil {
+		return err
+	}
+	b.Author = userID
+	b.Createtime = time.Now().Format(time.RFC3339)
+	err = shot.SetBreakdown(b)
+	if err != nil {
+		return err
+	}
+	return updateItem(session, project, id, userID, "SetBreakdown", bson.M{"$set": bson.M{"breakdown": shot.Breakdown, "updatetime": time.Now().Format(time.RFC3339)}})
+}
+
+// RmBreakdown 함수는 샷(id)에서 에셋 연결을 삭제한다.
+func RmBreakdown(session *mgo.Session, project, id, userID, asset string) error {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return err
+	}
+	shot, err := getItem(session, project, id)
+	if err != nil {
+		return err
+	}
+	err = shot.RmBreakdown(asset)
+	if err != nil {
+		return err
+	}
+	return updateItem(session, project, id, userID, "RmBreakdown", bson.M{"$set": bson.M{"breakdown": shot.Breakdown, "updatetime": time.Now().Format(time.RFC3339)}})
+}
+
+// ClearBreakdownFlag 함수는 샷(id)의 브레이크다운 플래그를 해제한다. asset이 빈 문자열이면 모든 플래그를 해제한다.
+func ClearBreakdownFlag(session *mgo.Session, project, id, userID, asset string) error {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return err
+	}
+	shot, err := getItem(session, project, id)
+	if err != nil {
+		return err
+	}
+	shot.ClearBreakdownFlag(asset)
+	return updateItem(session, project, id, userID, "ClearBreakdownFlag", bson.M{"$set": bson.M{"breakdown": shot.Breakdown}})
+}
+
+// AssetShots 함수는 에셋을 사용하는 샷 리스트를 반환한다.
+func AssetShots(session *mgo.Session, project, asset string) ([]BreakdownShot, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return nil, err
+	}
+	c := session.DB("project").C(project)
+	var shots []Item
+	err = c.Find(bson.M{"breakdown.asset": asset}).Select(bson.M{"id": 1, "name": 1, "statusv2": 1, "breakdown": 1}).Sort("name").All(&shots)
+	if err != nil {
+		return nil, err
+	}
+	results := []BreakdownShot{}
+	for _, s := range shots {
+		for _, b := range s.Breakdown {
+			if b.Asset != asset {
+				continue
+			}
+			results = append(results, BreakdownShot{ID: s.ID, Name: s.Name, StatusV2: s.StatusV2, Breakdown: b})
+		}
+	}
+	return results, nil
+}
+
+// ShotAssets 함수는 샷(id)에서 사용하는 에셋 리스트를 반환한다.
+func ShotAssets(session *mgo.Session, project, id string) ([]BreakdownAsset, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return nil, err
+	}
+	shot, err := getItem(session, project, id)
+	if err != nil {
+		return nil, err
+	}
+	results := []BreakdownAsset{}
+	for _, b := range shot.Breakdown {
+		a := BreakdownAsset{Name: b.Asset, Breakdown: b}
+		// 삭제된 에셋이라도 연결정보는 반환한다.
+		asset, err := Asset(session, project, b.Asset)
+		if err == nil {
+			a.ID = asset.ID
+			a.StatusV2 = asset.StatusV2
+		}
+		results = append(results, a)
+	}
+	return results, nil
+}
+
+// flagBreakdownShots 함수는 에셋의 상태가 바뀌었을 때 에셋을 사용하는 샷의 브레이크다운에 플래그를 설정한다.
+func flagBreakdownShots(session *mgo.Session, project, asset, status, userID string) error {
+	c := session.DB("project").C(project)
+	var shots []Item
+	err := c.Find(bson.M{"breakdown.asset": asset}).Select(bson.M{"id": 1, "breakdown": 1}).All(&shots)
+	if err != nil {
+		return err
+	}
+	now := time.Now().Format(time.RFC3339)
+	for _, s := range shots {
+		if !s.FlagBreakdown(asset, status, now) {
+			continue
+		}
+		err = updateItem(session, project, s.ID, userID, "FlagBreakdown", bson.M{"$set": bson.M{"breakdown": s.Breakdown}})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
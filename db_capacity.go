@@ -0,0 +1,242 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// getWorkCalendar 함수는 사용자의 근무 캘린더를 가지고 온다. 캘린더가 없다면 기본 캘린더(주 5일, 하루 8시간)를 반환한다.
+func getWorkCalendar(session *mgo.Session, userID string) (WorkCalendar, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("setting").C("workcalendar")
+	result := WorkCalendar{}
+	err := c.Find(bson.M{"userid": userID}).One(&result)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			return WorkCalendar{UserID: userID, Leaves: []Leave{}}, nil
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// allWorkCalendars 함수는 전체 근무 캘린더를 사용자 ID:근무 캘린더 맵으로 가지고 온다.
+func allWorkCalendars(session *mgo.Session) (map[string]WorkCalendar, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("setting").C("workcalendar")
+	var list []WorkCalendar
+	err := c.Find(bson.M{}).All(&list)
+	if err != nil {
+		return nil, err
+	}
+	calendars := make(map[string]WorkCalendar)
+	for _, cal := range list {
+		calendars[cal.UserID] = cal
+	}
+	return calendars, nil
+}
+
+// setWorkCalendar 함수는 근무 캘린더를 DB에 저장한다.
+func setWorkCalendar(session *mgo.Session, cal WorkCalendar) error {
+	err := cal.CheckError()
+	if err != nil {
+		return err
+	}
+	cal.Updatetime = time.Now().Format(time.RFC3339)
+	c := session.DB("setting").C("workcalendar")
+	_, err = c.Upsert(bson.M{"userid": cal.UserID}, cal)
+	return err
+}
+
+// SetWorkCalendar 함수는 사용자의 하루 근무시간과 근무 요일을 설정한다. 등록된 휴가는 유지한다.
+func SetWorkCalendar(session *mgo.Session, userID string, hoursPerDay float64, workDays []int) (WorkCalendar, error) {
+	session.SetMode(mgo.Monotonic, true)
+	if userID == CompanyCalendarID {
+		return WorkCalendar{}, errors.New("company 캘린더는 휴일만 설정할 수 있습니다")
+	}
+	cal, err := getWorkCalendar(session, userID)
+	if err != nil {
+		return cal, err
+	}
+	cal.HoursPerDay = hoursPerDay
+	cal.WorkDays = workDays
+	return cal, setWorkCalendar(session, cal)
+}
+
+// AddLeave 함수는 사용자의 근무 캘린더에 휴가를 추가한다. userID가 company라면 회사 전체 휴일을 추가한다.
+func AddLeave(session *mgo.Session, userID string, l Leave) (WorkCalendar, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := l.CheckError()
+	if err != nil {
+		return WorkCalendar{}, err
+	}
+	cal, err := getWorkCalendar(session, userID)
+	if err != nil {
+		return cal, err
+	}
+	for _, v := range cal.Leaves {
+		if v.Start <= l.End && l.Start <= v.End {
+			return cal, fmt.Errorf("%s ~ %s 기간에 이미 등록된 휴가가 있습니다", v.Start, v.End)
+		}
+	}
+	cal.Leaves = append(cal.Leaves, l)
+	return cal, setWorkCalendar(session, cal)
+}
+
+// RmLeave 함수는 사용자의 근무 캘린더에서 start에 시작하는 휴가를 삭제한다.
+func RmLeave(session *mgo.Session, userID, start string) (WorkCalendar, error) {
+	session.SetMode(mgo.Monotonic, true)
+	cal, err := getWorkCalendar(session, userID)
+	if err != nil {
+		return cal, err
+	}
+	leaves := []Leave{}
+	for _, l := range cal.Leaves {
+		if l.Start == start {
+			continue
+		}
+		leaves = append(leaves, l)
+	}
+	if len(leaves) == len(cal.Leaves) {
+		return cal, fmt.Errorf("%s 에 시작하는 휴가가 존재하지 않습니다", start)
+	}
+	cal.Leaves = leaves
+	return cal, setWorkCalendar(session, cal)
+}
+
+// GetCapacityReport 함수는 start가 속한 주부터 weeks 주 동안의 주간 작업량 리포트를 반환한다.
+// 아티스트의 작업량은 전체 프로젝트의 Task로 계산한다. team, user가 빈 문자열이 아니면 해당 팀, 아티스트만 포함한다.
+func GetCapacityReport(session *mgo.Session, start string, weeks int, team, user string) (CapacityReport, error) {
+	session.SetMode(mgo.Monotonic, true)
+	items, err := allScheduleItems(session)
+	if err != nil {
+		return CapacityReport{}, err
+	}
+	done, err := CompleteStatusMap(session)
+	if err != nil {
+		return CapacityReport{}, err
+	}
+	calendars, err := allWorkCalendars(session)
+	if err != nil {
+		return CapacityReport{}, err
+	}
+	teams, err := userTeams(session)
+	if err != nil {
+		return CapacityReport{}, err
+	}
+	all, err := allUsers(session)
+	if err != nil {
+		return CapacityReport{}, err
+	}
+	var users []string
+	for _, u := range all {
+		if u.IsLeave {
+			continue
+		}
+		if user != "" && u.ID != user {
+			continue
+		}
+		if team != "" && teams[u.ID] != team {
+			continue
+		}
+		users = append(users, u.ID)
+	}
+	return buildCapacityReport(start, weeks, capacityTasks(items, done), users, calendars, teams)
+}
+
+// userScheduleItems 함수는 전체 프로젝트에서 user가 배정된 아이템만 프로젝트 이름을 키로 하는 맵으로 가지고 온다.
+func userScheduleItems(session *mgo.Session, user string) (map[string][]Item, error) {
+	tasks, err := TasksettingNames(session)
+	if err != nil {
+		return nil, err
+	}
+	var queries []bson.M
+	for _, task := range tasks {
+		queries = append(queries, taskUserQuery(strings.ToLower(task), user))
+	}
+	items := make(map[string][]Item)
+	if len(queries) == 0 {
+		return items, nil
+	}
+	projects, err := getProjects(session)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range projects {
+		var list []Item
+		err := session.DB("project").C(p.ID).Find(bson.M{"$or": queries}).Select(bson.M{"id": 1, "name": 1, "seq": 1, "type": 1, "shottype": 1, "ddline2d": 1, "ddline3d": 1, "tasks": 1, "dependencies": 1}).All(&list)
+		if err != nil {
+			return nil, err
+		}
+		if len(list) != 0 {
+			items[p.ID] = list
+		}
+	}
+	return items, nil
+}
+
+// OverbookWarning 함수는 Task의 작업기간 동안 user가 오버부킹 되었는지 체크하고 경고 메시지를 반환한다.
+// 오버부킹이 아니거나 작업기간을 계산할 수 없다면 빈 문자열을 반환한다. 작업량은 user가 배정된 아이템으로만 계산한다.
+func OverbookWarning(session *mgo.Session, project, id, task, user string) (string, error) {
+	user = assigneeID(user)
+	if user == "" {
+		return "", nil
+	}
+	i, err := getItem(session, project, id)
+	if err != nil {
+		return "", err
+	}
+	t, ok := i.Tasks[task]
+	if !ok {
+		return "", nil
+	}
+	b, ok := newScheduleBar(project, i, task, t, nil)
+	if !ok {
+		return "", nil
+	}
+	monday, _, err := weekRange(b.Start)
+	if err != nil {
+		return "", err
+	}
+	last, _, err := weekRange(b.End)
+	if err != nil {
+		return "", err
+	}
+	s, _ := time.Parse("2006-01-02", monday)
+	e, _ := time.Parse("2006-01-02", last)
+	weeks := int(e.Sub(s).Hours()/24)/7 + 1
+	items, err := userScheduleItems(session, user)
+	if err != nil {
+		return "", err
+	}
+	done, err := CompleteStatusMap(session)
+	if err != nil {
+		return "", err
+	}
+	calendars := make(map[string]WorkCalendar)
+	for _, id := range []string{user, CompanyCalendarID} {
+		calendars[id], err = getWorkCalendar(session, id)
+		if err != nil {
+			return "", err
+		}
+	}
+	report, err := buildCapacityReport(monday, weeks, capacityTasks(items, done), []string{user}, calendars, map[string]string{})
+	if err != nil {
+		return "", err
+	}
+	var overbooked []string
+	for _, row := range report.Users {
+		if row.Overbooked {
+			overbooked = append(overbooked, fmt.Sprintf("%s(%.1f/%.1f)", row.Week, row.Load, row.Capacity))
+		}
+	}
+	if len(overbooked) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("%s 아티스트가 오버부킹 되었습니다. 주(배정/가능 맨데이): %s", user, strings.Join(overbooked, ", ")), nil
+}
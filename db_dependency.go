@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// dependencyItems 함수는 의존관계 계산에 필요한 필드만 가진 프로젝트의 모든 아이템을 ID를 키로 반환한다.
+func dependencyItems(session *mgo.Session, project string) (map[string]Item, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("project").C(project)
+	var items []Item
+	err := c.Find(bson.M{}).Select(bson.M{"id": 1, "name": 1, "type": 1, "statusv2": 1, "tasks": 1, "dependencies": 1}).All(&items)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]Item)
+	for _, i := range items {
+		results[i.ID] = i
+	}
+	return results, nil
+}
+
+// CompleteStatusMap 함수는 끝난 상태로 취급하는 Status ID를 키로 하는 맵을 반환한다.
+func CompleteStatusMap(session *mgo.Session) (map[string]bool, error) {
+	status, err := AllStatus(session)
+	if err != nil {
+		return nil, err
+	}
+	complete := make(map[string]bool)
+	for _, s := range status {
+		if s.Complete {
+			complete[s.ID] = true
+		}
+	}
+	return complete, nil
+}
+
+// AddDependency 함수는 id 아이템에 의존관계를 추가한다. 순환하는 의존관계는 추가할 수 없다.
+func AddDependency(session *mgo.Session, project, id, userID string, d Dependency) error {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return err
+	}
+	items, err := dependencyItems(session, project)
+	if err != nil {
+		return err
+	}
+	err = validDependency(items, id, d)
+	if err != nil {
+		return err
+	}
+	d.Author = userID
+	d.Createtime = time.Now().Format(time.RFC3339)
+	dependencies := append(items[id].Dependencies, d)
+	return updateItem(session, project, id, userID, "AddDependency", bson.M{"$set": bson.M{"dependencies": dependencies, "updatetime": time.Now().Format(time.RFC3339)}})
+}
+
+// RmDependency 함수는 id 아이템의 의존관계를 삭제한다.
+func RmDependency(session *mgo.Session, project, id, userID, task, upstream, upstreamTask string) error {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return err
+	}
+	item, err := getItem(session, project, id)
+	if err != nil {
+		return err
+	}
+	dependencies := []Dependency{}
+	for _, d := range item.Dependencies {
+		if d.Task == task && d.Upstream == upstream && d.UpstreamTask == upstreamTask {
+			continue
+		}
+		dependencies = append(dependencies, d)
+	}
+	if len(dependencies) == len(item.Dependencies) {
+		return errors.New("삭제할 의존관계가 존재하지 않습니다")
+	}
+	return updateItem(session, project, id, userID, "RmDependency", bson.M{"$set": bson.M{"dependencies": dependencies, "updatetime": time.Now().Format(time.RFC3339)}})
+}
+
+// Blockers 함수는 id 아이템(task)이 진행되기 전에 끝나야 하는, 아직 끝나지 않은 모든 상위 아이템(Task)을 반환한다.
+func Blockers(session *mgo.Session, project, id, task string) ([]Blocker, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return nil, err
+	}
+	items, err := dependencyItems(session, project)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := items[id]; !ok {
+		return nil, errors.New(id + " 아이템이 존재하지 않습니다")
+	}
+	complete, err := CompleteStatusMap(session)
+	if err != nil {
+		return nil, err
+	}
+	return dependencyBlockers(items, id, task, complete), nil
+}
+
+// BlockedItemIDs 함수는 끝나지 않은 상위 아이템(Task)이 있는 프로젝트의 아이템 ID 리스트를 반환한다.
+func BlockedItemIDs(session *mgo.Session, project string) ([]string, error) {
+	items, err := dependencyItems(session, project)
+	if err != nil {
+		return nil, err
+	}
+	complete, err := CompleteStatusMap(session)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for id, item := range items {
+		if len(item.Dependencies) == 0 {
+			continue
+		}
+		if len(dependencyBlockers(items, id, "", complete)) != 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// digestChannels 함수는 다이제스트를 보낼 수 있는 채널을 채널이름:채널 맵으로 반환한다.
+func digestChannels() map[string]DigestChannel {
+	return map[string]DigestChannel{
+		"email":   SMTPChannel{Addr: *flagSMTP, From: "csi@" + *flagMailDNS, MailDNS: *flagMailDNS},
+		"slack":   SlackChannel{},
+		"webhook": WebhookChannel{},
+	}
+}
+
+// getDigestPreference 함수는 사용자의 다이제스트 설정을 가지고 온다. 설정이 없다면 off 상태의 기본 설정을 반환한다.
+func getDigestPreference(session *mgo.Session, userID string) (DigestPreference, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("user").C("digest")
+	p := DigestPreference{}
+	err := c.Find(bson.M{"userid": userID}).One(&p)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			return DigestPreference{UserID: userID, Frequency: "off", Hour: 9, Weekday: 1, Channels: []string{"email"}}, nil
+		}
+		return p, err
+	}
+	return p, nil
+}
+
+// SetDigestPreference 함수는 사용자의 다이제스트 설정을 저장한다. 마지막으로 보낸 시간은 유지한다.
+func SetDigestPreference(session *mgo.Session, p DigestPreference) (DigestPreference, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := p.CheckError()
+	if err != nil {
+		return p, err
+	}
+	old, err := getDigestPreference(session, p.UserID)
+	if err != nil {
+		return p, err
+	}
+	p.LastSent = old.LastSent
+	p.Updatetime = time.Now().Format(time.RFC3339)
+	c := session.DB("user").C("digest")
+	_, err = c.Upsert(bson.M{"userid": p.UserID}, p)
+	return p, err
+}
+
+// allDigestPreferences 함수는 다이제스트를 켠 사용자의 설정 리스트를 가지고 온다.
+func allDigestPreferences(session *mgo.Session) ([]DigestPreference, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("user").C("digest")
+	var results []DigestPreference
+	err := c.Find(bson.M{"frequency": bson.M{"$ne": "off"}}).All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// digestItems 함수는 프로젝트에서 사용자가 배정된 아이템과 names 이름의 아이템을 가지고 온다.
+func digestItems(session *mgo.Session, project, userID string, tasks, names []string) ([]Item, error) {
+	queries := []bson.M{}
+	for _, task := range tasks {
+		queries = append(queries, taskUserQuery(strings.ToLower(task), userID))
+	}
+	if len(names) != 0 {
+		queries = append(queries, bson.M{"name": bson.M{"$in": names}})
+	}
+	if len(queries) == 0 {
+		return nil, nil
+	}
+	var items []Item
+	err := session.DB("project").C(project).Find(bson.M{"$or": queries}).Select(bson.M{"id": 1, "name": 1, "type": 1, "shottype": 1, "ddline2d": 1, "ddline3d": 1, "tasks": 1, "comments": 1}).All(&items)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// BuildUserDigest 함수는 사용자의 다이제스트를 만든다. since 이후의 코멘트, 리뷰를 포함한다.
+func BuildUserDigest(session *mgo.Session, userID, since string, now time.Time) (Digest, error) {
+	session.SetMode(mgo.Monotonic, true)
+	u, err := getUser(session, userID)
+	if err != nil {
+		return Digest{}, err
+	}
+	lead := u.AccessLevel >= LeadAccessLevel
+	// 리뷰: 본인이 올린 리뷰와, 리드라면 wait 상태의 리뷰
+	query := bson.M{"author": userID, "updatetime": bson.M{"$gt": since}}
+	if lead {
+		query = bson.M{"$or": []bson.M{query, {"status": "wait"}}}
+	}
+	var reviews []Review
+	err = session.DB("csi").C("review").Find(query).Select(bson.M{"project": 1, "name": 1, "task": 1, "author": 1, "status": 1, "comments": 1, "updatetime": 1}).All(&reviews)
+	if err != nil {
+		return Digest{}, err
+	}
+	reviewNames := make(map[string][]string) // project:names
+	for _, r := range reviews {
+		if r.Author != userID && !inStrings(reviewNames[r.Project], r.Name) {
+			reviewNames[r.Project] = append(reviewNames[r.Project], r.Name)
+		}
+	}
+	tasks, err := TasksettingNames(session)
+	if err != nil {
+		return Digest{}, err
+	}
+	projects, err := getProjects(session)
+	if err != nil {
+		return Digest{}, err
+	}
+	items := make(map[string][]Item)
+	for _, p := range projects {
+		list, err := digestItems(session, p.ID, userID, tasks, reviewNames[p.ID])
+		if err != nil {
+			return Digest{}, err
+		}
+		if len(list) != 0 {
+			items[p.ID] = list
+		}
+	}
+	complete, err := CompleteStatusMap(session)
+	if err != nil {
+		return Digest{}, err
+	}
+	teams, err := userTeams(session)
+	if err != nil {
+		return Digest{}, err
+	}
+	return buildDigest(u, lead, since, now, items, reviews, complete, teams), nil
+}
+
+// SendDigest 함수는 설정된 채널로 사용자의 다이제스트를 보내고 보낸 시간을 기록한다.
+// 보낼 내용이 없다면 보내지 않고 시간만 기록한다. 채널 하나가 실패해도 나머지 채널로 보낸다.
+func SendDigest(session *mgo.Session, p DigestPreference, now time.Time, channels map[string]DigestChannel) (Digest, error) {
+	d, err := BuildUserDigest(session, p.UserID, p.Since(now), now)
+	if err != nil {
+		return d, err
+	}
+	var errs []string
+	if !d.Empty() {
+		u, err := getUser(session, p.UserID)
+		if err != nil {
+			return d, err
+		}
+		for _, name := range p.Channels {
+			ch, ok := channels[name]
+			if !ok {
+				errs = append(errs, fmt.Sprintf("%s 채널이 없습니다", name))
+				continue
+			}
+			err := ch.Send(u, p, d)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			}
+		}
+	}
+	c := session.DB("user").C("digest")
+	err = c.Update(bson.M{"userid": p.UserID}, bson.M{"$set": bson.M{"lastsent": now.Format(time.RFC3339)}})
+	if err != nil && err != mgo.ErrNotFound {
+		return d, err
+	}
+	if len(errs) != 0 {
+		return d, fmt.Errorf("%s 다이제스트 전송 실패: %s", p.UserID, strings.Join(errs, ", "))
+	}
+	return d, nil
+}
+
+// digestScheduler 함수는 1분마다 보낼 시간이 된 사용자의 다이제스트를 보낸다.
+func digestScheduler() {
+	for {
+		session, err := mgo.Dial(*flagDBIP)
+		if err != nil {
+			log.Println(err)
+		} else {
+			now := time.Now()
+			prefs, err := allDigestPreferences(session)
+			if err != nil {
+				log.Println(err)
+			}
+			channels := digestChannels()
+			for _, p := range prefs {
+				if !p.Due(now) {
+					continue
+				}
+				_, err := SendDigest(session, p, now, channels)
+				if err != nil {
+					log.Println(err)
+				}
+			}
+			session.Close()
+		}
+		time.Sleep(time.Minute)
+	}
+}
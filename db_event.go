@@ -0,0 +1,291 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// EventSubscriber 는 이벤트 버스의 구독자이다. publishEvent 함수로 발생한 모든 이벤트를 받는다.
+type EventSubscriber interface {
+	Notify(session *mgo.Session, e Event) error
+}
+
+// eventSubscribers 는 이벤트 버스에 등록된 구독자 리스트이다.
+var eventSubscribers = []EventSubscriber{slackSubscriber{}, webhookSubscriber{}}
+
+// publishEvent 함수는 이벤트를 csi.event 에 저장하고 구독자에게 전달한다.
+// 이벤트는 부가 기능이기 때문에 저장, 전달에 실패해도 이미 바뀐 데이터를 되돌리지 않고 로그만 출력한다.
+func publishEvent(session *mgo.Session, events ...Event) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("event")
+	now := time.Now().Format(time.RFC3339)
+	for _, e := range events {
+		if e.ID == "" {
+			e.ID = bson.NewObjectId()
+		}
+		if e.Createtime == "" {
+			e.Createtime = now
+		}
+		if e.Changes == nil {
+			e.Changes = []EventChange{}
+		}
+		err := c.Insert(e)
+		if err != nil {
+			log.Println(err)
+		}
+		for _, s := range eventSubscribers {
+			err := s.Notify(session, e)
+			if err != nil && *flagDebug {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+// reviewEvent 함수는 리뷰 id의 이벤트를 만든다. 리뷰를 가지고 올 수 없다면 ID만 채운다.
+func reviewEvent(session *mgo.Session, typ, id string, changes ...EventChange) Event {
+	e := Event{Type: typ, Target: id, Changes: changes}
+	r, err := getReview(session, id)
+	if err != nil {
+		return e
+	}
+	e.Project = r.Project
+	e.Name = r.Name
+	e.Task = r.Task
+	return e
+}
+
+// getEvents 함수는 since 이후에 발생한 이벤트를 발생순으로 가지고 온다. typ, project가 빈 문자열이 아니면 필터링한다.
+func getEvents(session *mgo.Session, since, typ, project string, limit int) ([]Event, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("event")
+	query := bson.M{}
+	if since != "" {
+		query["createtime"] = bson.M{"$gt": since}
+	}
+	if project != "" {
+		query["project"] = project
+	}
+	switch {
+	case typ == "" || typ == "*":
+	case strings.HasSuffix(typ, ".*"):
+		query["type"] = &bson.RegEx{Pattern: "^" + regexp.QuoteMeta(strings.TrimSuffix(typ, "*"))}
+	default:
+		query["type"] = typ
+	}
+	results := []Event{}
+	err := c.Find(query).Sort("createtime", "_id").Limit(limit).All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// addWebhook 함수는 웹훅 구독을 추가한다.
+func addWebhook(session *mgo.Session, s WebhookSubscription) (WebhookSubscription, error) {
+	err := s.CheckError()
+	if err != nil {
+		return s, err
+	}
+	session.SetMode(mgo.Monotonic, true)
+	s.ID = bson.NewObjectId()
+	s.Createtime = time.Now().Format(time.RFC3339)
+	s.Updatetime = s.Createtime
+	return s, session.DB("csi").C("webhook").Insert(s)
+}
+
+// getWebhook 함수는 웹훅 구독을 가지고 온다.
+func getWebhook(session *mgo.Session, id string) (WebhookSubscription, error) {
+	if !bson.IsObjectIdHex(id) {
+		return WebhookSubscription{}, errors.New(id + " 는 웹훅 ID 형식이 아닙니다")
+	}
+	session.SetMode(mgo.Monotonic, true)
+	s := WebhookSubscription{}
+	err := session.DB("csi").C("webhook").FindId(bson.ObjectIdHex(id)).One(&s)
+	if err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// allWebhooks 함수는 전체 웹훅 구독을 가지고 온다.
+func allWebhooks(session *mgo.Session) ([]WebhookSubscription, error) {
+	session.SetMode(mgo.Monotonic, true)
+	results := []WebhookSubscription{}
+	err := session.DB("csi").C("webhook").Find(bson.M{}).Sort("name").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// setWebhook 함수는 웹훅 구독을 수정한다.
+func setWebhook(session *mgo.Session, s WebhookSubscription) (WebhookSubscription, error) {
+	err := s.CheckError()
+	if err != nil {
+		return s, err
+	}
+	session.SetMode(mgo.Monotonic, true)
+	s.Updatetime = time.Now().Format(time.RFC3339)
+	return s, session.DB("csi").C("webhook").UpdateId(s.ID, s)
+}
+
+// rmWebhook 함수는 웹훅 구독을 삭제한다. 전송 기록은 남기고 보내지 않은 전송은 dispatcher가 failed로 바꾼다.
+func rmWebhook(session *mgo.Session, id string) error {
+	if !bson.IsObjectIdHex(id) {
+		return errors.New(id + " 는 웹훅 ID 형식이 아닙니다")
+	}
+	session.SetMode(mgo.Monotonic, true)
+	return session.DB("csi").C("webhook").RemoveId(bson.ObjectIdHex(id))
+}
+
+// getWebhookDeliveries 함수는 웹훅 전송 기록을 최신순으로 가지고 온다. subscription, status가 빈 문자열이 아니면 필터링한다.
+func getWebhookDeliveries(session *mgo.Session, subscription, status string, limit int) ([]WebhookDelivery, error) {
+	session.SetMode(mgo.Monotonic, true)
+	query := bson.M{}
+	if subscription != "" {
+		if !bson.IsObjectIdHex(subscription) {
+			return nil, errors.New(subscription + " 는 웹훅 ID 형식이 아닙니다")
+		}
+		query["subscription"] = bson.ObjectIdHex(subscription)
+	}
+	if status != "" {
+		query["status"] = status
+	}
+	results := []WebhookDelivery{}
+	err := session.DB("csi").C("webhookdelivery").Find(query).Sort("-createtime", "-_id").Limit(limit).All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// redeliverWebhook 함수는 웹훅 전송을 처음 상태로 되돌려서 다시 보내게 한다.
+func redeliverWebhook(session *mgo.Session, id string) error {
+	if !bson.IsObjectIdHex(id) {
+		return errors.New(id + " 는 웹훅 전송 ID 형식이 아닙니다")
+	}
+	session.SetMode(mgo.Monotonic, true)
+	now := time.Now().Format(time.RFC3339)
+	return session.DB("csi").C("webhookdelivery").UpdateId(bson.ObjectIdHex(id), bson.M{"$set": bson.M{
+		"status":      DeliveryPending,
+		"attempts":    0,
+		"nextattempt": now,
+		"error":       "",
+		"updatetime":  now,
+	}})
+}
+
+// webhookSubscriber 는 이벤트에 해당하는 웹훅 구독마다 전송을 csi.webhookdelivery 에 쌓는 구독자이다.
+// 실제 전송은 webhookDispatcher가 한다.
+type webhookSubscriber struct{}
+
+// Notify 메소드는 이벤트에 해당하는 웹훅 구독의 전송을 추가한다.
+func (webhookSubscriber) Notify(session *mgo.Session, e Event) error {
+	var subs []WebhookSubscription
+	err := session.DB("csi").C("webhook").Find(bson.M{"active": true}).All(&subs)
+	if err != nil {
+		return err
+	}
+	c := session.DB("csi").C("webhookdelivery")
+	now := time.Now().Format(time.RFC3339)
+	for _, s := range subs {
+		if !s.Match(e) {
+			continue
+		}
+		err := c.Insert(WebhookDelivery{
+			ID:           bson.NewObjectId(),
+			Subscription: s.ID,
+			Event:        e,
+			Status:       DeliveryPending,
+			NextAttempt:  now,
+			Createtime:   now,
+			Updatetime:   now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dispatchWebhooks 함수는 보낼 시간이 된 웹훅 전송을 보내고 결과를 기록한다.
+func dispatchWebhooks(session *mgo.Session, client *http.Client, now time.Time) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("webhookdelivery")
+	var deliveries []WebhookDelivery
+	err := c.Find(bson.M{"status": DeliveryPending, "nextattempt": bson.M{"$lte": now.Format(time.RFC3339)}}).Sort("nextattempt").Limit(100).All(&deliveries)
+	if err != nil {
+		return err
+	}
+	subs := make(map[bson.ObjectId]WebhookSubscription)
+	for _, d := range deliveries {
+		s, ok := subs[d.Subscription]
+		if !ok {
+			err := session.DB("csi").C("webhook").FindId(d.Subscription).One(&s)
+			if err != nil && err != mgo.ErrNotFound {
+				return err
+			}
+			subs[d.Subscription] = s
+		}
+		if s.ID == "" || !s.Active {
+			d.Status = DeliveryFailed
+			d.Error = "웹훅 구독이 없거나 꺼져 있습니다"
+			d.Updatetime = now.Format(time.RFC3339)
+		} else {
+			code, err := deliverWebhook(client, s, d)
+			d = d.attempted(code, err, s.MaxAttempts, now)
+		}
+		err := c.UpdateId(d.ID, d)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneEvents 함수는 EventKeepDays 보다 오래된 이벤트와 웹훅 전송 기록을 삭제한다.
+func pruneEvents(session *mgo.Session, now time.Time) error {
+	session.SetMode(mgo.Monotonic, true)
+	before := now.AddDate(0, 0, -EventKeepDays).Format(time.RFC3339)
+	_, err := session.DB("csi").C("event").RemoveAll(bson.M{"createtime": bson.M{"$lt": before}})
+	if err != nil {
+		return err
+	}
+	_, err = session.DB("csi").C("webhookdelivery").RemoveAll(bson.M{"createtime": bson.M{"$lt": before}, "status": bson.M{"$ne": DeliveryPending}})
+	return err
+}
+
+// webhookDispatcher 함수는 5초마다 웹훅 전송을 보내고, 1시간마다 오래된 이벤트를 정리한다.
+func webhookDispatcher() {
+	client := &http.Client{Timeout: 10 * time.Second}
+	var pruned time.Time
+	for {
+		session, err := mgo.Dial(*flagDBIP)
+		if err != nil {
+			log.Println(err)
+		} else {
+			now := time.Now()
+			err = dispatchWebhooks(session, client, now)
+			if err != nil {
+				log.Println(err)
+			}
+			if now.Sub(pruned) > time.Hour {
+				err = pruneEvents(session, now)
+				if err != nil {
+					log.Println(err)
+				}
+				pruned = now
+			}
+			session.Close()
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"reflect"
 	"sort"
 	"strconv"
@@ -14,34 +16,52 @@ import (
 	"gopkg.in/mgo.v2/bson"
 )
 
-func addItem(session *mgo.Session, project string, i Item) error {
+// addItem 함수는 아이템을 추가하고 추가된 문서를 item_history에 기록한다.
+func addItem(session *mgo.Session, project, userID string, i Item) error {
 	session.SetMode(mgo.Monotonic, true)
 	// 프로젝트가 존재하는지 체크합니다.
-	c := session.DB("projectinfo").C(project)
-	num, err := c.Find(bson.M{"id": project}).Count()
+	p, err := getProject(session, project)
 	if err != nil {
-		return err
-	}
-	if num != 1 {
 		return errors.New("프로젝트가 존재하지 않습니다")
 	}
 	//문서의 중복이 있는지 체크합니다.
-	c = session.DB("project").C(project)
-	num, err = c.Find(bson.M{"name": i.Name, "type": i.Type}).Count()
+	c := session.DB("project").C(project)
+	num, err := c.Find(bson.M{"name": i.Name, "type": i.Type}).Count()
 	if err != nil {
 		return err
 	}
 	if num != 0 {
 		return fmt.Errorf("%s 프로젝트에 이미 %s_%s 샷은 존재합니다", project, i.Name, i.Type)
 	}
+	// 프로젝트에 Task 템플릿이 설정되어 있다면 InitGenerate 대신 템플릿의 Task를 생성합니다.
+	_, tasks, err := itemTaskTemplateTasks(session, p, i, "")
+	if err != nil {
+		return err
+	}
+	if tasks != nil {
+		i.Tasks = tasks
+	}
 	err = c.Insert(i)
 	if err != nil {
 		return err
 	}
-	return nil
+	// 추가 기록은 restoreItem으로 다시 추가된 기록과 같이 New에 추가된 문서를 저장한다.
+	var doc bson.M
+	err = c.Find(bson.M{"id": i.ID}).One(&doc)
+	if err != nil {
+		return err
+	}
+	return addItemHistory(session, ItemHistory{
+		Project: project,
+		ItemID:  i.ID,
+		New:     doc,
+		Author:  userID,
+		Time:    time.Now().Format(time.RFC3339),
+		Source:  "addItem",
+	})
 }
 
-func setItem(session *mgo.Session, project string, i Item) error {
+func setItem(session *mgo.Session, project, userID string, i Item) error {
 	session.SetMode(mgo.Monotonic, true)
 	i.Updatetime = time.Now().Format(time.RFC3339)
 	i.updateStatus() // legacy
@@ -51,8 +71,7 @@ func setItem(session *mgo.Session, project string, i Item) error {
 	}
 	i.updateStatusV2(status)
 	i.setRnumTag() // 롤넘버에 따른 테그 셋팅
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": i.ID}, i)
+	err = updateItem(session, project, i.ID, userID, "setItem", i)
 	if err != nil {
 		return err
 	}
@@ -73,6 +92,30 @@ func getItem(session *mgo.Session, project, id string) (Item, error) {
 	return result, nil
 }
 
+// PatchItem 함수는 json merge 문서로 아이템의 여러 필드를 한번에 수정하고 바뀐 필드 리스트를 반환한다.
+// 수정 가능한 필드, 필요한 권한, 검사규칙은 Item 자료구조의 patch 태그를 따른다. 바뀐 필드만 $set으로 저장한다.
+// 필드, 값, 권한 검사에 실패하면 ItemPatchError를 반환한다.
+func PatchItem(session *mgo.Session, project, id, userID string, level AccessLevel, patch map[string]json.RawMessage) ([]string, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return nil, err
+	}
+	item, err := getItem(session, project, id)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := applyItemPatch(&item, patch, level)
+	if err != nil {
+		return nil, ItemPatchError{Err: err}
+	}
+	err = updateItem(session, project, id, userID, "PatchItem", itemPatchSet(item, fields))
+	if err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
 // Shot 함수는 프로젝트명, 샷이름을 이용해서 샷정보를 반환한다.
 func Shot(session *mgo.Session, project string, name string) (Item, error) {
 	session.SetMode(mgo.Monotonic, true)
@@ -95,6 +138,8 @@ func Shot(session *mgo.Session, project string, name string) (Item, error) {
 	if err != nil {
 		return Item{}, err
 	}
+	// 플레이트 버전이 있다면 사용중인 플레이트 정보를 반환한다.
+	result.ResolveActivePlate()
 	return result, nil
 }
 
@@ -134,6 +179,12 @@ func UseTypes(session *mgo.Session, project string, name string) ([]string, erro
 	var results []string
 	for _, i := range items {
 		results = append(results, i.Type)
+		// 플레이트 버전으로 관리되는 재스캔, 소스 타입을 추가한다.
+		for _, p := range i.Plates {
+			if p.Type != i.Type {
+				results = append(results, p.Type)
+			}
+		}
 	}
 	return results, nil
 }
@@ -183,7 +234,7 @@ func Shots(session *mgo.Session, project string, seq string) ([]string, error) {
 	return shots, nil
 }
 
-func rmItem(session *mgo.Session, project, name, usertyp string) error {
+func rmItem(session *mgo.Session, project, name, userID, usertyp string) error {
 	session.SetMode(mgo.Monotonic, true)
 	var typ string
 	if usertyp == "" {
@@ -203,24 +254,23 @@ func rmItem(session *mgo.Session, project, name, usertyp string) error {
 	if num == 0 {
 		return errors.New("삭제할 아이템이 없습니다")
 	}
-	err = c.Remove(bson.M{"name": name, "type": typ})
+	err = removeItem(session, project, bson.M{"name": name, "type": typ}, userID, "rmItem")
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func rmItemID(session *mgo.Session, project, id string) error {
+func rmItemID(session *mgo.Session, project, id, userID string) error {
 	session.SetMode(mgo.Monotonic, true)
-	c := session.DB("project").C(project)
-	err := c.Remove(bson.M{"id": id})
+	err := removeItem(session, project, bson.M{"id": id}, userID, "rmItemID")
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func rmItemAndType(session *mgo.Session, project, name, typ string) error {
+func rmItemAndType(session *mgo.Session, project, name, userID, typ string) error {
 	session.SetMode(mgo.Monotonic, true)
 	c := session.DB("project").C(project)
 	num, err := c.Find(bson.M{"name": name, "type": typ}).Count()
@@ -230,7 +280,7 @@ func rmItemAndType(session *mgo.Session, project, name, typ string) error {
 	if num == 0 {
 		return errors.New("삭제할 아이템이 없습니다")
 	}
-	err = c.Remove(bson.M{"name": name, "type": typ})
+	err = removeItem(session, project, bson.M{"name": name, "type": typ}, userID, "rmItemAndType")
 	if err != nil {
 		return err
 	}
@@ -917,7 +967,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 	//진행률 출력.
 	assign := bson.M{"$and": []bson.M{
 		bson.M{"tasks." + task + ".status": ASSIGN},
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 	}}
 
@@ -928,7 +978,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 
 	ready := bson.M{"$and": []bson.M{
 		bson.M{"tasks." + task + ".status": READY},
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 	}}
 
@@ -939,7 +989,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 
 	wip := bson.M{"$and": []bson.M{
 		bson.M{"tasks." + task + ".status": WIP},
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 	}}
 
@@ -950,7 +1000,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 
 	confirm := bson.M{"$and": []bson.M{
 		bson.M{"tasks." + task + ".status": CONFIRM},
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 	}}
 
@@ -961,7 +1011,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 
 	done := bson.M{"$and": []bson.M{
 		bson.M{"tasks." + task + ".status": DONE},
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 	}}
 
@@ -972,7 +1022,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 
 	omit := bson.M{"$and": []bson.M{
 		bson.M{"tasks." + task + ".status": OMIT},
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 	}}
 
@@ -983,7 +1033,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 
 	hold := bson.M{"$and": []bson.M{
 		bson.M{"tasks." + task + ".status": HOLD},
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 	}}
 	holdnum, err := c.Find(hold).Count()
@@ -993,7 +1043,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 
 	out := bson.M{"$and": []bson.M{
 		bson.M{"tasks." + task + ".status": OUT},
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 	}}
 	outnum, err := c.Find(out).Count()
@@ -1003,7 +1053,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 
 	none := bson.M{"$and": []bson.M{
 		bson.M{"tasks." + task + ".status": NONE},
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 	}}
 	nonenum, err := c.Find(none).Count()
@@ -1028,7 +1078,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 	for _, s := range statuslist {
 		query := bson.M{"$and": []bson.M{
 			bson.M{"tasks." + task + ".statusv2": s.ID},
-			bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(task, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 		}}
 		num, err := c.Find(query).Count()
@@ -1039,7 +1089,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 	}
 	// 전체 아이템 갯수를 구한다.
 	queryTotal := bson.M{"$and": []bson.M{
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 	}}
 	totalnum, err := c.Find(queryTotal).Count()
@@ -1049,7 +1099,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 	results.Total = totalnum
 	// 샷 갯수를 구한다.
 	queryShot := bson.M{"$and": []bson.M{
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
 	}}
 	shotnum, err := c.Find(queryShot).Count()
@@ -1060,7 +1110,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 	// 샷2D 갯수를 구한다.
 	queryShot2D := bson.M{"$and": []bson.M{
 		bson.M{"shottype": "2d"},
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
 	}}
 	shot2dNum, err := c.Find(queryShot2D).Count()
@@ -1071,7 +1121,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 	// 샷3D 갯수를 구한다.
 	queryShot3D := bson.M{"$and": []bson.M{
 		bson.M{"shottype": "3d"},
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
 	}}
 	shot3dNum, err := c.Find(queryShot3D).Count()
@@ -1081,7 +1131,7 @@ func TotalTaskAndUserStatusnum(session *mgo.Session, project, task, user string)
 	results.Shot3d = shot3dNum
 	// 에셋 갯수를 구한다.
 	queryAsset := bson.M{"$and": []bson.M{
-		bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+		taskUserQuery(task, user),
 		bson.M{"$or": []bson.M{bson.M{"type": "asset"}}},
 	}}
 	assetnum, err := c.Find(queryAsset).Count()
@@ -1115,7 +1165,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	for _, t := range tasks {
 		assignQuery := bson.M{"$and": []bson.M{
 			bson.M{"tasks." + t.Name + ".status": ASSIGN},
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 		}}
 		assignQuerys = append(assignQuerys, assignQuery)
@@ -1129,7 +1179,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	for _, t := range tasks {
 		readyQuery := bson.M{"$and": []bson.M{
 			bson.M{"tasks." + t.Name + ".status": READY},
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 		}}
 		readyQuerys = append(readyQuerys, readyQuery)
@@ -1143,7 +1193,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	for _, t := range tasks {
 		wipQuery := bson.M{"$and": []bson.M{
 			bson.M{"tasks." + t.Name + ".status": WIP},
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 		}}
 		wipQuerys = append(wipQuerys, wipQuery)
@@ -1157,7 +1207,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	for _, t := range tasks {
 		confirmQuery := bson.M{"$and": []bson.M{
 			bson.M{"tasks." + t.Name + ".status": CONFIRM},
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 		}}
 		confirmQuerys = append(confirmQuerys, confirmQuery)
@@ -1171,7 +1221,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	for _, t := range tasks {
 		doneQuery := bson.M{"$and": []bson.M{
 			bson.M{"tasks." + t.Name + ".status": DONE},
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 		}}
 		doneQuerys = append(doneQuerys, doneQuery)
@@ -1185,7 +1235,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	for _, t := range tasks {
 		omitQuery := bson.M{"$and": []bson.M{
 			bson.M{"tasks." + t.Name + ".status": OMIT},
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 		}}
 		omitQuerys = append(omitQuerys, omitQuery)
@@ -1199,7 +1249,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	for _, t := range tasks {
 		holdQuery := bson.M{"$and": []bson.M{
 			bson.M{"tasks." + t.Name + ".status": HOLD},
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 		}}
 		holdQuerys = append(holdQuerys, holdQuery)
@@ -1213,7 +1263,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	for _, t := range tasks {
 		outQuery := bson.M{"$and": []bson.M{
 			bson.M{"tasks." + t.Name + ".status": OUT},
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 		}}
 		outQuerys = append(outQuerys, outQuery)
@@ -1227,7 +1277,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	for _, t := range tasks {
 		noneQuery := bson.M{"$and": []bson.M{
 			bson.M{"tasks." + t.Name + ".status": NONE},
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 		}}
 		noneQuerys = append(noneQuerys, noneQuery)
@@ -1256,7 +1306,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 		for _, t := range tasks {
 			query := bson.M{"$and": []bson.M{
 				bson.M{"tasks." + t.Name + ".statusv2": status.ID},
-				bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+				taskUserQuery(t.Name, user),
 				bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 			}}
 			querys = append(querys, query)
@@ -1272,7 +1322,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	var querysTotal []bson.M
 	for _, t := range tasks {
 		queryTotal := bson.M{"$and": []bson.M{
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}, bson.M{"type": "asset"}}},
 		}}
 		querysTotal = append(querysTotal, queryTotal)
@@ -1287,7 +1337,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	var querysShot []bson.M
 	for _, t := range tasks {
 		queryShot := bson.M{"$and": []bson.M{
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
 		}}
 		querysShot = append(querysShot, queryShot)
@@ -1303,7 +1353,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	for _, t := range tasks {
 		queryShot2D := bson.M{"$and": []bson.M{
 			bson.M{"shottype": "2d"},
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
 		}}
 		querysShot2D = append(querysShot2D, queryShot2D)
@@ -1319,7 +1369,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	for _, t := range tasks {
 		queryShot3D := bson.M{"$and": []bson.M{
 			bson.M{"shottype": "3d"},
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
 		}}
 		querysShot3D = append(querysShot3D, queryShot3D)
@@ -1334,7 +1384,7 @@ func TotalUserStatusnum(session *mgo.Session, project, user string) (Infobarnum,
 	var querysAsset []bson.M
 	for _, t := range tasks {
 		queryAsset := bson.M{"$and": []bson.M{
-			bson.M{"tasks." + t.Name + ".user": &bson.RegEx{Pattern: user, Options: "i"}},
+			taskUserQuery(t.Name, user),
 			bson.M{"$or": []bson.M{bson.M{"type": "asset"}}},
 		}}
 		querysAsset = append(querysAsset, queryAsset)
@@ -1518,13 +1568,12 @@ func TotalStatusnum(session *mgo.Session, project string) (Infobarnum, error) {
 }
 
 // setTaskMov함수는 해당 샷에 mov를 설정하는 함수이다.
-func setTaskMov(session *mgo.Session, project, name, task, mov string) (string, error) {
+func setTaskMov(session *mgo.Session, project, name, userID, task, mov string) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return "", err
 	}
-	c := session.DB("project").C(project)
 	typ, err := Type(session, project, name)
 	if err != nil {
 		return "", err
@@ -1534,7 +1583,7 @@ func setTaskMov(session *mgo.Session, project, name, task, mov string) (string,
 	if err != nil {
 		return id, err
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".mov": mov, "tasks." + task + ".mdate": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "setTaskMov", bson.M{"$set": bson.M{"tasks." + task + ".mov": mov, "tasks." + task + ".mdate": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return id, err
 	}
@@ -1542,18 +1591,17 @@ func setTaskMov(session *mgo.Session, project, name, task, mov string) (string,
 }
 
 // setTaskExpectDay함수는 해당 샷에 예상일을 설정하는 함수이다.
-func setTaskExpectDay(session *mgo.Session, project, id, task string, expectDay int) error {
+func setTaskExpectDay(session *mgo.Session, project, id, userID, task string, expectDay int) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
 	err = HasTask(session, project, id, task)
 	if err != nil {
 		return err
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".expectday": expectDay}})
+	err = updateItem(session, project, id, userID, "setTaskExpectDay", bson.M{"$set": bson.M{"tasks." + task + ".expectday": expectDay}})
 	if err != nil {
 		return err
 	}
@@ -1561,18 +1609,30 @@ func setTaskExpectDay(session *mgo.Session, project, id, task string, expectDay
 }
 
 // setTaskResultDay함수는 해당 샷에 예상일을 설정하는 함수이다.
-func setTaskResultDay(session *mgo.Session, project, id, task string, resultDay int) error {
+func setTaskResultDay(session *mgo.Session, project, id, userID, task string, resultDay int) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
 	err = HasTask(session, project, id, task)
 	if err != nil {
 		return err
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".resultday": resultDay}})
+	i, err := getItem(session, project, id)
+	if err != nil {
+		return err
+	}
+	if len(i.Tasks[task].Assignees) > 1 {
+		return fmt.Errorf("%s Task는 여러 아티스트가 작업합니다. 아티스트별 resultday를 설정해주세요", task)
+	}
+	if len(i.Tasks[task].Assignees) == 1 {
+		t := i.Tasks[task]
+		t.Assignees[0].ResultDay = resultDay
+		t.syncAssignees()
+		return updateTaskAssignees(session, project, id, userID, task, t, "setTaskResultDay")
+	}
+	err = updateItem(session, project, id, userID, "setTaskResultDay", bson.M{"$set": bson.M{"tasks." + task + ".resultday": resultDay}})
 	if err != nil {
 		return err
 	}
@@ -1580,18 +1640,17 @@ func setTaskResultDay(session *mgo.Session, project, id, task string, resultDay
 }
 
 // setTaskUserComment함수는 해당 아이템의 Task에 UserComment를 설정하는 함수이다.
-func setTaskUserComment(session *mgo.Session, project, id, task, comment string) error {
+func setTaskUserComment(session *mgo.Session, project, id, userID, task, comment string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
 	err = HasTask(session, project, id, task)
 	if err != nil {
 		return err
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".usercomment": comment}})
+	err = updateItem(session, project, id, userID, "setTaskUserComment", bson.M{"$set": bson.M{"tasks." + task + ".usercomment": comment}})
 	if err != nil {
 		return err
 	}
@@ -1599,13 +1658,12 @@ func setTaskUserComment(session *mgo.Session, project, id, task, comment string)
 }
 
 // setTaskLevel함수는 해당 샷에 level를 설정하는 함수이다.
-func setTaskLevel(session *mgo.Session, project, name, task, level string) error {
+func setTaskLevel(session *mgo.Session, project, name, userID, task, level string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
 
 	typ, err := Type(session, project, name)
 	if err != nil {
@@ -1620,7 +1678,7 @@ func setTaskLevel(session *mgo.Session, project, name, task, level string) error
 	if err != nil {
 		return err
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".tasklevel": TaskLevel(l)}})
+	err = updateItem(session, project, id, userID, "setTaskLevel", bson.M{"$set": bson.M{"tasks." + task + ".tasklevel": TaskLevel(l)}})
 	if err != nil {
 		return err
 	}
@@ -1649,7 +1707,7 @@ func Type(session *mgo.Session, project, name string) (string, error) {
 
 // SetImageSizeVer2 함수는 해당 샷의 이미지 사이즈를 설정한다.
 // key 설정값 : platesize, undistortionsize, rendersize
-func SetImageSizeVer2(session *mgo.Session, project, id, key, size string) error {
+func SetImageSizeVer2(session *mgo.Session, project, id, userID, key, size string) error {
 	if !(key == "platesize" || key == "dsize" || key == "undistortionsize" || key == "rendersize") {
 		return errors.New("잘못된 key값입니다")
 	}
@@ -1658,14 +1716,13 @@ func SetImageSizeVer2(session *mgo.Session, project, id, key, size string) error
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
 	if key == "dsize" || key == "undistortionsize" {
-		err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"dsize": size, "undistortionsize": size, "updatetime": time.Now().Format(time.RFC3339)}})
+		err = updateItem(session, project, id, userID, "SetImageSizeVer2", bson.M{"$set": bson.M{"dsize": size, "undistortionsize": size, "updatetime": time.Now().Format(time.RFC3339)}})
 		if err != nil {
 			return err
 		}
 	} else {
-		err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{key: size, "updatetime": time.Now().Format(time.RFC3339)}})
+		err = updateItem(session, project, id, userID, "SetImageSizeVer2", bson.M{"$set": bson.M{key: size, "updatetime": time.Now().Format(time.RFC3339)}})
 		if err != nil {
 			return err
 		}
@@ -1675,7 +1732,7 @@ func SetImageSizeVer2(session *mgo.Session, project, id, key, size string) error
 
 // SetTimecode 함수는 item에 Timecode를 설정한다.
 // ScanTimecodeIn,ScanTimecodeOut,JustTimecodeIn,JustTimecoeOut 문자를 key로 사용할 수 있다.
-func SetTimecode(session *mgo.Session, project, name, key, timecode string) error {
+func SetTimecode(session *mgo.Session, project, name, userID, key, timecode string) error {
 	key = strings.ToLower(key)
 	if !(key == "scantimecodein" ||
 		key == "scantimecodeout" ||
@@ -1692,15 +1749,14 @@ func SetTimecode(session *mgo.Session, project, name, key, timecode string) erro
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{key: timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, name+"_"+typ, userID, "SetTimecode", bson.M{"$set": bson.M{key: timecode, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
 	// 우리회사는 현재 timecode와 keycode를 혼용해서 사용중이다.
 	// 원래는 Timecode가 맞지만 현재 DB가 keycode로 되어있어 아직은 아래줄이 필요하다.
 	key = strings.Replace(key, "timecode", "keycode", -1)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{key: timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, name+"_"+typ, userID, "SetTimecode", bson.M{"$set": bson.M{key: timecode, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1708,14 +1764,22 @@ func SetTimecode(session *mgo.Session, project, name, key, timecode string) erro
 }
 
 // SetUseType 함수는 item에 UseType string을 설정한다.
-func SetUseType(session *mgo.Session, project, id, usetype string) error {
+func SetUseType(session *mgo.Session, project, id, userID, usetype string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"usetype": usetype, "updatetime": time.Now().Format(time.RFC3339)}})
+	item, err := getItem(session, project, id)
+	if err != nil {
+		return err
+	}
+	// 플레이트 버전이 있다면 사용중인 플레이트를 바꾼다.
+	if _, ok := item.Plate(usetype); ok {
+		_, err = SetActivePlate(session, project, id, userID, usetype)
+		return err
+	}
+	err = updateItem(session, project, id, userID, "SetUseType", bson.M{"$set": bson.M{"usetype": usetype, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1724,7 +1788,7 @@ func SetUseType(session *mgo.Session, project, id, usetype string) error {
 
 // SetFrame 함수는 item에 프레임을 설정한다.
 // ScanIn,ScanOut,ScanFrame,PlateIn,PlateOut,JustIn,JustOut,HandleIn,HandleOut 문자를 key로 사용할 수 있다.
-func SetFrame(session *mgo.Session, project, name, key string, frame int) error {
+func SetFrame(session *mgo.Session, project, name, userID, key string, frame int) error {
 	if frame == -1 {
 		return nil
 	}
@@ -1748,8 +1812,7 @@ func SetFrame(session *mgo.Session, project, name, key string, frame int) error
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{key: frame, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, name+"_"+typ, userID, "SetFrame", bson.M{"$set": bson.M{key: frame, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1757,14 +1820,13 @@ func SetFrame(session *mgo.Session, project, name, key string, frame int) error
 }
 
 // SetCameraPubPath 함수는 해당 카메라 퍼블리쉬 경로를 설정한다.
-func SetCameraPubPath(session *mgo.Session, project, id, path string) error {
+func SetCameraPubPath(session *mgo.Session, project, id, userID, path string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"productioncam.pubpath": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetCameraPubPath", bson.M{"$set": bson.M{"productioncam.pubpath": path, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1772,7 +1834,7 @@ func SetCameraPubPath(session *mgo.Session, project, id, path string) error {
 }
 
 // SetCameraPubTask 함수는 해당 카메라 퍼블리쉬 팀을 설정한다.
-func SetCameraPubTask(session *mgo.Session, project, id, task string) error {
+func SetCameraPubTask(session *mgo.Session, project, id, userID, task string) error {
 	if !(task == "" || task == "mm" || task == "layout" || task == "ani") {
 		return errors.New("none(빈문자열), mm, layout, ani 팀만 카메라 publish가 가능합니다")
 	}
@@ -1781,8 +1843,7 @@ func SetCameraPubTask(session *mgo.Session, project, id, task string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"productioncam.pubtask": task, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetCameraPubTask", bson.M{"$set": bson.M{"productioncam.pubtask": task, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1790,14 +1851,13 @@ func SetCameraPubTask(session *mgo.Session, project, id, task string) error {
 }
 
 // SetCameraLensmm 함수는 해당 아이템에 카메라 렌즈mm를 설정한다.
-func SetCameraLensmm(session *mgo.Session, project, id, lensmm string) error {
+func SetCameraLensmm(session *mgo.Session, project, id, userID, lensmm string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"productioncam.lensmm": lensmm, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetCameraLensmm", bson.M{"$set": bson.M{"productioncam.lensmm": lensmm, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1805,14 +1865,13 @@ func SetCameraLensmm(session *mgo.Session, project, id, lensmm string) error {
 }
 
 // SetCameraProjection 함수는 샷에 Projection 카메라 사용여부를 체크한다.
-func SetCameraProjection(session *mgo.Session, project, id string, isProjection bool) error {
+func SetCameraProjection(session *mgo.Session, project, id, userID string, isProjection bool) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"productioncam.projection": isProjection, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetCameraProjection", bson.M{"$set": bson.M{"productioncam.projection": isProjection, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1820,7 +1879,7 @@ func SetCameraProjection(session *mgo.Session, project, id string, isProjection
 }
 
 // SetObjectID 함수는 Item에 Object In, Out 값을 설정한다.
-func SetObjectID(session *mgo.Session, project, name string, in, out int) error {
+func SetObjectID(session *mgo.Session, project, name, userID string, in, out int) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1833,8 +1892,7 @@ func SetObjectID(session *mgo.Session, project, name string, in, out int) error
 	if typ != "asset" {
 		return errors.New("asset 타입이 아닙니다")
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"objectidin": in, "objectidout": out, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, name+"_"+typ, userID, "SetObjectID", bson.M{"$set": bson.M{"objectidin": in, "objectidout": out, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1842,14 +1900,13 @@ func SetObjectID(session *mgo.Session, project, name string, in, out int) error
 }
 
 // SetSeq 함수는 item에 seq 값을 셋팅한다.
-func SetSeq(session *mgo.Session, project, id, seq string) error {
+func SetSeq(session *mgo.Session, project, id, userID, seq string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"seq": seq, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetSeq", bson.M{"$set": bson.M{"seq": seq, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1857,14 +1914,13 @@ func SetSeq(session *mgo.Session, project, id, seq string) error {
 }
 
 // SetSeason 함수는 item에 season 값을 셋팅한다.
-func SetSeason(session *mgo.Session, project, id, season string) error {
+func SetSeason(session *mgo.Session, project, id, userID, season string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"season": season, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetSeason", bson.M{"$set": bson.M{"season": season, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1872,14 +1928,13 @@ func SetSeason(session *mgo.Session, project, id, season string) error {
 }
 
 // SetEpisode 함수는 item에 episode 값을 셋팅한다.
-func SetEpisode(session *mgo.Session, project, id, episode string) error {
+func SetEpisode(session *mgo.Session, project, id, userID, episode string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"episode": episode, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetEpisode", bson.M{"$set": bson.M{"episode": episode, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1887,14 +1942,13 @@ func SetEpisode(session *mgo.Session, project, id, episode string) error {
 }
 
 // SetOverscanRatio 함수는 item에 OverscanRatio 값을 셋팅한다.
-func SetOverscanRatio(session *mgo.Session, project, id string, ratio float64) error {
+func SetOverscanRatio(session *mgo.Session, project, id, userID string, ratio float64) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"overscanratio": ratio, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetOverscanRatio", bson.M{"$set": bson.M{"overscanratio": ratio, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1902,14 +1956,13 @@ func SetOverscanRatio(session *mgo.Session, project, id string, ratio float64) e
 }
 
 // SetPlatePath 함수는 item에 PlatePath값을 셋팅한다.
-func SetPlatePath(session *mgo.Session, project, id, path string) error {
+func SetPlatePath(session *mgo.Session, project, id, userID, path string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"platepath": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetPlatePath", bson.M{"$set": bson.M{"platepath": path, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1917,7 +1970,7 @@ func SetPlatePath(session *mgo.Session, project, id, path string) error {
 }
 
 // SetThummov 함수는 item에 Thummov값을 셋팅한다.
-func SetThummov(session *mgo.Session, project, name, path string) error {
+func SetThummov(session *mgo.Session, project, name, userID, path string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1927,8 +1980,7 @@ func SetThummov(session *mgo.Session, project, name, path string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"thummov": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, name+"_"+typ, userID, "SetThummov", bson.M{"$set": bson.M{"thummov": path, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1936,7 +1988,7 @@ func SetThummov(session *mgo.Session, project, name, path string) error {
 }
 
 // SetBeforemov 함수는 item에 Before mov값을 셋팅한다.
-func SetBeforemov(session *mgo.Session, project, name, path string) error {
+func SetBeforemov(session *mgo.Session, project, name, userID, path string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1946,8 +1998,7 @@ func SetBeforemov(session *mgo.Session, project, name, path string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"beforemov": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, name+"_"+typ, userID, "SetBeforemov", bson.M{"$set": bson.M{"beforemov": path, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1955,7 +2006,7 @@ func SetBeforemov(session *mgo.Session, project, name, path string) error {
 }
 
 // SetAftermov 함수는 item에 After mov값을 셋팅한다.
-func SetAftermov(session *mgo.Session, project, name, path string) error {
+func SetAftermov(session *mgo.Session, project, name, userID, path string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1965,8 +2016,7 @@ func SetAftermov(session *mgo.Session, project, name, path string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"aftermov": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, name+"_"+typ, userID, "SetAftermov", bson.M{"$set": bson.M{"aftermov": path, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1974,14 +2024,13 @@ func SetAftermov(session *mgo.Session, project, name, path string) error {
 }
 
 // SetEditmov 함수는 item에 Edit(편집본) mov값을 셋팅한다.
-func SetEditmov(session *mgo.Session, project, id, path string) error {
+func SetEditmov(session *mgo.Session, project, id, userID, path string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"editmov": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetEditmov", bson.M{"$set": bson.M{"editmov": path, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -1989,7 +2038,7 @@ func SetEditmov(session *mgo.Session, project, id, path string) error {
 }
 
 // SetTaskStatus 함수는 item에 task의 status 값을 셋팅한다. // legacy
-func SetTaskStatus(session *mgo.Session, project, id, task, status string) error {
+func SetTaskStatus(session *mgo.Session, project, id, userID, task, status string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2032,7 +2081,6 @@ func SetTaskStatus(session *mgo.Session, project, id, task, status string) error
 	t.StatusV2 = status
 	item.Tasks[task] = t
 
-	c := session.DB("project").C(project)
 	item.Updatetime = time.Now().Format(time.RFC3339)
 	item.updateStatus() // legacy
 	globalStatus, err := AllStatus(session)
@@ -2040,7 +2088,7 @@ func SetTaskStatus(session *mgo.Session, project, id, task, status string) error
 		return err
 	}
 	item.updateStatusV2(globalStatus)
-	err = c.Update(bson.M{"id": item.ID}, item)
+	err = updateItem(session, project, item.ID, userID, "SetTaskStatus", item)
 	if err != nil {
 		return err
 	}
@@ -2048,7 +2096,7 @@ func SetTaskStatus(session *mgo.Session, project, id, task, status string) error
 }
 
 // SetTaskStatusV2 함수는 item에 task의 status 값을 셋팅한다.
-func SetTaskStatusV2(session *mgo.Session, project, id, task, status string) (string, error) {
+func SetTaskStatusV2(session *mgo.Session, project, id, userID, task, status string) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2066,7 +2114,6 @@ func SetTaskStatusV2(session *mgo.Session, project, id, task, status string) (st
 	item.Tasks[task] = t
 
 	// 앞으로 바뀔 상태
-	c := session.DB("project").C(project)
 	// 아이템 업데이트 시간을 변경한다.
 	item.Updatetime = time.Now().Format(time.RFC3339)
 	// 입력받은 상태가 글로벌 status에 존재하는지 체크한다.
@@ -2086,7 +2133,7 @@ func SetTaskStatusV2(session *mgo.Session, project, id, task, status string) (st
 	}
 	// 아이템의 statusV2를 업데이트한다.
 	item.updateStatusV2(globalStatus)
-	err = c.Update(bson.M{"id": item.ID}, item)
+	err = updateItem(session, project, item.ID, userID, "SetTaskStatusV2", item)
 	if err != nil {
 		return item.Name, err
 	}
@@ -2111,7 +2158,7 @@ func HasTask(session *mgo.Session, project, id, task string) error {
 }
 
 // AddTask 함수는 item에 task를 추가한다.
-func AddTask(session *mgo.Session, project, id, task, status string) error {
+func AddTask(session *mgo.Session, project, id, userID, task, status string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2132,7 +2179,6 @@ func AddTask(session *mgo.Session, project, id, task, status string) error {
 	} else {
 		return fmt.Errorf("이미 %s 에 %s Task가 존재합니다", id, taskname)
 	}
-	c := session.DB("project").C(project)
 	item.Updatetime = time.Now().Format(time.RFC3339)
 	item.updateStatus() // legacy
 	globalStatus, err := AllStatus(session)
@@ -2140,7 +2186,7 @@ func AddTask(session *mgo.Session, project, id, task, status string) error {
 		return err
 	}
 	item.updateStatusV2(globalStatus)
-	err = c.Update(bson.M{"id": item.ID}, item)
+	err = updateItem(session, project, item.ID, userID, "AddTask", item)
 	if err != nil {
 		return err
 	}
@@ -2148,14 +2194,13 @@ func AddTask(session *mgo.Session, project, id, task, status string) error {
 }
 
 // RmTask 함수는 item에 task를 제거한다.
-func RmTask(session *mgo.Session, project, id, taskname string) error {
+func RmTask(session *mgo.Session, project, id, userID, taskname string) error {
 	session.SetMode(mgo.Monotonic, true)
 	item, err := getItem(session, project, id)
 	if err != nil {
 		return err
 	}
 	delete(item.Tasks, taskname)
-	c := session.DB("project").C(project)
 	item.Updatetime = time.Now().Format(time.RFC3339)
 	item.updateStatus() // legacy
 	status, err := AllStatus(session)
@@ -2163,7 +2208,7 @@ func RmTask(session *mgo.Session, project, id, taskname string) error {
 		return err
 	}
 	item.updateStatusV2(status)
-	err = c.Update(bson.M{"id": item.ID}, item)
+	err = updateItem(session, project, item.ID, userID, "RmTask", item)
 	if err != nil {
 		return err
 	}
@@ -2171,46 +2216,54 @@ func RmTask(session *mgo.Session, project, id, taskname string) error {
 }
 
 // SetTaskUser 함수는 item에 task의 user 값을 셋팅한다.
-func SetTaskUser(session *mgo.Session, project, name, task, user string) (string, error) {
+// 배정한 아티스트가 작업기간 동안 오버부킹 되었다면 경고 메시지를 함께 반환한다.
+func SetTaskUser(session *mgo.Session, project, name, userID, task, user string) (string, string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	typ, err := Type(session, project, name)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	id := name + "_" + typ
 	err = HasTask(session, project, id, task)
 	if err != nil {
-		return id, err
+		return id, "", err
 	}
 	item, err := getItem(session, project, id)
 	if err != nil {
-		return id, err
+		return id, "", err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": item.ID}, bson.M{"$set": bson.M{"tasks." + task + ".user": user, "updatetime": time.Now().Format(time.RFC3339)}})
+	// 여러 아티스트가 작업하는 Task라면 lead 아티스트를 교체한다.
+	t := item.Tasks[task]
+	t.SetLeadUser(user)
+	err = updateTaskAssignees(session, project, item.ID, userID, task, t, "SetTaskUser")
 	if err != nil {
-		return id, err
+		return id, "", err
 	}
-	return id, nil
+	// 배정은 이미 저장되었다. 오버부킹 경고는 부가 기능이므로 실패해도 로그만 출력한다.
+	warning, err := OverbookWarning(session, project, id, task, user)
+	if err != nil {
+		log.Println(err)
+		return id, "", nil
+	}
+	return id, warning, nil
 }
 
 // SetTaskDate 함수는 item에 task에 마감일을 셋팅한다.
-func SetTaskDate(session *mgo.Session, project, id, task, date string) error {
+func SetTaskDate(session *mgo.Session, project, id, userID, task, date string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
 	fullTime, err := ditime.ToFullTime(19, date)
 	if err != nil {
 		return err
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".date": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetTaskDate", bson.M{"$set": bson.M{"tasks." + task + ".date": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2218,7 +2271,7 @@ func SetTaskDate(session *mgo.Session, project, id, task, date string) error {
 }
 
 // SetDeadline2D 함수는 item에 2D마감일을 셋팅한다.
-func SetDeadline2D(session *mgo.Session, project, name, date string) (string, error) {
+func SetDeadline2D(session *mgo.Session, project, name, userID, date string) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2233,8 +2286,7 @@ func SetDeadline2D(session *mgo.Session, project, name, date string) (string, er
 	if err != nil {
 		return id, err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"ddline2d": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetDeadline2D", bson.M{"$set": bson.M{"ddline2d": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return id, err
 	}
@@ -2242,7 +2294,7 @@ func SetDeadline2D(session *mgo.Session, project, name, date string) (string, er
 }
 
 // SetDeadline3D 함수는 item에 3D마감일을 셋팅한다.
-func SetDeadline3D(session *mgo.Session, project, name, date string) (string, error) {
+func SetDeadline3D(session *mgo.Session, project, name, userID, date string) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2257,8 +2309,7 @@ func SetDeadline3D(session *mgo.Session, project, name, date string) (string, er
 	if err != nil {
 		return id, err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"ddline3d": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetDeadline3D", bson.M{"$set": bson.M{"ddline3d": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return id, err
 	}
@@ -2266,13 +2317,12 @@ func SetDeadline3D(session *mgo.Session, project, name, date string) (string, er
 }
 
 // SetTaskStartdate 함수는 item에 task의 startdate 값을 셋팅한다.
-func SetTaskStartdate(session *mgo.Session, project, id, task, date string) error {
+func SetTaskStartdate(session *mgo.Session, project, id, userID, task, date string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
 	err = HasTask(session, project, id, task)
 	if err != nil {
 		return err
@@ -2281,7 +2331,7 @@ func SetTaskStartdate(session *mgo.Session, project, id, task, date string) erro
 	if err != nil {
 		return err
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".startdate": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetTaskStartdate", bson.M{"$set": bson.M{"tasks." + task + ".startdate": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2289,7 +2339,7 @@ func SetTaskStartdate(session *mgo.Session, project, id, task, date string) erro
 }
 
 // SetTaskUserNote 함수는 item에 task의 user note 값을 셋팅한다.
-func SetTaskUserNote(session *mgo.Session, project, name, task, usernote string) error {
+func SetTaskUserNote(session *mgo.Session, project, name, userID, task, usernote string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2304,8 +2354,7 @@ func SetTaskUserNote(session *mgo.Session, project, name, task, usernote string)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".usernote": usernote, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetTaskUserNote", bson.M{"$set": bson.M{"tasks." + task + ".usernote": usernote, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2313,13 +2362,12 @@ func SetTaskUserNote(session *mgo.Session, project, name, task, usernote string)
 }
 
 // SetTaskPredate 함수는 item에 task의 predate 값을 셋팅한다.
-func SetTaskPredate(session *mgo.Session, project, id, task, date string) (string, error) {
+func SetTaskPredate(session *mgo.Session, project, id, userID, task, date string) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return "", err
 	}
-	c := session.DB("project").C(project)
 	err = HasTask(session, project, id, task)
 	if err != nil {
 		return id, err
@@ -2328,7 +2376,7 @@ func SetTaskPredate(session *mgo.Session, project, id, task, date string) (strin
 	if err != nil {
 		return id, err
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".predate": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetTaskPredate", bson.M{"$set": bson.M{"tasks." + task + ".predate": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return id, err
 	}
@@ -2336,7 +2384,7 @@ func SetTaskPredate(session *mgo.Session, project, id, task, date string) (strin
 }
 
 // SetShotType 함수는 item에 shot type을 셋팅한다.
-func SetShotType(session *mgo.Session, project, name, shottype string) (string, error) {
+func SetShotType(session *mgo.Session, project, name, userID, shottype string) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2354,8 +2402,7 @@ func SetShotType(session *mgo.Session, project, name, shottype string) (string,
 	if err != nil {
 		return id, err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"shottype": shottype, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetShotType", bson.M{"$set": bson.M{"shottype": shottype, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return id, err
 	}
@@ -2363,7 +2410,7 @@ func SetShotType(session *mgo.Session, project, name, shottype string) (string,
 }
 
 // SetOutputName 함수는 item에 Outputname 을 셋팅한다.
-func SetOutputName(session *mgo.Session, project, name, outputname string) error {
+func SetOutputName(session *mgo.Session, project, name, userID, outputname string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2380,8 +2427,7 @@ func SetOutputName(session *mgo.Session, project, name, outputname string) error
 		return errors.New("outputname 이 빈 문자열 입니다")
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"outputname": outputname, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetOutputName", bson.M{"$set": bson.M{"outputname": outputname, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2389,7 +2435,7 @@ func SetOutputName(session *mgo.Session, project, name, outputname string) error
 }
 
 // SetRetimePlate 함수는 item에 RetimePlate를 셋팅한다.
-func SetRetimePlate(session *mgo.Session, project, name, retimeplate string) error {
+func SetRetimePlate(session *mgo.Session, project, name, userID, retimeplate string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2403,8 +2449,7 @@ func SetRetimePlate(session *mgo.Session, project, name, retimeplate string) err
 		return fmt.Errorf("%s 는 %s type 입니다. retime plate를 설정할 수 없습니다", name, typ)
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"retimeplate": retimeplate, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetRetimePlate", bson.M{"$set": bson.M{"retimeplate": retimeplate, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2412,7 +2457,7 @@ func SetRetimePlate(session *mgo.Session, project, name, retimeplate string) err
 }
 
 // SetOCIOcc 함수는 item에 OCIO .cc를 셋팅한다.
-func SetOCIOcc(session *mgo.Session, project, name, path string) error {
+func SetOCIOcc(session *mgo.Session, project, name, userID, path string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2426,8 +2471,7 @@ func SetOCIOcc(session *mgo.Session, project, name, path string) error {
 		return fmt.Errorf("%s 는 %s type 입니다. 설정할 수 없습니다", name, typ)
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"ociocc": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetOCIOcc", bson.M{"$set": bson.M{"ociocc": path, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2435,7 +2479,7 @@ func SetOCIOcc(session *mgo.Session, project, name, path string) error {
 }
 
 // SetRollmedia 함수는 item에 Setellite Rollmedia를 셋팅한다.
-func SetRollmedia(session *mgo.Session, project, name, rollmedia string) error {
+func SetRollmedia(session *mgo.Session, project, name, userID, rollmedia string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2446,8 +2490,7 @@ func SetRollmedia(session *mgo.Session, project, name, rollmedia string) error {
 		return err
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"rollmedia": rollmedia, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetRollmedia", bson.M{"$set": bson.M{"rollmedia": rollmedia, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2455,10 +2498,9 @@ func SetRollmedia(session *mgo.Session, project, name, rollmedia string) error {
 }
 
 // SetScanname 함수는 item에 Scanname을 셋팅한다.
-func SetScanname(session *mgo.Session, project, id, scanname string) error {
+func SetScanname(session *mgo.Session, project, id, userID, scanname string) error {
 	session.SetMode(mgo.Monotonic, true)
-	c := session.DB("project").C(project)
-	err := c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"scanname": scanname, "updatetime": time.Now().Format(time.RFC3339)}})
+	err := updateItem(session, project, id, userID, "SetScanname", bson.M{"$set": bson.M{"scanname": scanname, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2466,7 +2508,7 @@ func SetScanname(session *mgo.Session, project, id, scanname string) error {
 }
 
 // SetRnum 함수는 샷에 롤넘버를 설정한다.
-func SetRnum(session *mgo.Session, project, name, rnum string) (string, error) {
+func SetRnum(session *mgo.Session, project, name, userID, rnum string) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2485,7 +2527,7 @@ func SetRnum(session *mgo.Session, project, name, rnum string) (string, error) {
 		return id, err
 	}
 	item.Rnum = rnum
-	err = setItem(session, project, item)
+	err = setItem(session, project, userID, item)
 	if err != nil {
 		return id, err
 	}
@@ -2493,7 +2535,7 @@ func SetRnum(session *mgo.Session, project, name, rnum string) (string, error) {
 }
 
 // SetAssetType 함수는 item에 assettype을 셋팅한다.
-func SetAssetType(session *mgo.Session, project, name, assettype string) (string, string, string, error) {
+func SetAssetType(session *mgo.Session, project, name, userID, assettype string) (string, string, string, error) {
 	_, err := validAssettype(assettype)
 	if err != nil {
 		return "", "", assettype, err
@@ -2519,7 +2561,7 @@ func SetAssetType(session *mgo.Session, project, name, assettype string) (string
 	beforeType = i.Assettype
 	i.Assettype = assettype
 	i.setAssettags()
-	err = setItem(session, project, i)
+	err = setItem(session, project, userID, i)
 	if err != nil {
 		return id, beforeType, assettype, err
 	}
@@ -2527,7 +2569,7 @@ func SetAssetType(session *mgo.Session, project, name, assettype string) (string
 }
 
 // SetScanTimecodeIn 함수는 item에 Scan Timecode In을 셋팅한다.
-func SetScanTimecodeIn(session *mgo.Session, project, name, timecode string) error {
+func SetScanTimecodeIn(session *mgo.Session, project, name, userID, timecode string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2544,8 +2586,7 @@ func SetScanTimecodeIn(session *mgo.Session, project, name, timecode string) err
 	if !(regexpTimecode.MatchString(timecode) || timecode == "") {
 		return fmt.Errorf("%s 문자열은 00:00:00:00 형식의 문자열이 아닙니다", timecode)
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"scantimecodein": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetScanTimecodeIn", bson.M{"$set": bson.M{"scantimecodein": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2553,7 +2594,7 @@ func SetScanTimecodeIn(session *mgo.Session, project, name, timecode string) err
 }
 
 // SetScanTimecodeOut 함수는 item에 Scan Timecode In을 셋팅한다.
-func SetScanTimecodeOut(session *mgo.Session, project, name, timecode string) error {
+func SetScanTimecodeOut(session *mgo.Session, project, name, userID, timecode string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2570,8 +2611,7 @@ func SetScanTimecodeOut(session *mgo.Session, project, name, timecode string) er
 	if !(regexpTimecode.MatchString(timecode) || timecode == "") {
 		return fmt.Errorf("%s 문자열은 00:00:00:00 형식의 문자열이 아닙니다", timecode)
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"scantimecodeout": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetScanTimecodeOut", bson.M{"$set": bson.M{"scantimecodeout": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2579,7 +2619,7 @@ func SetScanTimecodeOut(session *mgo.Session, project, name, timecode string) er
 }
 
 // SetJustTimecodeIn 함수는 item에 Just Timecode In을 셋팅한다.
-func SetJustTimecodeIn(session *mgo.Session, project, name, timecode string) error {
+func SetJustTimecodeIn(session *mgo.Session, project, name, userID, timecode string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2596,8 +2636,7 @@ func SetJustTimecodeIn(session *mgo.Session, project, name, timecode string) err
 	if !(regexpTimecode.MatchString(timecode) || timecode == "") {
 		return fmt.Errorf("%s 문자열은 00:00:00:00 형식의 문자열이 아닙니다", timecode)
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"justtimecodein": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetJustTimecodeIn", bson.M{"$set": bson.M{"justtimecodein": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2605,7 +2644,7 @@ func SetJustTimecodeIn(session *mgo.Session, project, name, timecode string) err
 }
 
 // SetJustTimecodeOut 함수는 item에 Just Timecode In을 셋팅한다.
-func SetJustTimecodeOut(session *mgo.Session, project, name, timecode string) error {
+func SetJustTimecodeOut(session *mgo.Session, project, name, userID, timecode string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2622,8 +2661,7 @@ func SetJustTimecodeOut(session *mgo.Session, project, name, timecode string) er
 	if !(regexpTimecode.MatchString(timecode) || timecode == "") {
 		return fmt.Errorf("%s 문자열은 00:00:00:00 형식의 문자열이 아닙니다", timecode)
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"justtimecodeout": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetJustTimecodeOut", bson.M{"$set": bson.M{"justtimecodeout": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2631,7 +2669,7 @@ func SetJustTimecodeOut(session *mgo.Session, project, name, timecode string) er
 }
 
 // SetFinver 함수는 item에 파이널 버전을 셋팅한다.
-func SetFinver(session *mgo.Session, project, name, version string) error {
+func SetFinver(session *mgo.Session, project, name, userID, version string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2642,8 +2680,7 @@ func SetFinver(session *mgo.Session, project, name, version string) error {
 		return err
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"finver": version, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetFinver", bson.M{"$set": bson.M{"finver": version, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2651,7 +2688,7 @@ func SetFinver(session *mgo.Session, project, name, version string) error {
 }
 
 // SetFindate 함수는 item에 최종 데이터 아웃풋 날짜를 셋팅한다.
-func SetFindate(session *mgo.Session, project, name, date string) error {
+func SetFindate(session *mgo.Session, project, name, userID, date string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2666,8 +2703,7 @@ func SetFindate(session *mgo.Session, project, name, date string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"findate": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetFindate", bson.M{"$set": bson.M{"findate": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return err
 	}
@@ -2675,7 +2711,7 @@ func SetFindate(session *mgo.Session, project, name, date string) error {
 }
 
 // SetCrowdAsset 함수는 item에 crowdtype을 설정한다.
-func SetCrowdAsset(session *mgo.Session, project, name string) (string, bool, error) {
+func SetCrowdAsset(session *mgo.Session, project, name, userID string) (string, bool, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2686,13 +2722,12 @@ func SetCrowdAsset(session *mgo.Session, project, name string) (string, bool, er
 		return "", false, err
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
 	item, err := getItem(session, project, id)
 	if err != nil {
 		return id, item.CrowdAsset, err
 	}
 	invertBool := !item.CrowdAsset
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"crowdasset": invertBool, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetCrowdAsset", bson.M{"$set": bson.M{"crowdasset": invertBool, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return id, invertBool, err
 	}
@@ -2700,7 +2735,7 @@ func SetCrowdAsset(session *mgo.Session, project, name string) (string, bool, er
 }
 
 // AddTag 함수는 item에 tag를 셋팅한다.
-func AddTag(session *mgo.Session, project, id, inputTag string) (string, error) {
+func AddTag(session *mgo.Session, project, id, userID, inputTag string) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2717,8 +2752,7 @@ func AddTag(session *mgo.Session, project, id, inputTag string) (string, error)
 		}
 	}
 	newTags := append(i.Tag, rmspaceTag)
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tag": newTags, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "AddTag", bson.M{"$set": bson.M{"tag": newTags, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return i.Name, err
 	}
@@ -2726,7 +2760,7 @@ func AddTag(session *mgo.Session, project, id, inputTag string) (string, error)
 }
 
 // RenameTag 함수는 item의 Tag를 리네임한다.
-func RenameTag(session *mgo.Session, project, before, after string) error {
+func RenameTag(session *mgo.Session, project, userID, before, after string) error {
 	session.SetMode(mgo.Monotonic, true)
 	c := session.DB("project").C(project)
 	var items []Item
@@ -2745,7 +2779,7 @@ func RenameTag(session *mgo.Session, project, before, after string) error {
 			}
 		}
 		if !reflect.DeepEqual(beforeTags, newTags) {
-			err = c.Update(bson.M{"id": i.ID}, bson.M{"$set": bson.M{"tag": newTags, "updatetime": time.Now().Format(time.RFC3339)}})
+			err = updateItem(session, project, i.ID, userID, "RenameTag", bson.M{"$set": bson.M{"tag": newTags, "updatetime": time.Now().Format(time.RFC3339)}})
 			if err != nil {
 				return err
 			}
@@ -2755,7 +2789,7 @@ func RenameTag(session *mgo.Session, project, before, after string) error {
 }
 
 // SetTags 함수는 item에 tag를 교체한다.
-func SetTags(session *mgo.Session, project, name string, tags []string) error {
+func SetTags(session *mgo.Session, project, name, userID string, tags []string) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2772,7 +2806,7 @@ func SetTags(session *mgo.Session, project, name string, tags []string) error {
 	}
 	i.Tag = tags
 	// 만약 태그에 권정보가 없더라도 권관련 태그는 날아가면 안된다. setItem을 이용한다.
-	err = setItem(session, project, i)
+	err = setItem(session, project, userID, i)
 	if err != nil {
 		return err
 	}
@@ -2780,7 +2814,7 @@ func SetTags(session *mgo.Session, project, name string, tags []string) error {
 }
 
 // RmTag 함수는 item에 tag를 삭제한다.
-func RmTag(session *mgo.Session, project, id, inputTag string, isContain bool) (string, error) {
+func RmTag(session *mgo.Session, project, id, userID, inputTag string, isContain bool) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2804,7 +2838,7 @@ func RmTag(session *mgo.Session, project, id, inputTag string, isContain bool) (
 	}
 	i.Tag = newTags
 	// 만약 태그에 권정보가 없더라도 권관련 태그는 날아가면 안된다. setItem을 이용한다.
-	err = setItem(session, project, i)
+	err = setItem(session, project, userID, i)
 	if err != nil {
 		return i.Name, err
 	}
@@ -2818,7 +2852,6 @@ func SetNote(session *mgo.Session, project, id, userID, text string, overwrite b
 	if err != nil {
 		return "", "", err
 	}
-	c := session.DB("project").C(project)
 	i, err := getItem(session, project, id)
 	if err != nil {
 		return "", "", err
@@ -2833,7 +2866,7 @@ func SetNote(session *mgo.Session, project, id, userID, text string, overwrite b
 			note = text + "\n " + i.Note.Text
 		}
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"note.text": note, "note.author": userID, "note.date": time.Now().Format(time.RFC3339), "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "SetNote", bson.M{"$set": bson.M{"note.text": note, "note.author": userID, "note.date": time.Now().Format(time.RFC3339), "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return i.Name, "", err
 	}
@@ -2865,7 +2898,7 @@ func AddComment(session *mgo.Session, project, name, userID, authorName, date, t
 		MediaTitle: mediatitle,
 	}
 	i.Comments = append(i.Comments, c)
-	err = setItem(session, project, i)
+	err = setItem(session, project, userID, i)
 	if err != nil {
 		return id, err
 	}
@@ -2873,7 +2906,7 @@ func AddComment(session *mgo.Session, project, name, userID, authorName, date, t
 }
 
 // EditComment 함수는 item에 수정사항을 수정한다.
-func EditComment(session *mgo.Session, project, id, date, authorName, text, mediatitle, media string) (string, error) {
+func EditComment(session *mgo.Session, project, id, userID, date, authorName, text, mediatitle, media string) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2895,8 +2928,7 @@ func EditComment(session *mgo.Session, project, id, date, authorName, text, medi
 		}
 		comments = append(comments, c)
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"comments": comments, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, id, userID, "EditComment", bson.M{"$set": bson.M{"comments": comments, "updatetime": time.Now().Format(time.RFC3339)}})
 	if err != nil {
 		return i.Name, err
 	}
@@ -2929,7 +2961,7 @@ func RmComment(session *mgo.Session, project, name, userID, date string) (string
 		newComments = append(newComments, comment)
 	}
 	i.Comments = newComments
-	err = setItem(session, project, i)
+	err = setItem(session, project, userID, i)
 	if err != nil {
 		return id, "", err
 	}
@@ -2963,7 +2995,7 @@ func AddSource(session *mgo.Session, project, name, author, title, path string)
 	s.Title = title
 	s.Path = path
 	i.Sources = append(i.Sources, s)
-	err = setItem(session, project, i)
+	err = setItem(session, project, author, i)
 	if err != nil {
 		return id, err
 	}
@@ -2992,7 +3024,7 @@ func AddReference(session *mgo.Session, project, name, author, title, path strin
 	r.Title = title
 	r.Path = path
 	i.References = append(i.References, r)
-	err = setItem(session, project, i)
+	err = setItem(session, project, author, i)
 	if err != nil {
 		return id, err
 	}
@@ -3000,7 +3032,7 @@ func AddReference(session *mgo.Session, project, name, author, title, path strin
 }
 
 // RmSource 함수는 item에서 소스를 삭제합니다.
-func RmSource(session *mgo.Session, project, name, title string) (string, error) {
+func RmSource(session *mgo.Session, project, name, userID, title string) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -3023,7 +3055,7 @@ func RmSource(session *mgo.Session, project, name, title string) (string, error)
 		newSources = append(newSources, source)
 	}
 	i.Sources = newSources
-	err = setItem(session, project, i)
+	err = setItem(session, project, userID, i)
 	if err != nil {
 		return id, err
 	}
@@ -3031,7 +3063,7 @@ func RmSource(session *mgo.Session, project, name, title string) (string, error)
 }
 
 // RmReference 함수는 item에서 레퍼런스를 삭제합니다.
-func RmReference(session *mgo.Session, project, name, title string) (string, error) {
+func RmReference(session *mgo.Session, project, name, userID, title string) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -3054,7 +3086,7 @@ func RmReference(session *mgo.Session, project, name, title string) (string, err
 		newReferences = append(newReferences, ref)
 	}
 	i.References = newReferences
-	err = setItem(session, project, i)
+	err = setItem(session, project, userID, i)
 	if err != nil {
 		return id, err
 	}
@@ -3095,13 +3127,12 @@ func GetShottype(session *mgo.Session, project, name string) (string, error) {
 }
 
 // setTaskPublish함수는 해당 샷 Task에 Publish를 설정하는 함수이다.
-func addTaskPublish(session *mgo.Session, project, name, task, key string, p Publish) error {
+func addTaskPublish(session *mgo.Session, project, name, userID, task, key string, p Publish) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
 	typ, err := Type(session, project, name)
 	if err != nil {
 		return err
@@ -3111,8 +3142,7 @@ func addTaskPublish(session *mgo.Session, project, name, task, key string, p Pub
 	if err != nil {
 		return err
 	}
-	err = c.Update(
-		bson.M{"id": id},
+	err = updateItem(session, project, id, userID, "addTaskPublish",
 		bson.M{"$push": bson.M{fmt.Sprintf("tasks.%s.publishes.%s", task, key): p}})
 	if err != nil {
 		return err
@@ -3121,16 +3151,15 @@ func addTaskPublish(session *mgo.Session, project, name, task, key string, p Pub
 }
 
 // rmTaskPublishKey 함수는 item > tasks > publishes 를 제거한다.
-func rmTaskPublishKey(session *mgo.Session, project, id, taskname, key string) error {
+func rmTaskPublishKey(session *mgo.Session, project, id, userID, taskname, key string) error {
 	session.SetMode(mgo.Monotonic, true)
 	item, err := getItem(session, project, id)
 	if err != nil {
 		return err
 	}
 	delete(item.Tasks[taskname].Publishes, key)
-	c := session.DB("project").C(project)
 	item.Updatetime = time.Now().Format(time.RFC3339)
-	err = c.Update(bson.M{"id": item.ID}, item)
+	err = updateItem(session, project, item.ID, userID, "rmTaskPublishKey", item)
 	if err != nil {
 		return err
 	}
@@ -3138,7 +3167,7 @@ func rmTaskPublishKey(session *mgo.Session, project, id, taskname, key string) e
 }
 
 // rmTaskPublish 함수는 item > tasks > publishes > 하나의 아이템을 제거한다.
-func rmTaskPublish(session *mgo.Session, project, id, taskname, key, createtime, path string) error {
+func rmTaskPublish(session *mgo.Session, project, id, userID, taskname, key, createtime, path string) error {
 	session.SetMode(mgo.Monotonic, true)
 	item, err := getItem(session, project, id)
 	if err != nil {
@@ -3153,9 +3182,8 @@ func rmTaskPublish(session *mgo.Session, project, id, taskname, key, createtime,
 		keepList = append(keepList, p)
 	}
 	item.Tasks[taskname].Publishes[key] = keepList // 퍼블리쉬 리스트를 교체한다.
-	c := session.DB("project").C(project)
 	item.Updatetime = time.Now().Format(time.RFC3339)
-	err = c.Update(bson.M{"id": item.ID}, item)
+	err = updateItem(session, project, item.ID, userID, "rmTaskPublish", item)
 	if err != nil {
 		return err
 	}
@@ -3175,3 +3203,71 @@ func HasItem(session *mgo.Session, project, id string) error {
 	}
 	return errors.New(project + " 프로젝트에 해당 Item이 존재하지 않습니다.")
 }
+
+// propagateReviewApproval 함수는 최종 Stage에서 approve 된 리뷰를 아이템 Task 상태, Task mov, Publish 상태에 반영한다.
+// 바뀐 필드를 반환한다. 최종 Stage가 아니거나 리뷰에 해당하는 아이템이 없다면 아무것도 하지 않는다.
+func propagateReviewApproval(session *mgo.Session, review Review, stage Stage, userID string) ([]ItemHistory, error) {
+	if !stage.Final {
+		return nil, nil
+	}
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, review.Project)
+	if err != nil {
+		return nil, err
+	}
+	typ, err := Type(session, review.Project, review.Name)
+	if err != nil {
+		// 샷 또는 에셋이 없는 리뷰는 반영할 아이템이 없다.
+		return nil, nil
+	}
+	item, err := getItem(session, review.Project, review.Name+"_"+typ)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Format(time.RFC3339)
+	changes, err := applyReviewApproval(&item, review, stage, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return changes, nil
+	}
+	globalStatus, err := AllStatus(session)
+	if err != nil {
+		return nil, err
+	}
+	if stage.ApproveTaskStatus != "" {
+		hasStatus := false
+		for _, s := range globalStatus {
+			if s.ID == stage.ApproveTaskStatus {
+				hasStatus = true
+				break
+			}
+		}
+		if !hasStatus {
+			return nil, fmt.Errorf("%s status가 존재하지 않습니다", stage.ApproveTaskStatus)
+		}
+	}
+	item.updateStatusV2(globalStatus)
+	// 바뀐 필드만 $set으로 저장한다. 변경 기록과 이벤트는 updateItem이 남긴다.
+	task := item.Tasks[review.Task]
+	prefix := "tasks." + review.Task + "."
+	set := bson.M{"statusv2": item.StatusV2, "updatetime": now}
+	for i := range changes {
+		changes[i].Author = userID
+		switch field := strings.TrimPrefix(changes[i].Field, prefix); {
+		case field == "statusv2":
+			set[prefix+"statusv2"] = task.StatusV2
+		case field == "mov":
+			set[prefix+"mov"] = task.Mov
+			set[prefix+"mdate"] = task.Mdate
+		case strings.HasPrefix(field, "publishes."):
+			set[prefix+"publishes"] = task.Publishes
+		}
+	}
+	err = updateItem(session, review.Project, item.ID, userID, "review approve: "+review.ID.Hex(), bson.M{"$set": set})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// addItemGroup 함수는 시퀀스, 에피소드를 추가한다. 같은 프로젝트에 같은 이름이 있다면 추가할 수 없다.
+func addItemGroup(session *mgo.Session, g ItemGroup) error {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, g.Project)
+	if err != nil {
+		return err
+	}
+	c := session.DB("csi").C("itemgroup")
+	num, err := c.Find(bson.M{"project": g.Project, "kind": g.Kind, "name": g.Name}).Count()
+	if err != nil {
+		return err
+	}
+	if num != 0 {
+		return fmt.Errorf("%s 프로젝트에 이미 %s %s 가 존재합니다", g.Project, g.Kind, g.Name)
+	}
+	return c.Insert(g)
+}
+
+// getItemGroup 함수는 프로젝트, 종류, 이름으로 시퀀스, 에피소드를 가지고 온다.
+func getItemGroup(session *mgo.Session, project, kind, name string) (ItemGroup, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("itemgroup")
+	g := ItemGroup{}
+	err := c.Find(bson.M{"project": project, "kind": kind, "name": name}).One(&g)
+	if err != nil {
+		return g, fmt.Errorf("%s 프로젝트에 %s %s 가 존재하지 않습니다: %v", project, kind, name, err)
+	}
+	return g, nil
+}
+
+// searchItemGroups 함수는 프로젝트의 시퀀스, 에피소드를 이름순으로 반환한다. status가 빈 문자열이면 모든 상태를 반환한다.
+func searchItemGroups(session *mgo.Session, project, kind, status string) ([]ItemGroup, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("itemgroup")
+	q := bson.M{"project": project, "kind": kind}
+	if status != "" {
+		q["statusv2"] = status
+	}
+	results := []ItemGroup{}
+	err := c.Find(q).Sort("name").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// setItemGroupFields 함수는 시퀀스, 에피소드의 여러 필드를 한번에 설정한다.
+func setItemGroupFields(session *mgo.Session, id bson.ObjectId, fields bson.M) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("itemgroup")
+	return c.UpdateId(id, bson.M{"$set": fields})
+}
+
+// addItemGroupComment 함수는 시퀀스, 에피소드에 코멘트를 추가한다.
+func addItemGroupComment(session *mgo.Session, id bson.ObjectId, comment Comment, updatetime string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("itemgroup")
+	return c.UpdateId(id, bson.M{"$push": bson.M{"comments": comment}, "$set": bson.M{"updatetime": updatetime}})
+}
+
+// rmItemGroup 함수는 시퀀스, 에피소드를 삭제한다. 속한 아이템은 삭제되지 않는다.
+func rmItemGroup(session *mgo.Session, id bson.ObjectId) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("itemgroup")
+	return c.RemoveId(id)
+}
+
+// itemGroupMembers 함수는 시퀀스, 에피소드에 속한 아이템을 가지고 온다. 아이템의 seq 또는 episode 값으로 찾는다.
+func itemGroupMembers(session *mgo.Session, g ItemGroup) ([]Item, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("project").C(g.Project)
+	var items []Item
+	err := c.Find(bson.M{g.Kind: g.Name}).Select(bson.M{"id": 1, "name": 1, "type": 1, "statusv2": 1}).Sort("name").All(&items)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ItemGroupProgressOf 함수는 시퀀스, 에피소드에 속한 아이템의 진행상황을 계산한다.
+func ItemGroupProgressOf(session *mgo.Session, g ItemGroup) (ItemGroupProgress, error) {
+	items, err := itemGroupMembers(session, g)
+	if err != nil {
+		return ItemGroupProgress{}, err
+	}
+	complete, err := CompleteStatusMap(session)
+	if err != nil {
+		return ItemGroupProgress{}, err
+	}
+	return itemGroupProgress(items, complete), nil
+}
+
+// itemGroupNamesByStatus 함수는 상태가 status인 시퀀스, 에피소드 이름 리스트를 반환한다. 검색에서 사용한다.
+func itemGroupNamesByStatus(session *mgo.Session, project, kind, status string) ([]string, error) {
+	groups, err := searchItemGroups(session, project, kind, status)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	return names, nil
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// addItemHistory 함수는 아이템 변경 기록을 추가한다.
+func addItemHistory(session *mgo.Session, histories ...ItemHistory) error {
+	if len(histories) == 0 {
+		return nil
+	}
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("item_history")
+	var docs []interface{}
+	for _, h := range histories {
+		if h.ID == "" {
+			h.ID = bson.NewObjectId()
+		}
+		docs = append(docs, h)
+	}
+	err := c.Insert(docs...)
+	if err != nil {
+		return err
+	}
+	publishEvent(session, itemHistoryEvents(histories)...)
+	return nil
+}
+
+// getItemHistory 함수는 아이템의 변경 기록을 시간순으로 반환한다.
+func getItemHistory(session *mgo.Session, project, id string) ([]ItemHistory, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("item_history")
+	results := []ItemHistory{}
+	err := c.Find(bson.M{"project": project, "itemid": id}).Sort("time", "_id").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// getItemHistoryByID 함수는 변경 기록 ID로 변경 기록을 가지고 온다.
+func getItemHistoryByID(session *mgo.Session, id string) (ItemHistory, error) {
+	if !bson.IsObjectIdHex(id) {
+		return ItemHistory{}, errors.New(id + " 는 변경 기록 ID 형식이 아닙니다")
+	}
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("item_history")
+	var result ItemHistory
+	err := c.FindId(bson.ObjectIdHex(id)).One(&result)
+	if err != nil {
+		return ItemHistory{}, err
+	}
+	return result, nil
+}
+
+// updateItem 함수는 아이템을 업데이트하고 변경 전후 문서를 비교해서 바뀐 필드를 item_history에 기록한다.
+// update는 mgo Update 함수에 사용하는 값이다. $set 같은 연산자 문서 또는 아이템 전체를 사용할 수 있다.
+func updateItem(session *mgo.Session, project, id, userID, source string, update interface{}) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("project").C(project)
+	var before bson.M
+	err := c.Find(bson.M{"id": id}).One(&before)
+	if err != nil {
+		return err
+	}
+	err = c.Update(bson.M{"id": id}, update)
+	if err != nil {
+		return err
+	}
+	var after bson.M
+	err = c.Find(bson.M{"id": id}).One(&after)
+	if err != nil {
+		return err
+	}
+	changes := diffItemDocuments("", before, after)
+	now := time.Now().Format(time.RFC3339)
+	for i := range changes {
+		changes[i].Project = project
+		changes[i].ItemID = id
+		changes[i].Author = userID
+		changes[i].Time = now
+		changes[i].Source = source
+	}
+	err = addItemHistory(session, changes...)
+	if err != nil {
+		return err
+	}
+	// 에셋의 상태가 바뀌면 에셋을 사용하는 샷에 플래그를 설정한다.
+	// 에셋은 이미 저장되었으므로 플래그 설정에 실패해도 에러를 반환하지 않고 로그만 출력한다.
+	if after["type"] == "asset" {
+		for _, h := range changes {
+			if h.Field != "statusv2" {
+				continue
+			}
+			name, _ := after["name"].(string)
+			status, _ := h.New.(string)
+			err = flagBreakdownShots(session, project, name, status, userID)
+			if err != nil {
+				log.Println(err)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// removeItem 함수는 selector에 해당하는 아이템을 삭제하고 삭제 전 문서를 item_history에 기록한다.
+// 삭제 기록의 Field는 빈 문자열이고 Old에 삭제 전 문서가 저장되어 삭제된 아이템을 복원할 때 사용한다.
+func removeItem(session *mgo.Session, project string, selector bson.M, userID, source string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("project").C(project)
+	var doc bson.M
+	err := c.Find(selector).One(&doc)
+	if err != nil {
+		return err
+	}
+	err = c.Remove(selector)
+	if err != nil {
+		return err
+	}
+	id, _ := doc["id"].(string)
+	return addItemHistory(session, ItemHistory{
+		Project: project,
+		ItemID:  id,
+		Old:     doc,
+		Author:  userID,
+		Time:    time.Now().Format(time.RFC3339),
+		Source:  source,
+	})
+}
+
+// restoreItemFieldLevel 함수는 필드를 복원하는데 필요한 권한을 반환한다.
+// patch 태그가 있는 필드는 수정할 때와 같은 권한이 필요하고, 나머지 필드는 아이템 전체 복원과 같이 PM 권한이 필요하다.
+func restoreItemFieldLevel(field string) AccessLevel {
+	for _, f := range itemPatchFields {
+		if f.key == field {
+			return f.Level
+		}
+	}
+	return PmAccessLevel
+}
+
+// restoreItemField 함수는 변경 기록의 필드를 변경 전 값으로 되돌린다. 되돌린 변경도 item_history에 기록된다.
+// 롤넘버, 에셋타입을 되돌리면 그 값으로 만드는 태그도 같이 바꾼다.
+func restoreItemField(session *mgo.Session, historyID, userID string, accessLevel AccessLevel) (ItemHistory, error) {
+	h, err := getItemHistoryByID(session, historyID)
+	if err != nil {
+		return ItemHistory{}, err
+	}
+	if h.Field == "" {
+		return h, errors.New("삭제 기록은 필드를 복원할 수 없습니다. 아이템 전체 복원을 사용해주세요")
+	}
+	if restoreItemFieldLevel(h.Field) > accessLevel {
+		return h, fmt.Errorf("권한이 낮아서 %s 필드를 복원할 수 없습니다", h.Field)
+	}
+	set := bson.M{"updatetime": time.Now().Format(time.RFC3339)}
+	update := bson.M{"$set": set}
+	if h.Old == nil {
+		update["$unset"] = bson.M{h.Field: ""}
+	} else {
+		set[h.Field] = h.Old
+	}
+	switch h.Field {
+	case "rnum", "assettype":
+		item, err := getItem(session, h.Project, h.ItemID)
+		if err != nil {
+			return h, err
+		}
+		old, _ := h.Old.(string)
+		if h.Field == "rnum" {
+			restoreRnumTag(&item, old)
+			set["tag"] = item.Tag
+		} else {
+			item.Assettype = old
+			item.setAssettags()
+			set["assettags"] = item.Assettags
+		}
+	}
+	err = updateItem(session, h.Project, h.ItemID, userID, "restore: "+historyID, update)
+	if err != nil {
+		return h, err
+	}
+	return h, nil
+}
+
+// restoreRnumTag 함수는 아이템의 롤넘버를 rnum으로 바꾸고 권 태그를 다시 만든다. 롤넘버가 없다면 권 태그를 지운다.
+func restoreRnumTag(item *Item, rnum string) {
+	item.Rnum = rnum
+	var tags []string
+	for _, t := range item.Tag {
+		if !validRnumTag(t) {
+			tags = append(tags, t)
+		}
+	}
+	item.Tag = tags
+	item.setRnumTag()
+}
+
+// restoreItem 함수는 아이템을 t 시간의 상태로 되돌린다.
+// 현재 문서에 t 이후의 변경 기록을 거꾸로 적용하고, 아이템이 삭제되었다면 마지막 삭제 기록의 문서에서 시작해서 다시 추가한다.
+func restoreItem(session *mgo.Session, project, id, userID string, t time.Time) error {
+	histories, err := getItemHistory(session, project, id)
+	if err != nil {
+		return err
+	}
+	var after []ItemHistory
+	for _, h := range histories {
+		ht, err := time.Parse(time.RFC3339, h.Time)
+		if err != nil {
+			return err
+		}
+		if ht.After(t) {
+			after = append(after, h)
+		}
+	}
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("project").C(project)
+	var doc bson.M
+	err = c.Find(bson.M{"id": id}).One(&doc)
+	deleted := err == mgo.ErrNotFound
+	if err != nil && !deleted {
+		return err
+	}
+	if deleted {
+		// 마지막 삭제 기록 이후의 변경은 없으므로 삭제 기록 이전의 기록만 되돌린다.
+		last := -1
+		for i, h := range after {
+			if _, ok := h.Old.(bson.M); ok && h.Field == "" {
+				last = i
+			}
+		}
+		if last == -1 {
+			return fmt.Errorf("%s 아이템이 존재하지 않고 %s 이후의 삭제 기록도 없습니다", id, t.Format(time.RFC3339))
+		}
+		doc = after[last].Old.(bson.M)
+		after = after[:last]
+	}
+	if len(after) == 0 && !deleted {
+		return nil
+	}
+	err = revertItemDocument(doc, after)
+	if err != nil {
+		return err
+	}
+	delete(doc, "_id")
+	doc["updatetime"] = time.Now().Format(time.RFC3339)
+	source := "restore: " + t.Format(time.RFC3339)
+	if deleted {
+		err = c.Insert(doc)
+		if err != nil {
+			return err
+		}
+		// 다시 추가된 기록은 New에 추가된 문서를 저장한다.
+		return addItemHistory(session, ItemHistory{
+			Project: project,
+			ItemID:  id,
+			New:     doc,
+			Author:  userID,
+			Time:    time.Now().Format(time.RFC3339),
+			Source:  source,
+		})
+	}
+	return updateItem(session, project, id, userID, source, doc)
+}
@@ -10,7 +10,7 @@ import (
 
 // SetImageSize 함수는 해당 샷의 이미지 사이즈를 설정한다. // legacy
 // key 설정값 : platesize, undistortionsize, rendersize
-func SetImageSize(session *mgo.Session, project, name, key, size string) (string, error) {
+func SetImageSize(session *mgo.Session, project, name, userID, key, size string) (string, error) {
 	if !(key == "platesize" || key == "dsize" || key == "undistortionsize" || key == "rendersize") {
 		return "", errors.New("잘못된 key값입니다")
 	}
@@ -24,14 +24,13 @@ func SetImageSize(session *mgo.Session, project, name, key, size string) (string
 		return "", err
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
 	if key == "dsize" || key == "undistortionsize" {
-		err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"dsize": size, "undistortionsize": size, "updatetime": time.Now().Format(time.RFC3339)}})
+		err = updateItem(session, project, id, userID, "SetImageSize", bson.M{"$set": bson.M{"dsize": size, "undistortionsize": size, "updatetime": time.Now().Format(time.RFC3339)}})
 		if err != nil {
 			return id, err
 		}
 	} else {
-		err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{key: size, "updatetime": time.Now().Format(time.RFC3339)}})
+		err = updateItem(session, project, id, userID, "SetImageSize", bson.M{"$set": bson.M{key: size, "updatetime": time.Now().Format(time.RFC3339)}})
 		if err != nil {
 			return id, err
 		}
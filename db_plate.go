@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// platesUpdate 함수는 플레이트 버전과 사용중인 플레이트 정보를 저장하기 위한 업데이트 쿼리를 반환한다.
+func platesUpdate(i Item) bson.M {
+	return bson.M{"$set": bson.M{
+		"plates":          i.Plates,
+		"activeplate":     i.ActivePlate,
+		"usetype":         i.UseType,
+		"scanname":        i.Scanname,
+		"dataname":        i.Dataname,
+		"platesize":       i.Platesize,
+		"platepath":       i.Platepath,
+		"scantime":        i.Scantime,
+		"scanframe":       i.ScanFrame,
+		"scantimecodein":  i.ScanTimecodeIn,
+		"scantimecodeout": i.ScanTimecodeOut,
+		"justtimecodein":  i.JustTimecodeIn,
+		"justtimecodeout": i.JustTimecodeOut,
+		"scanin":          i.ScanIn,
+		"scanout":         i.ScanOut,
+		"platein":         i.PlateIn,
+		"plateout":        i.PlateOut,
+		"justin":          i.JustIn,
+		"justout":         i.JustOut,
+		"updatetime":      time.Now().Format(time.RFC3339),
+	}}
+}
+
+// Plates 함수는 아이템의 플레이트 버전 리스트와 사용중인 플레이트 버전 타입을 반환한다.
+func Plates(session *mgo.Session, project, id string) ([]Plate, string, error) {
+	err := HasProject(session, project)
+	if err != nil {
+		return nil, "", err
+	}
+	item, err := getItem(session, project, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(item.Plates) == 0 {
+		return []Plate{item.CurrentPlate(item.Type)}, item.Type, nil
+	}
+	item.syncActivePlate()
+	return item.Plates, item.ActivePlate, nil
+}
+
+// AddPlate 함수는 아이템에 플레이트 버전을 추가한다. active가 true이면 추가한 플레이트를 사용하도록 설정한다.
+func AddPlate(session *mgo.Session, project, id, userID string, p Plate, active bool) (Item, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return Item{}, err
+	}
+	item, err := getItem(session, project, id)
+	if err != nil {
+		return Item{}, err
+	}
+	err = item.AddPlate(p)
+	if err != nil {
+		return Item{}, err
+	}
+	if active {
+		err = item.SetActivePlate(p.Type)
+		if err != nil {
+			return Item{}, err
+		}
+	}
+	err = updateItem(session, project, id, userID, "AddPlate", platesUpdate(item))
+	if err != nil {
+		return Item{}, err
+	}
+	return item, nil
+}
+
+// SetActivePlate 함수는 아이템이 사용할 플레이트 버전을 설정한다.
+func SetActivePlate(session *mgo.Session, project, id, userID, typ string) (Item, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return Item{}, err
+	}
+	item, err := getItem(session, project, id)
+	if err != nil {
+		return Item{}, err
+	}
+	err = item.SetActivePlate(typ)
+	if err != nil {
+		return Item{}, err
+	}
+	err = updateItem(session, project, id, userID, "SetActivePlate", platesUpdate(item))
+	if err != nil {
+		return Item{}, err
+	}
+	return item, nil
+}
+
+// RmPlate 함수는 아이템의 플레이트 버전을 삭제한다.
+func RmPlate(session *mgo.Session, project, id, userID, typ string) (Item, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return Item{}, err
+	}
+	item, err := getItem(session, project, id)
+	if err != nil {
+		return Item{}, err
+	}
+	item.syncActivePlate()
+	err = item.RmPlate(typ)
+	if err != nil {
+		return Item{}, err
+	}
+	err = updateItem(session, project, id, userID, "RmPlate", platesUpdate(item))
+	if err != nil {
+		return Item{}, err
+	}
+	return item, nil
+}
+
+// MigratePlates 함수는 org1, left1, src1 형태로 따로 등록된 재스캔, 소스 아이템을 원본 샷의 플레이트 버전으로 옮기고 삭제한다.
+// 원본 샷의 UseType이 재스캔 타입이라면 해당 플레이트 버전을 사용하도록 설정한다. 재스캔 아이템의 코멘트는 원본 샷으로 옮긴다.
+// 배정된 Task, 퍼블리쉬, 의존관계, 브레이크다운이 있는 아이템은 데이터를 잃지 않도록 옮기지 않고 이유와 함께 skipped로 반환한다.
+// 옮겨진 아이템 ID 리스트를 반환한다. 중간에 실패해서 다시 실행해도 원본 샷에 이미 있는 코멘트는 다시 옮기지 않는다.
+func MigratePlates(session *mgo.Session, project, userID string) ([]string, []string, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return nil, nil, err
+	}
+	c := session.DB("project").C(project)
+	var items []Item
+	err = c.Find(bson.M{"type": &bson.RegEx{Pattern: regexpRescanType.String()}}).Sort("name", "type").All(&items)
+	if err != nil {
+		return nil, nil, err
+	}
+	var migrated []string
+	var skipped []string
+	for _, i := range items {
+		if reasons := migratePlateBlockers(i); len(reasons) != 0 {
+			skipped = append(skipped, fmt.Sprintf("%s: %s", i.ID, strings.Join(reasons, ", ")))
+			continue
+		}
+		parent, err := getItem(session, project, i.Name+"_"+plateParentType(i.Type))
+		if err != nil {
+			return migrated, skipped, fmt.Errorf("%s 의 원본 샷이 존재하지 않습니다: %v", i.ID, err)
+		}
+		useType := parent.UseType
+		err = parent.AddPlate(i.CurrentPlate(i.Type))
+		if err != nil {
+			return migrated, skipped, err
+		}
+		if useType == i.Type {
+			err = parent.SetActivePlate(i.Type)
+			if err != nil {
+				return migrated, skipped, err
+			}
+		}
+		update := platesUpdate(parent)
+		if comments := newPlateComments(parent.Comments, i.Comments); len(comments) != 0 {
+			update["$push"] = bson.M{"comments": bson.M{"$each": comments}}
+		}
+		err = updateItem(session, project, parent.ID, userID, "MigratePlates", update)
+		if err != nil {
+			return migrated, skipped, err
+		}
+		err = removeItem(session, project, bson.M{"id": i.ID}, userID, "MigratePlates")
+		if err != nil {
+			return migrated, skipped, err
+		}
+		migrated = append(migrated, i.ID)
+	}
+	return migrated, skipped, nil
+}
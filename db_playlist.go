@@ -0,0 +1,103 @@
+package main
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// addPlaylist 함수는 플레이리스트를 추가한다.
+func addPlaylist(session *mgo.Session, p Playlist) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("playlist")
+	return c.Insert(p)
+}
+
+// getPlaylist 함수는 id를 받아서 플레이리스트를 반환한다.
+func getPlaylist(session *mgo.Session, id string) (Playlist, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("playlist")
+	p := Playlist{}
+	err := c.FindId(bson.ObjectIdHex(id)).One(&p)
+	if err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// searchPlaylists 함수는 프로젝트, 날짜로 플레이리스트를 검색한다. 빈 문자열은 조건에서 제외한다.
+func searchPlaylists(session *mgo.Session, project, date string) ([]Playlist, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("playlist")
+	q := bson.M{}
+	if project != "" {
+		q["project"] = project
+	}
+	if date != "" {
+		q["date"] = date
+	}
+	results := []Playlist{}
+	err := c.Find(q).Sort("-date", "title").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// rmPlaylist 함수는 플레이리스트를 삭제한다.
+func rmPlaylist(session *mgo.Session, id string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("playlist")
+	return c.RemoveId(bson.ObjectIdHex(id))
+}
+
+// setPlaylistFields 함수는 플레이리스트의 여러 필드를 한번에 설정한다.
+func setPlaylistFields(session *mgo.Session, id string, fields bson.M) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("playlist")
+	return c.UpdateId(bson.ObjectIdHex(id), bson.M{"$set": fields})
+}
+
+// setPlaylistReviews 함수는 플레이리스트의 리뷰 리스트를 설정한다.
+// 다른 사용자가 그 사이에 리스트를 바꿨다면 덮어쓰지 않도록 이전 리스트가 같을 때만 변경한다.
+func setPlaylistReviews(session *mgo.Session, id string, before, after []string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("playlist")
+	if before == nil {
+		before = []string{}
+	}
+	q := bson.M{"_id": bson.ObjectIdHex(id), "reviews": before}
+	if len(before) == 0 {
+		// 리스트가 비어있다면 DB에는 빈 배열 또는 null로 저장되어 있을 수 있다.
+		q["reviews"] = bson.M{"$in": []interface{}{nil, []string{}}}
+	}
+	return c.Update(q, bson.M{"$set": bson.M{"reviews": after, "updatetime": time.Now().Format(time.RFC3339)}})
+}
+
+// setPlaylistSession 함수는 데일리 세션 상태를 바꾸고 Revision을 1 증가시킨다. 변경된 플레이리스트를 반환한다.
+func setPlaylistSession(session *mgo.Session, id string, s PlaylistSession) (Playlist, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("playlist")
+	p := Playlist{}
+	s.Updatetime = time.Now().Format(time.RFC3339)
+	change := mgo.Change{
+		Update: bson.M{
+			"$set": bson.M{
+				"session.active":     s.Active,
+				"session.host":       s.Host,
+				"session.current":    s.Current,
+				"session.frame":      s.Frame,
+				"session.playing":    s.Playing,
+				"session.updatetime": s.Updatetime,
+			},
+			"$inc": bson.M{"session.revision": 1},
+		},
+		ReturnNew: true,
+	}
+	_, err := c.FindId(bson.ObjectIdHex(id)).Apply(change, &p)
+	if err != nil {
+		return p, err
+	}
+	return p, nil
+}
@@ -89,6 +89,7 @@ func addProject(session *mgo.Session, p Project) error {
 	if err != nil {
 		log.Println(err)
 	}
+	publishEvent(session, Event{Type: EventProjectAdd, Project: p.ID, Target: p.ID})
 	return nil
 }
 
@@ -197,6 +198,7 @@ func rmProject(session *mgo.Session, project string) error {
 			return err
 		}
 	}
+	publishEvent(session, Event{Type: EventProjectRemove, Project: project, Target: project})
 	return nil
 }
 
@@ -218,6 +220,7 @@ func setProject(session *mgo.Session, p Project) error {
 		log.Println(err)
 		return err
 	}
+	publishEvent(session, Event{Type: EventProjectUpdate, Project: p.ID, Target: p.ID})
 	return nil
 }
 
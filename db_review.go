@@ -2,6 +2,7 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -12,36 +13,17 @@ import (
 func addReview(session *mgo.Session, r Review) error {
 	session.SetMode(mgo.Monotonic, true)
 	c := session.DB("csi").C("review")
+	if r.ID == "" {
+		r.ID = bson.NewObjectId()
+	}
 	err := c.Insert(r)
 	if err != nil {
 		return err
 	}
+	publishEvent(session, Event{Type: EventReviewAdd, Project: r.Project, Target: r.ID.Hex(), Name: r.Name, Task: r.Task, UserID: r.Author})
 	return nil
 }
 
-// GetWaitProcessStatusReview 함수는 processstatus 가 wait 값인 아이템을 하나 반환하고 상태를 processing으로 바꾼다.
-func GetWaitProcessStatusReview() (Review, error) {
-	var review Review
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		return review, err
-	}
-	defer session.Close()
-	session.SetMode(mgo.Monotonic, true)
-	c := session.DB("csi").C("review")
-	err = c.Find(bson.M{"processstatus": "wait"}).One(&review)
-	if err != nil {
-		return review, err
-	}
-	// 참고: 아래 부분은 추후 mgo가 아닌 mongo-driver로 바꾸면 한번에 처리할 수 있다.
-	err = setReviewProcessStatus(session, review.ID.Hex(), "queued")
-	if err != nil {
-		return review, err
-	}
-	review.ProcessStatus = "queued"
-	return review, nil
-}
-
 func getReview(session *mgo.Session, id string) (Review, error) {
 	session.SetMode(mgo.Monotonic, true)
 	c := session.DB("csi").C("review")
@@ -63,6 +45,7 @@ func setReviewStatus(session *mgo.Session, id, status string) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "status", New: status}))
 	return nil
 }
 
@@ -76,6 +59,7 @@ func setReviewStage(session *mgo.Session, id, stage string) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "stage", New: stage}))
 	return nil
 }
 
@@ -86,6 +70,67 @@ func setReviewProcessStatus(session *mgo.Session, id, status string) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "processstatus", New: status}))
+	return nil
+}
+
+// setReviewExt 함수는 id와 확장자를 입력받아서 리뷰데이터의 확장자를 변경한다.
+func setReviewExt(session *mgo.Session, id, ext string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("review")
+	err := c.UpdateId(bson.ObjectIdHex(id), bson.M{"$set": bson.M{"ext": ext}})
+	if err != nil {
+		return err
+	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "ext", New: ext}))
+	return nil
+}
+
+// setReviewSlated 함수는 id와 슬레이트 버전 존재여부를 입력받아서 리뷰에 기록한다.
+func setReviewSlated(session *mgo.Session, id string, slated bool) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("review")
+	err := c.UpdateId(bson.ObjectIdHex(id), bson.M{"$set": bson.M{"slated": slated}})
+	if err != nil {
+		return err
+	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "slated", New: slated}))
+	return nil
+}
+
+// setReviewDuration 함수는 id와 리뷰 동영상의 길이(초)를 입력받아서 리뷰에 기록한다.
+func setReviewDuration(session *mgo.Session, id string, duration float64) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("review")
+	err := c.UpdateId(bson.ObjectIdHex(id), bson.M{"$set": bson.M{"duration": duration}})
+	if err != nil {
+		return err
+	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "duration", New: duration}))
+	return nil
+}
+
+// setReviewColorTransform 함수는 id와 컬러 변환 정보를 입력받아서 리뷰에 적용된 컬러 변환을 기록한다.
+func setReviewColorTransform(session *mgo.Session, id string, ct ColorTransform) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("review")
+	err := c.UpdateId(bson.ObjectIdHex(id), bson.M{"$set": bson.M{"colortransform": ct}})
+	if err != nil {
+		return err
+	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "colortransform", New: ct}))
+	return nil
+}
+
+// setReviewLog 함수는 id와 log를 입력받아서 리뷰 로그를 기록한다.
+func setReviewLog(session *mgo.Session, id, log string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("review")
+	err := c.UpdateId(bson.ObjectIdHex(id), bson.M{"$set": bson.M{"log": log}})
+	if err != nil {
+		return err
+	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "log", New: log}))
 	return nil
 }
 
@@ -97,6 +142,7 @@ func setErrReview(session *mgo.Session, id, log string) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "processstatus", New: "error"}, EventChange{Field: "log", New: log}))
 	return nil
 }
 
@@ -160,6 +206,9 @@ func addReviewComment(session *mgo.Session, id string, cmt Comment) error {
 	if err != nil {
 		return err
 	}
+	e := reviewEvent(session, EventReviewComment, id, EventChange{Field: "comments", New: cmt})
+	e.UserID = cmt.Author
+	publishEvent(session, e)
 	return nil
 }
 
@@ -184,6 +233,7 @@ func EditReviewComment(session *mgo.Session, id, date, text, media string, frame
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewComment, id, EventChange{Field: "comments." + date + ".text", New: text}))
 	return nil
 }
 
@@ -206,9 +256,44 @@ func RmReviewComment(session *mgo.Session, id, date string) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewComment, id, EventChange{Field: "comments." + date, New: nil}))
 	return nil
 }
 
+// resolveReviewComment 함수는 review의 코멘트를 해결 또는 미해결 상태로 바꾼다. key는 코멘트 ID 또는 이전 코멘트의 Date이다.
+func resolveReviewComment(session *mgo.Session, id, key string, resolved bool, userID string) (Comment, error) {
+	session.SetMode(mgo.Monotonic, true)
+	reviewItem, err := getReview(session, id)
+	if err != nil {
+		return Comment{}, err
+	}
+	found := -1
+	for i, comment := range reviewItem.Comments {
+		if comment.Key() != key {
+			continue
+		}
+		found = i
+		reviewItem.Comments[i].Resolved = resolved
+		reviewItem.Comments[i].ResolvedBy = ""
+		reviewItem.Comments[i].ResolvedTime = ""
+		if resolved {
+			reviewItem.Comments[i].ResolvedBy = userID
+			reviewItem.Comments[i].ResolvedTime = time.Now().Format(time.RFC3339)
+		}
+	}
+	if found == -1 {
+		return Comment{}, fmt.Errorf("%s 코멘트가 존재하지 않습니다", key)
+	}
+	err = setReviewItem(session, reviewItem)
+	if err != nil {
+		return Comment{}, err
+	}
+	e := reviewEvent(session, EventReviewComment, id, EventChange{Field: "comments." + key + ".resolved", New: resolved})
+	e.UserID = userID
+	publishEvent(session, e)
+	return reviewItem.Comments[found], nil
+}
+
 // setReviewItem은 Review 자료구조를 새로운 Review로 설정한다.
 func setReviewItem(session *mgo.Session, r Review) error {
 	session.SetMode(mgo.Monotonic, true)
@@ -224,10 +309,12 @@ func setReviewItem(session *mgo.Session, r Review) error {
 func RmReview(session *mgo.Session, id string) error {
 	session.SetMode(mgo.Monotonic, true)
 	c := session.DB("csi").C("review")
+	e := reviewEvent(session, EventReviewRemove, id)
 	err := c.RemoveId(bson.ObjectIdHex(id))
 	if err != nil {
 		return err
 	}
+	publishEvent(session, e)
 	return nil
 }
 
@@ -250,6 +337,7 @@ func SetReviewProject(session *mgo.Session, id string, project string) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "project", New: project}))
 	return nil
 }
 
@@ -261,6 +349,7 @@ func SetReviewTask(session *mgo.Session, id string, task string) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "task", New: task}))
 	return nil
 }
 
@@ -272,6 +361,7 @@ func SetReviewName(session *mgo.Session, id string, name string) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "name", New: name}))
 	return nil
 }
 
@@ -283,6 +373,7 @@ func SetReviewPath(session *mgo.Session, id string, path string) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "path", New: path}))
 	return nil
 }
 
@@ -294,6 +385,7 @@ func SetReviewCreatetime(session *mgo.Session, id string, createtime string) err
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "createtime", New: createtime}))
 	return nil
 }
 
@@ -305,6 +397,7 @@ func SetReviewUpdatetime(session *mgo.Session, id string, updatetime string) err
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "updatetime", New: updatetime}))
 	return nil
 }
 
@@ -316,6 +409,7 @@ func SetReviewMainVersion(session *mgo.Session, id string, mainversion int) erro
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "mainversion", New: mainversion}))
 	return nil
 }
 
@@ -327,6 +421,7 @@ func SetReviewSubVersion(session *mgo.Session, id string, subversion int) error
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "subversion", New: subversion}))
 	return nil
 }
 
@@ -338,6 +433,7 @@ func SetReviewFps(session *mgo.Session, id string, fps float64) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "fps", New: fps}))
 	return nil
 }
 
@@ -349,6 +445,7 @@ func SetReviewDescription(session *mgo.Session, id string, description string) e
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "description", New: description}))
 	return nil
 }
 
@@ -360,5 +457,6 @@ func SetReviewCameraInfo(session *mgo.Session, id string, camerainfo string) err
 	if err != nil {
 		return err
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "camerainfo", New: camerainfo}))
 	return nil
 }
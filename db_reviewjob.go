@@ -0,0 +1,184 @@
+package main
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// addReviewJob 함수는 리뷰를 연산 큐에 등록한다. 이미 작업이 존재하면 처음 상태로 다시 등록한다.
+func addReviewJob(session *mgo.Session, r Review) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("reviewjob")
+	now := time.Now().Format(time.RFC3339)
+	job := ReviewJob{
+		ID:            r.ID.Hex(),
+		Project:       r.Project,
+		Name:          r.Name,
+		Task:          r.Task,
+		Type:          r.Type,
+		Status:        "queued",
+		Attempts:      0,
+		MaxAttempts:   *flagReviewJobMaxAttempts,
+		Createtime:    now,
+		NextRetrytime: now,
+	}
+	_, err := c.Upsert(bson.M{"id": job.ID}, job)
+	if err != nil {
+		return err
+	}
+	return setReviewProcessStatus(session, job.ID, "queued")
+}
+
+// getReviewJob 함수는 id를 받아서 ReviewJob을 반환한다.
+func getReviewJob(session *mgo.Session, id string) (ReviewJob, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("reviewjob")
+	job := ReviewJob{}
+	err := c.Find(bson.M{"id": id}).One(&job)
+	if err != nil {
+		return job, err
+	}
+	return job, nil
+}
+
+// claimReviewJob 함수는 연산 가능한 작업 하나를 running 상태로 바꾸고 반환한다.
+// 상태 변경과 조회를 한번에 처리하기 때문에 여러 worker가 같은 작업을 가지고 가지 않는다.
+func claimReviewJob(session *mgo.Session) (ReviewJob, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("reviewjob")
+	job := ReviewJob{}
+	now := time.Now().Format(time.RFC3339)
+	change := mgo.Change{
+		Update: bson.M{
+			"$set": bson.M{"status": "running", "starttime": now, "endtime": ""},
+			"$inc": bson.M{"attempts": 1},
+		},
+		ReturnNew: true,
+	}
+	q := bson.M{"status": "queued", "nextretrytime": bson.M{"$lte": now}}
+	_, err := c.Find(q).Sort("nextretrytime").Apply(change, &job)
+	if err != nil {
+		return job, err
+	}
+	return job, nil
+}
+
+// doneReviewJob 함수는 작업을 done 상태로 바꾼다.
+func doneReviewJob(session *mgo.Session, id string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("reviewjob")
+	now := time.Now().Format(time.RFC3339)
+	return c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"status": "done", "endtime": now, "log": ""}})
+}
+
+// retryReviewJob 함수는 실패한 작업을 다시 큐에 넣는다.
+// 최대 시도 횟수를 넘으면 failed 상태가 되며 true를 반환한다.
+func retryReviewJob(session *mgo.Session, job ReviewJob, log string) (bool, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("reviewjob")
+	job = job.retried(log, time.Now())
+	set := bson.M{
+		"status":  job.Status,
+		"endtime": job.Endtime,
+		"log":     job.Log,
+	}
+	if job.Status == "queued" {
+		set["nextretrytime"] = job.NextRetrytime
+	}
+	err := c.Update(bson.M{"id": job.ID}, bson.M{"$set": set})
+	return job.Status == "failed", err
+}
+
+// nextReviewJobRetrytime 함수는 대기중인 작업중 가장 빠른 재시도 시간을 반환한다.
+func nextReviewJobRetrytime(session *mgo.Session) (time.Time, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("reviewjob")
+	job := ReviewJob{}
+	err := c.Find(bson.M{"status": "queued"}).Sort("nextretrytime").One(&job)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, job.NextRetrytime)
+}
+
+// recoverReviewJobs 함수는 서버가 비정상 종료되어 running 상태로 남은 작업을 다시 큐에 넣는다.
+// 작업 없이 queued, processing 상태로 남은 리뷰도 큐에 등록한다.
+func recoverReviewJobs(session *mgo.Session) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("reviewjob")
+	now := time.Now().Format(time.RFC3339)
+	_, err := c.UpdateAll(bson.M{"status": "running"}, bson.M{"$set": bson.M{"status": "queued", "nextretrytime": now}})
+	if err != nil {
+		return err
+	}
+	var reviews []Review
+	rc := session.DB("csi").C("review")
+	err = rc.Find(bson.M{"processstatus": bson.M{"$in": []string{"queued", "processing"}}}).All(&reviews)
+	if err != nil {
+		return err
+	}
+	for _, r := range reviews {
+		n, err := c.Find(bson.M{"id": r.ID.Hex(), "status": "queued"}).Count()
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			continue
+		}
+		err = addReviewJob(session, r)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueueWaitReviews 함수는 processstatus가 wait인 리뷰를 큐에 등록하고 등록한 개수를 반환한다.
+func enqueueWaitReviews(session *mgo.Session) (int, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("review")
+	var reviews []Review
+	err := c.Find(bson.M{"processstatus": "wait"}).All(&reviews)
+	if err != nil {
+		return 0, err
+	}
+	for _, r := range reviews {
+		err = addReviewJob(session, r)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(reviews), nil
+}
+
+// allReviewJobs 함수는 ReviewJob 리스트를 반환한다. status가 빈 문자열이면 모든 작업을 반환한다.
+func allReviewJobs(session *mgo.Session, status string) ([]ReviewJob, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("reviewjob")
+	q := bson.M{}
+	if status != "" {
+		q["status"] = status
+	}
+	results := []ReviewJob{}
+	err := c.Find(q).Sort("-createtime").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// rmReviewJob 함수는 ReviewJob을 DB에서 삭제한다.
+func rmReviewJob(session *mgo.Session, id string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("reviewjob")
+	err := c.Remove(bson.M{"id": id})
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"sort"
+
+	"gopkg.in/mgo.v2"
+)
+
+// TaskRisks 함수는 프로젝트 Task의 마감 위험도 리스트를 반환한다. tasks가 비어있으면 전체 Task를 계산한다.
+// 아티스트의 작업량은 전체 프로젝트의 Task로 계산한다.
+func TaskRisks(session *mgo.Session, project string, tasks []string) ([]TaskRisk, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return nil, err
+	}
+	items, err := allScheduleItems(session)
+	if err != nil {
+		return nil, err
+	}
+	status, err := AllStatus(session)
+	if err != nil {
+		return nil, err
+	}
+	complete, err := CompleteStatusMap(session)
+	if err != nil {
+		return nil, err
+	}
+	calendars, err := allWorkCalendars(session)
+	if err != nil {
+		return nil, err
+	}
+	rc := riskContext{
+		Today:     riskToday(),
+		Progress:  statusProgress(status),
+		Complete:  complete,
+		Calendars: calendars,
+		Loads:     userDayLoads(capacityTasks(items, complete), calendars),
+	}
+	risks := []TaskRisk{}
+	for _, i := range items[project] {
+		var names []string
+		for name := range i.Tasks {
+			if len(tasks) != 0 && !inStrings(tasks, name) {
+				continue
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			risks = append(risks, rc.assess(project, i, name, i.Tasks[name]))
+		}
+	}
+	return risks, nil
+}
+
+// GetRiskReport 함수는 프로젝트의 시퀀스, Task별 마감 위험도 리포트를 반환한다.
+func GetRiskReport(session *mgo.Session, project string) (RiskReport, error) {
+	risks, err := TaskRisks(session, project, nil)
+	if err != nil {
+		return RiskReport{}, err
+	}
+	return buildRiskReport(project, riskToday(), risks), nil
+}
+
+// RiskItemIDs 함수는 level(on-track, at-risk, late) 위험도의 Task가 있는 아이템 ID 리스트를 반환한다.
+// tasks가 비어있으면 전체 Task에서 찾는다.
+func RiskItemIDs(session *mgo.Session, project, level string, tasks []string) ([]string, error) {
+	risks, err := TaskRisks(session, project, tasks)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, r := range risks {
+		if r.Level == level && !inStrings(ids, r.ID) {
+			ids = append(ids, r.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/digital-idea/ditime"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// scheduleItems 함수는 간트차트 계산에 필요한 필드만 가진 프로젝트의 아이템 리스트를 가지고 온다.
+func scheduleItems(session *mgo.Session, project string) ([]Item, error) {
+	c := session.DB("project").C(project)
+	var items []Item
+	err := c.Find(bson.M{}).Select(bson.M{"id": 1, "name": 1, "seq": 1, "type": 1, "shottype": 1, "ddline2d": 1, "ddline3d": 1, "tasks": 1, "dependencies": 1}).Sort("name").All(&items)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// allScheduleItems 함수는 전체 프로젝트의 아이템 리스트를 프로젝트 이름을 키로 하는 맵으로 가지고 온다.
+func allScheduleItems(session *mgo.Session) (map[string][]Item, error) {
+	projects, err := getProjects(session)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[string][]Item)
+	for _, p := range projects {
+		list, err := scheduleItems(session, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		items[p.ID] = list
+	}
+	return items, nil
+}
+
+// GetSchedule 함수는 간트차트 데이터를 반환한다. project가 빈 문자열이면 전체 프로젝트에서 계산한다.
+// 아티스트, 팀별 일정은 f.User, f.Team 으로 거른다.
+func GetSchedule(session *mgo.Session, project string, f ScheduleFilter) (Schedule, error) {
+	session.SetMode(mgo.Monotonic, true)
+	var projects []Project
+	if project != "" {
+		p, err := getProject(session, project)
+		if err != nil {
+			return Schedule{}, err
+		}
+		projects = append(projects, p)
+	} else {
+		all, err := getProjects(session)
+		if err != nil {
+			return Schedule{}, err
+		}
+		projects = all
+	}
+	items := make(map[string][]Item)
+	for _, p := range projects {
+		list, err := scheduleItems(session, p.ID)
+		if err != nil {
+			return Schedule{}, err
+		}
+		items[p.ID] = list
+	}
+	teams, err := userTeams(session)
+	if err != nil {
+		return Schedule{}, err
+	}
+	return buildSchedule(items, projects, teams, f), nil
+}
+
+// shiftFullTime 함수는 RFC3339 시간을 days 만큼 옮긴다. 빈 문자열은 그대로 반환한다.
+func shiftFullTime(fulltime string, days int) (string, error) {
+	if fulltime == "" {
+		return "", nil
+	}
+	t, err := time.Parse(time.RFC3339, fulltime)
+	if err != nil {
+		return "", err
+	}
+	return t.AddDate(0, 0, days).Format(time.RFC3339), nil
+}
+
+// Reschedule 함수는 Task의 시작일, 1차 마감일, 2차 마감일을 바꾸고 이 Task와 관련된 일정 충돌을 반환한다.
+// 빈 값은 바꾸지 않는다. shift가 0이 아니면 기존 날짜를 shift일 만큼 옮긴 뒤 입력한 날짜를 적용한다. 간트차트에서 바를 드래그할 때 사용한다.
+// 날짜는 SetTaskStartdate, SetTaskPredate, SetTaskDate와 같은 형식을 사용한다.
+func Reschedule(session *mgo.Session, project, id, userID, task, startdate, predate, date string, shift int) (ScheduleBar, []ScheduleConflict, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasTask(session, project, id, task)
+	if err != nil {
+		return ScheduleBar{}, nil, err
+	}
+	i, err := getItem(session, project, id)
+	if err != nil {
+		return ScheduleBar{}, nil, err
+	}
+	t := i.Tasks[task]
+	if shift != 0 {
+		for _, v := range []*string{&t.Startdate, &t.Predate, &t.Date} {
+			*v, err = shiftFullTime(*v, shift)
+			if err != nil {
+				return ScheduleBar{}, nil, err
+			}
+		}
+	}
+	for _, v := range []struct {
+		input string
+		field *string
+	}{{startdate, &t.Startdate}, {predate, &t.Predate}, {date, &t.Date}} {
+		if v.input == "" {
+			continue
+		}
+		fullTime, err := ditime.ToFullTime(19, v.input)
+		if err != nil {
+			return ScheduleBar{}, nil, err
+		}
+		*v.field = fullTime
+	}
+	err = checkTaskDates(t.Startdate, t.Predate, t.Date)
+	if err != nil {
+		return ScheduleBar{}, nil, fmt.Errorf("%s %s: %v", id, task, err)
+	}
+	err = updateItem(session, project, id, userID, "Reschedule", bson.M{"$set": bson.M{
+		"tasks." + task + ".startdate": t.Startdate,
+		"tasks." + task + ".predate":   t.Predate,
+		"tasks." + task + ".date":      t.Date,
+		"updatetime":                   time.Now().Format(time.RFC3339),
+	}})
+	if err != nil {
+		return ScheduleBar{}, nil, err
+	}
+	i.Tasks[task] = t
+	teams, err := userTeams(session)
+	if err != nil {
+		return ScheduleBar{}, nil, err
+	}
+	bar, _ := newScheduleBar(project, i, task, t, teams)
+	s, err := GetSchedule(session, project, ScheduleFilter{})
+	if err != nil {
+		return bar, nil, err
+	}
+	conflicts := []ScheduleConflict{}
+	for _, c := range s.Conflicts {
+		if inStrings(c.Bars, bar.key()) {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return bar, conflicts, nil
+}
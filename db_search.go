@@ -111,6 +111,32 @@ func GenQuery(session *mgo.Session, op SearchOption) (SearchOption, bson.M) {
 			query = append(query, bson.M{"type": "asset"})
 		} else if strings.HasPrefix(word, "episode:") {
 			query = append(query, bson.M{"episode": &bson.RegEx{Pattern: strings.TrimPrefix(word, "episode:"), Options: "i"}})
+		} else if strings.HasPrefix(word, "plate:") {
+			query = append(query, bson.M{"plates.type": strings.TrimPrefix(word, "plate:")})
+		} else if strings.HasPrefix(word, "activeplate:") {
+			query = append(query, bson.M{"activeplate": strings.TrimPrefix(word, "activeplate:")})
+		} else if strings.HasPrefix(word, "breakdownflag:") {
+			if strings.TrimPrefix(word, "breakdownflag:") == "false" {
+				query = append(query, bson.M{"breakdown.flagged": bson.M{"$ne": true}})
+			} else {
+				query = append(query, bson.M{"breakdown.flagged": true})
+			}
+		} else if strings.HasPrefix(word, "breakdown:") {
+			query = append(query, bson.M{"breakdown.asset": strings.TrimPrefix(word, "breakdown:")})
+		} else if strings.HasPrefix(word, "seq:") {
+			query = append(query, bson.M{"seq": &bson.RegEx{Pattern: strings.TrimPrefix(word, "seq:"), Options: "i"}})
+		} else if strings.HasPrefix(word, "seqstatus:") || strings.HasPrefix(word, "episodestatus:") {
+			// 시퀀스, 에피소드의 상태는 itemgroup 컬렉션에 있으므로 해당 상태인 이름을 먼저 구한다.
+			kind := ItemGroupSeq
+			if strings.HasPrefix(word, "episodestatus:") {
+				kind = ItemGroupEpisode
+			}
+			names, err := itemGroupNamesByStatus(session, op.Project, kind, strings.SplitN(word, ":", 2)[1])
+			if err != nil {
+				log.Println(err)
+				names = []string{}
+			}
+			query = append(query, itemGroupStatusQuery(kind, names))
 		} else if strings.HasPrefix(word, "season:") {
 			query = append(query, bson.M{"season": &bson.RegEx{Pattern: strings.TrimPrefix(word, "season:"), Options: "i"}})
 		} else if strings.HasPrefix(word, "status:") {
@@ -192,6 +218,7 @@ func GenQuery(session *mgo.Session, op SearchOption) (SearchOption, bson.M) {
 				} else {
 					for _, task := range allTasks {
 						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".user": &bson.RegEx{Pattern: strings.TrimPrefix(word, "user:")}})
+						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".assignees.user": &bson.RegEx{Pattern: strings.TrimPrefix(word, "user:")}})
 					}
 				}
 			} else {
@@ -200,6 +227,7 @@ func GenQuery(session *mgo.Session, op SearchOption) (SearchOption, bson.M) {
 						query = append(query, bson.M{"tasks." + task + ".user": ""})
 					} else {
 						query = append(query, bson.M{"tasks." + task + ".user": &bson.RegEx{Pattern: strings.TrimPrefix(word, "user:")}})
+						query = append(query, bson.M{"tasks." + task + ".assignees.user": &bson.RegEx{Pattern: strings.TrimPrefix(word, "user:")}})
 					}
 				}
 			}
@@ -222,6 +250,28 @@ func GenQuery(session *mgo.Session, op SearchOption) (SearchOption, bson.M) {
 					}
 				}
 			}
+		} else if strings.HasPrefix(word, "blocked:") {
+			// 의존관계는 그래프 계산이 필요하므로 블로커가 있는 아이템 ID를 먼저 구한다.
+			ids, err := BlockedItemIDs(session, op.Project)
+			if err != nil {
+				// $in, $nin에 nil을 넣으면 검색 전체가 실패하므로 빈 리스트로 검색한다.
+				log.Println(err)
+				ids = []string{}
+			}
+			if strings.TrimPrefix(word, "blocked:") == "false" {
+				query = append(query, bson.M{"id": bson.M{"$nin": ids}})
+			} else {
+				query = append(query, bson.M{"id": bson.M{"$in": ids}})
+			}
+		} else if strings.HasPrefix(word, "risk:") {
+			// 마감 위험도는 아티스트 작업량 계산이 필요하므로 해당 위험도의 Task가 있는 아이템 ID를 먼저 구한다.
+			ids, err := RiskItemIDs(session, op.Project, strings.TrimPrefix(word, "risk:"), selectTasks)
+			if err != nil {
+				// $in에 nil을 넣으면 검색 전체가 실패하므로 아무것도 찾지 않도록 빈 리스트로 검색한다.
+				log.Println(err)
+				ids = []string{}
+			}
+			query = append(query, bson.M{"id": bson.M{"$in": ids}})
 		} else if strings.HasPrefix(word, "rnum:") { // 롤넘버 형태일 때
 			query = append(query, bson.M{"rnum": &bson.RegEx{Pattern: strings.TrimPrefix(word, "rnum:"), Options: "i"}})
 		} else if regexTaskStatusQuery.MatchString(word) {
@@ -249,17 +299,20 @@ func GenQuery(session *mgo.Session, op SearchOption) (SearchOption, bson.M) {
 				query = append(query, bson.M{"tag": &bson.RegEx{Pattern: word, Options: "i"}})
 				query = append(query, bson.M{"assettags": &bson.RegEx{Pattern: word, Options: "i"}})
 				query = append(query, bson.M{"scanname": &bson.RegEx{Pattern: word, Options: ""}})
+				query = append(query, bson.M{"plates.scanname": &bson.RegEx{Pattern: word, Options: ""}})
 				query = append(query, bson.M{"rnum": &bson.RegEx{Pattern: word, Options: ""}})
 				// Task가 선언 되어있을 때
 				if len(selectTasks) == 0 {
 					for _, task := range allTasks {
-						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".user": &bson.RegEx{Pattern: word}})        // 아티스트명을 검색한다.
-						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".usercomment": &bson.RegEx{Pattern: word}}) // UserComment를 검색한다.
+						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".user": &bson.RegEx{Pattern: word}})           // 아티스트명을 검색한다.
+						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".assignees.user": &bson.RegEx{Pattern: word}}) // 여러 아티스트가 작업하는 Task의 아티스트명을 검색한다.
+						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".usercomment": &bson.RegEx{Pattern: word}})    // UserComment를 검색한다.
 					}
 				} else {
 					for _, task := range selectTasks {
-						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".user": &bson.RegEx{Pattern: word}})        // 아티스트명을 검색한다.
-						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".usercomment": &bson.RegEx{Pattern: word}}) // UserComment를 검색한다.
+						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".user": &bson.RegEx{Pattern: word}})           // 아티스트명을 검색한다.
+						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".assignees.user": &bson.RegEx{Pattern: word}}) // 여러 아티스트가 작업하는 Task의 아티스트명을 검색한다.
+						query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".usercomment": &bson.RegEx{Pattern: word}})    // UserComment를 검색한다.
 					}
 				}
 			}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AddTaskTemplate 함수는 Task 템플릿을 DB에 추가한다.
+func AddTaskTemplate(session *mgo.Session, t TaskTemplate) error {
+	session.SetMode(mgo.Monotonic, true)
+	settings, err := AllTaskSettings(session)
+	if err != nil {
+		return err
+	}
+	err = t.CheckError(settings)
+	if err != nil {
+		return err
+	}
+	c := session.DB("setting").C("tasktemplate")
+	num, err := c.Find(bson.M{"id": t.ID}).Count()
+	if err != nil {
+		return err
+	}
+	if num > 0 {
+		return errors.New("이미 Task 템플릿이 존재합니다")
+	}
+	t.Updatetime = time.Now().Format(time.RFC3339)
+	return c.Insert(t)
+}
+
+// SetTaskTemplate 함수는 Task 템플릿을 수정한다.
+func SetTaskTemplate(session *mgo.Session, t TaskTemplate) error {
+	session.SetMode(mgo.Monotonic, true)
+	settings, err := AllTaskSettings(session)
+	if err != nil {
+		return err
+	}
+	err = t.CheckError(settings)
+	if err != nil {
+		return err
+	}
+	t.Updatetime = time.Now().Format(time.RFC3339)
+	c := session.DB("setting").C("tasktemplate")
+	return c.Update(bson.M{"id": t.ID}, t)
+}
+
+// RmTaskTemplate 함수는 Task 템플릿을 삭제한다. 프로젝트에서 사용중인 템플릿은 삭제할 수 없다.
+func RmTaskTemplate(session *mgo.Session, id string) error {
+	session.SetMode(mgo.Monotonic, true)
+	projects, err := getProjects(session)
+	if err != nil {
+		return err
+	}
+	for _, p := range projects {
+		for _, rule := range p.TaskTemplates {
+			if rule.Template == id {
+				return fmt.Errorf("%s 프로젝트에서 %s 템플릿을 사용중입니다", p.ID, id)
+			}
+		}
+	}
+	c := session.DB("setting").C("tasktemplate")
+	return c.Remove(bson.M{"id": id})
+}
+
+// getTaskTemplate 함수는 id를 입력받아 Task 템플릿을 가지고 온다.
+func getTaskTemplate(session *mgo.Session, id string) (TaskTemplate, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("setting").C("tasktemplate")
+	result := TaskTemplate{}
+	err := c.Find(bson.M{"id": id}).One(&result)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			return result, fmt.Errorf("%s Task 템플릿이 존재하지 않습니다", id)
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// AllTaskTemplates 함수는 모든 Task 템플릿을 가지고 온다.
+func AllTaskTemplates(session *mgo.Session) ([]TaskTemplate, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("setting").C("tasktemplate")
+	results := []TaskTemplate{}
+	err := c.Find(bson.M{}).Sort("id").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// SetProjectTaskTemplate 함수는 프로젝트의 아이템 타입, shottype 별 Task 템플릿을 설정한다. rule.Template이 빈 문자열이면 설정을 삭제한다.
+func SetProjectTaskTemplate(session *mgo.Session, project string, rule TaskTemplateRule) error {
+	session.SetMode(mgo.Monotonic, true)
+	p, err := getProject(session, project)
+	if err != nil {
+		return err
+	}
+	if rule.Template != "" {
+		_, err = getTaskTemplate(session, rule.Template)
+		if err != nil {
+			return err
+		}
+	}
+	err = p.SetTaskTemplateRule(rule)
+	if err != nil {
+		return err
+	}
+	return setProject(session, p)
+}
+
+// itemTaskTemplateTasks 함수는 아이템에 사용할 템플릿 ID와 템플릿으로 생성할 Task 맵을 반환한다.
+// template이 빈 문자열이면 프로젝트에 설정된 템플릿을 사용한다. 사용할 템플릿이 없다면 빈 문자열과 nil을 반환한다.
+func itemTaskTemplateTasks(session *mgo.Session, p Project, i Item, template string) (string, map[string]Task, error) {
+	if template == "" {
+		template = p.TaskTemplateID(i)
+	}
+	if template == "" {
+		return "", nil, nil
+	}
+	t, err := getTaskTemplate(session, template)
+	if err != nil {
+		return template, nil, err
+	}
+	settings, err := AllTaskSettings(session)
+	if err != nil {
+		return template, nil, err
+	}
+	initStatusID, err := GetInitStatusID(session)
+	if err != nil {
+		return template, nil, err
+	}
+	return template, taskTemplateTasks(t, settings, initStatusID), nil
+}
+
+// ApplyTaskTemplate 함수는 기존 아이템에 Task 템플릿을 다시 적용하고 추가되거나 삭제되는 Task 리스트를 반환한다.
+// template이 빈 문자열이면 프로젝트에 설정된 템플릿을 사용한다. dryrun이 true이면 DB를 수정하지 않고 미리보기만 반환한다.
+func ApplyTaskTemplate(session *mgo.Session, project, id, userID, template string, remove, dryrun bool) (TaskTemplateDiff, error) {
+	session.SetMode(mgo.Monotonic, true)
+	p, err := getProject(session, project)
+	if err != nil {
+		return TaskTemplateDiff{ID: id}, err
+	}
+	i, err := getItem(session, project, id)
+	if err != nil {
+		return TaskTemplateDiff{ID: id}, err
+	}
+	template, tasks, err := itemTaskTemplateTasks(session, p, i, template)
+	if err != nil {
+		return TaskTemplateDiff{ID: id, Template: template}, err
+	}
+	if template == "" {
+		return TaskTemplateDiff{ID: id}, fmt.Errorf("%s 에 적용할 Task 템플릿이 없습니다", id)
+	}
+	diff := applyTaskTemplate(&i, tasks, remove)
+	diff.Template = template
+	if dryrun || (len(diff.Add) == 0 && (!remove || len(diff.Remove) == 0)) {
+		return diff, nil
+	}
+	err = updateItem(session, project, id, userID, "ApplyTaskTemplate", bson.M{"$set": bson.M{"tasks": i.Tasks, "updatetime": time.Now().Format(time.RFC3339)}})
+	if err != nil {
+		return diff, err
+	}
+	return diff, nil
+}
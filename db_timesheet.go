@@ -0,0 +1,244 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// TimeLogFilter 자료구조는 타임시트를 검색할 때 사용하는 조건이다. 빈 값은 조건에서 제외한다.
+type TimeLogFilter struct {
+	Project string
+	Item    string
+	Task    string
+	UserID  string
+	Start   string // 시작일 2006-01-02
+	End     string // 종료일 2006-01-02
+	Status  string
+}
+
+// query 메소드는 검색조건을 DB 쿼리로 바꾼다.
+func (f TimeLogFilter) query() bson.M {
+	q := bson.M{}
+	if f.Project != "" {
+		q["project"] = f.Project
+	}
+	if f.Item != "" {
+		q["item"] = f.Item
+	}
+	if f.Task != "" {
+		q["task"] = f.Task
+	}
+	if f.UserID != "" {
+		q["userid"] = f.UserID
+	}
+	if f.Status != "" {
+		q["status"] = f.Status
+	}
+	date := bson.M{}
+	if f.Start != "" {
+		date["$gte"] = f.Start
+	}
+	if f.End != "" {
+		date["$lte"] = f.End
+	}
+	if len(date) != 0 {
+		q["date"] = date
+	}
+	return q
+}
+
+// AddTimeLog 함수는 타임시트를 추가하고 Task의 ResultDay를 다시 계산한다.
+func AddTimeLog(session *mgo.Session, l TimeLog) (TimeLog, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := l.CheckError()
+	if err != nil {
+		return l, err
+	}
+	err = HasProject(session, l.Project)
+	if err != nil {
+		return l, err
+	}
+	err = HasTask(session, l.Project, l.Item, l.Task)
+	if err != nil {
+		return l, err
+	}
+	// 같은 날 같은 Task의 기록은 하나의 타임시트로 관리한다.
+	c := session.DB("csi").C("timesheet")
+	old := TimeLog{}
+	err = c.Find(bson.M{"project": l.Project, "item": l.Item, "task": l.Task, "userid": l.UserID, "date": l.Date}).One(&old)
+	if err == nil {
+		if old.Status == "approved" {
+			return old, fmt.Errorf("%s 타임시트는 이미 승인되어 수정할 수 없습니다", l.Date)
+		}
+		l.ID = old.ID
+		l.Createtime = old.Createtime
+	} else if err != mgo.ErrNotFound {
+		return l, err
+	} else {
+		l.ID = bson.NewObjectId()
+		l.Createtime = time.Now().Format(time.RFC3339)
+	}
+	l.Status = "pending"
+	l.Approver = ""
+	l.Approvetime = ""
+	_, err = c.UpsertId(l.ID, l)
+	if err != nil {
+		return l, err
+	}
+	return l, updateTaskResultDay(session, l.Project, l.Item, l.Task, l.UserID)
+}
+
+// getTimeLog 함수는 id에 해당하는 타임시트를 가지고 온다.
+func getTimeLog(session *mgo.Session, id string) (TimeLog, error) {
+	session.SetMode(mgo.Monotonic, true)
+	if !bson.IsObjectIdHex(id) {
+		return TimeLog{}, errors.New("id가 ObjectId 형식이 아닙니다")
+	}
+	c := session.DB("csi").C("timesheet")
+	l := TimeLog{}
+	err := c.FindId(bson.ObjectIdHex(id)).One(&l)
+	if err != nil {
+		return l, err
+	}
+	return l, nil
+}
+
+// RmTimeLog 함수는 타임시트를 삭제하고 Task의 ResultDay를 다시 계산한다. 승인된 타임시트는 삭제할 수 없다.
+func RmTimeLog(session *mgo.Session, id, userID string) (TimeLog, error) {
+	session.SetMode(mgo.Monotonic, true)
+	l, err := getTimeLog(session, id)
+	if err != nil {
+		return l, err
+	}
+	if l.UserID != userID {
+		return l, errors.New("다른 사용자의 타임시트는 삭제할 수 없습니다")
+	}
+	if l.Status == "approved" {
+		return l, errors.New("승인된 타임시트는 삭제할 수 없습니다")
+	}
+	c := session.DB("csi").C("timesheet")
+	err = c.RemoveId(l.ID)
+	if err != nil {
+		return l, err
+	}
+	return l, updateTaskResultDay(session, l.Project, l.Item, l.Task, userID)
+}
+
+// searchTimeLogs 함수는 조건에 맞는 타임시트를 날짜순으로 가지고 온다.
+func searchTimeLogs(session *mgo.Session, f TimeLogFilter) ([]TimeLog, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("csi").C("timesheet")
+	results := []TimeLog{}
+	err := c.Find(f.query()).Sort("date", "project", "item", "task").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ApproveTimesheet 함수는 아티스트(userID)의 date가 속한 주의 타임시트를 승인(approved) 또는 반려(rejected)하고 승인, 반려된 타임시트 수를 반환한다.
+// 반려된 타임시트는 ResultDay 계산에서 제외된다.
+func ApproveTimesheet(session *mgo.Session, approverID, userID, date, status string) (int, error) {
+	session.SetMode(mgo.Monotonic, true)
+	if status != "approved" && status != "rejected" {
+		return 0, errors.New("status는 approved 또는 rejected 이어야 합니다")
+	}
+	if approverID == userID {
+		return 0, errors.New("본인의 타임시트는 승인할 수 없습니다")
+	}
+	start, end, err := weekRange(date)
+	if err != nil {
+		return 0, err
+	}
+	logs, err := searchTimeLogs(session, TimeLogFilter{UserID: userID, Start: start, End: end})
+	if err != nil {
+		return 0, err
+	}
+	c := session.DB("csi").C("timesheet")
+	info, err := c.UpdateAll(bson.M{"userid": userID, "date": bson.M{"$gte": start, "$lte": end}}, bson.M{"$set": bson.M{
+		"status":      status,
+		"approver":    approverID,
+		"approvetime": time.Now().Format(time.RFC3339),
+	}})
+	if err != nil {
+		return 0, err
+	}
+	// 상태가 바뀐 Task의 ResultDay를 다시 계산한다.
+	done := make(map[string]bool)
+	for _, l := range logs {
+		key := l.Project + "/" + l.Item + "/" + l.Task
+		if done[key] {
+			continue
+		}
+		done[key] = true
+		err = updateTaskResultDay(session, l.Project, l.Item, l.Task, approverID)
+		if err != nil {
+			return info.Updated, err
+		}
+	}
+	return info.Updated, nil
+}
+
+// updateTaskResultDay 함수는 타임시트로 Task의 ResultDay를 다시 계산해서 저장한다.
+func updateTaskResultDay(session *mgo.Session, project, id, task, userID string) error {
+	i, err := getItem(session, project, id)
+	if err != nil {
+		return err
+	}
+	t, ok := i.Tasks[task]
+	if !ok {
+		return nil // Task가 삭제되었다면 계산하지 않는다.
+	}
+	logs, err := searchTimeLogs(session, TimeLogFilter{Project: project, Item: id, Task: task})
+	if err != nil {
+		return err
+	}
+	t.applyTimeLogs(logs)
+	return updateTaskAssignees(session, project, id, userID, task, t, "Timesheet")
+}
+
+// userTeams 함수는 전체 사용자의 ID:팀 이름 맵을 반환한다. 여러 조직에 속해있다면 Primary 조직의 팀을 사용한다.
+func userTeams(session *mgo.Session) (map[string]string, error) {
+	users, err := allUsers(session)
+	if err != nil {
+		return nil, err
+	}
+	teams := make(map[string]string)
+	for _, u := range users {
+		for _, o := range u.Organizations {
+			if o.Primary {
+				teams[u.ID] = o.Team.Name
+				break
+			}
+			teams[u.ID] = o.Team.Name
+		}
+	}
+	return teams, nil
+}
+
+// TimesheetReport 함수는 프로젝트의 예측 맨데이와 타임시트 작업시간을 groupby(task, user, team) 기준으로 비교한 리포트를 반환한다.
+func TimesheetReport(session *mgo.Session, project, groupby string) ([]TimesheetReportRow, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return nil, err
+	}
+	var items []Item
+	err = session.DB("project").C(project).Find(bson.M{}).Select(bson.M{"id": 1, "tasks": 1}).All(&items)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := searchTimeLogs(session, TimeLogFilter{Project: project})
+	if err != nil {
+		return nil, err
+	}
+	teams, err := userTeams(session)
+	if err != nil {
+		return nil, err
+	}
+	return timesheetReport(items, logs, groupby, teams)
+}
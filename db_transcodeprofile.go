@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SetTranscodeProfile 함수는 TranscodeProfile을 DB에 저장한다.
+func SetTranscodeProfile(session *mgo.Session, t TranscodeProfile) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("setting").C("transcodeprofile")
+	err := c.Update(bson.M{"id": t.ID}, t)
+	if err != nil {
+		if err == mgo.ErrNotFound {
+			err = c.Insert(t)
+			if err != nil {
+				return err
+			}
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// GetTranscodeProfile 함수는 TranscodeProfile을 DB에서 가지고 온다.
+func GetTranscodeProfile(session *mgo.Session, id string) (TranscodeProfile, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("setting").C("transcodeprofile")
+	t := TranscodeProfile{}
+	err := c.Find(bson.M{"id": id}).One(&t)
+	if err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// HasTranscodeProfile 함수는 TranscodeProfile이 존재하는지 체크하는 함수이다.
+func HasTranscodeProfile(session *mgo.Session, id string) bool {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("setting").C("transcodeprofile")
+	n, err := c.Find(bson.M{"id": id}).Count()
+	if err != nil {
+		return false
+	}
+	if n == 0 {
+		return false
+	}
+	return true
+}
+
+// AddTranscodeProfile 함수는 TranscodeProfile을 DB에 추가한다.
+func AddTranscodeProfile(session *mgo.Session, t TranscodeProfile) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("setting").C("transcodeprofile")
+	n, err := c.Find(bson.M{"id": t.ID}).Count()
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return errors.New(t.ID + " TranscodeProfile이 이미 존재합니다")
+	}
+	err = c.Insert(t)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RmTranscodeProfile 함수는 TranscodeProfile을 DB에서 삭제한다.
+func RmTranscodeProfile(session *mgo.Session, id string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("setting").C("transcodeprofile")
+	err := c.Remove(bson.M{"id": id})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// AllTranscodeProfiles 함수는 모든 TranscodeProfile 값을 DB에서 가지고 온다.
+func AllTranscodeProfiles(session *mgo.Session) ([]TranscodeProfile, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("setting").C("transcodeprofile")
+	results := []TranscodeProfile{}
+	err := c.Find(bson.M{}).Sort("id").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
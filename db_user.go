@@ -41,6 +41,8 @@ func addUser(session *mgo.Session, u User) error {
 		log.Println(err)
 		return err
 	}
+	// 사용자 이벤트는 패스워드, 토큰이 포함되지 않도록 ID만 담는다.
+	publishEvent(session, Event{Type: EventUserAdd, Target: u.ID})
 	return nil
 }
 
@@ -125,6 +127,7 @@ func rmUser(session *mgo.Session, id string) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, Event{Type: EventUserRemove, Target: id})
 	return nil
 }
 
@@ -155,6 +158,7 @@ func setUser(session *mgo.Session, u User) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, Event{Type: EventUserUpdate, Target: u.ID})
 	return nil
 }
 
@@ -189,6 +193,7 @@ func initPassUser(session *mgo.Session, id, initpassword string) error {
 		log.Println(err)
 		return err
 	}
+	publishEvent(session, Event{Type: EventUserUpdate, Target: id, Changes: []EventChange{{Field: "password"}}})
 	return nil
 }
 
@@ -225,6 +230,7 @@ func updatePasswordUser(session *mgo.Session, id, pw, newPw string) error {
 	if err != nil {
 		return err
 	}
+	publishEvent(session, Event{Type: EventUserUpdate, Target: id, UserID: id, Changes: []EventChange{{Field: "password"}}})
 	return nil
 }
 
@@ -397,6 +403,7 @@ func setLeaveUser(session *mgo.Session, id string, leave bool) error {
 			return err
 		}
 	}
+	publishEvent(session, Event{Type: EventUserUpdate, Target: id, Changes: []EventChange{{Field: "isleave", New: leave}}})
 	return nil
 }
 
@@ -437,6 +444,7 @@ func ReplaceTags(session *mgo.Session, old, new string) error {
 		if err != nil {
 			return err
 		}
+		publishEvent(session, Event{Type: EventUserUpdate, Target: u.ID, Changes: []EventChange{{Field: "tags", New: u.Tags}}})
 	}
 	// 각 유저를 체크하면서 태그이름을 변경한다.
 	return nil
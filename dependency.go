@@ -0,0 +1,186 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Dependency 자료구조는 아이템 또는 Task 사이의 의존관계이다. 의존관계는 하위 아이템에 저장한다.
+// 예) SS_0010_org 의 lighting Task는 stone_asset 의 lookdev Task가 끝나야 진행할 수 있다.
+type Dependency struct {
+	Task         string `json:"task"`         // 이 아이템의 Task. 빈 문자열이면 아이템 전체가 의존한다.
+	Upstream     string `json:"upstream"`     // 상위 아이템 ID. 예) stone_asset
+	UpstreamTask string `json:"upstreamtask"` // 상위 아이템의 Task. 빈 문자열이면 상위 아이템 전체에 의존한다.
+	Author       string `json:"author"`       // 작성자
+	Createtime   string `json:"createtime"`   // 생성시간 RFC3339
+}
+
+// Blocker 자료구조는 아이템(Task)이 진행되기 전에 끝나야 하는 상위 아이템(Task)이다.
+type Blocker struct {
+	ID       string `json:"id"`       // 상위 아이템 ID
+	Task     string `json:"task"`     // 상위 Task. 빈 문자열이면 아이템 전체이다.
+	StatusV2 string `json:"statusv2"` // 상위 아이템 또는 Task의 상태
+	Depth    int    `json:"depth"`    // 의존관계 단계. 직접 의존하면 1이다.
+}
+
+// dependencyNode 자료구조는 의존관계 그래프의 노드이다. Task가 빈 문자열이면 아이템 전체를 뜻한다.
+type dependencyNode struct {
+	ID   string
+	Task string
+}
+
+// String 메소드는 노드를 id 또는 id:task 형태의 문자열로 반환한다.
+func (n dependencyNode) String() string {
+	if n.Task == "" {
+		return n.ID
+	}
+	return n.ID + ":" + n.Task
+}
+
+// dependencyEdge 자료구조는 노드에서 상위 노드로 가는 간선이다.
+// 아이템 전체 노드는 자신의 Task가 모두 끝나야 끝나므로 자신의 Task 노드로 가는 암시적 간선을 가진다.
+// 아이템 전체에 걸린 의존관계는 그 아이템의 모든 Task에도 적용된다.
+type dependencyEdge struct {
+	To       dependencyNode
+	Implicit bool
+}
+
+// dependencyEdges 함수는 노드의 상위 노드 리스트를 반환한다. items는 아이템 ID를 키로 사용한다.
+func dependencyEdges(items map[string]Item, n dependencyNode) []dependencyEdge {
+	item, ok := items[n.ID]
+	if !ok {
+		return nil
+	}
+	var edges []dependencyEdge
+	for _, d := range item.Dependencies {
+		if d.Task == n.Task || d.Task == "" {
+			edges = append(edges, dependencyEdge{To: dependencyNode{ID: d.Upstream, Task: d.UpstreamTask}})
+		}
+	}
+	if n.Task == "" {
+		var tasks []string
+		for task := range item.Tasks {
+			tasks = append(tasks, task)
+		}
+		sort.Strings(tasks)
+		for _, task := range tasks {
+			edges = append(edges, dependencyEdge{To: dependencyNode{ID: n.ID, Task: task}, Implicit: true})
+		}
+	}
+	return edges
+}
+
+// dependencyReachable 함수는 from 노드에서 상위 방향으로 to 노드에 도달할 수 있는지 체크한다.
+// 아이템 전체 노드와 그 아이템의 Task 노드는 서로 포함관계이므로 같은 노드로 취급한다.
+func dependencyReachable(items map[string]Item, from, to dependencyNode) bool {
+	visited := map[dependencyNode]bool{from: true}
+	queue := []dependencyNode{from}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if n.ID == to.ID && (n.Task == to.Task || n.Task == "" || to.Task == "") {
+			return true
+		}
+		for _, e := range dependencyEdges(items, n) {
+			if visited[e.To] {
+				continue
+			}
+			visited[e.To] = true
+			queue = append(queue, e.To)
+		}
+	}
+	return false
+}
+
+// validDependency 함수는 id 아이템에 의존관계 d를 추가할 수 있는지 체크한다.
+// 아이템과 Task가 존재해야 하고, 중복되거나 순환하는 의존관계는 추가할 수 없다.
+func validDependency(items map[string]Item, id string, d Dependency) error {
+	item, ok := items[id]
+	if !ok {
+		return fmt.Errorf("%s 아이템이 존재하지 않습니다", id)
+	}
+	if d.Task != "" {
+		if _, ok := item.Tasks[d.Task]; !ok {
+			return fmt.Errorf("%s 에 %s task가 존재하지 않습니다", id, d.Task)
+		}
+	}
+	if d.Upstream == "" {
+		return errors.New("상위 아이템(upstream)을 설정해주세요")
+	}
+	if d.Upstream == id {
+		return errors.New("자기 자신에게 의존할 수 없습니다")
+	}
+	upstream, ok := items[d.Upstream]
+	if !ok {
+		return fmt.Errorf("%s 아이템이 존재하지 않습니다", d.Upstream)
+	}
+	if d.UpstreamTask != "" {
+		if _, ok := upstream.Tasks[d.UpstreamTask]; !ok {
+			return fmt.Errorf("%s 에 %s task가 존재하지 않습니다", d.Upstream, d.UpstreamTask)
+		}
+	}
+	for _, e := range item.Dependencies {
+		if e.Task == d.Task && e.Upstream == d.Upstream && e.UpstreamTask == d.UpstreamTask {
+			return errors.New("이미 존재하는 의존관계입니다")
+		}
+	}
+	from := dependencyNode{ID: id, Task: d.Task}
+	to := dependencyNode{ID: d.Upstream, Task: d.UpstreamTask}
+	if dependencyReachable(items, to, from) {
+		return fmt.Errorf("%s -> %s 의존관계는 순환됩니다", from, to)
+	}
+	return nil
+}
+
+// dependencyStatus 함수는 노드의 상태를 반환한다. 아이템이나 Task가 없다면 false를 반환한다.
+func dependencyStatus(items map[string]Item, n dependencyNode) (string, bool) {
+	item, ok := items[n.ID]
+	if !ok {
+		return "", false
+	}
+	if n.Task == "" {
+		return item.StatusV2, true
+	}
+	task, ok := item.Tasks[n.Task]
+	if !ok {
+		return "", false
+	}
+	return task.StatusV2, true
+}
+
+// dependencyBlockers 함수는 id 아이템(task)의 끝나지 않은 모든 상위 아이템(Task)을 가까운 순서로 반환한다.
+// done은 끝난 상태로 취급하는 StatusV2 리스트이다. 끝난 노드의 상위 노드는 더 이상 블로커가 아니므로 탐색하지 않는다.
+// 삭제된 아이템이나 Task에 대한 의존관계는 무시한다.
+func dependencyBlockers(items map[string]Item, id, task string, done map[string]bool) []Blocker {
+	start := dependencyNode{ID: id, Task: task}
+	depth := map[dependencyNode]int{start: 0}
+	queue := []dependencyNode{start}
+	blockers := []Blocker{}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, e := range dependencyEdges(items, n) {
+			if _, visited := depth[e.To]; visited {
+				continue
+			}
+			status, ok := dependencyStatus(items, e.To)
+			if !ok {
+				continue
+			}
+			if e.Implicit {
+				// 암시적 간선은 단계가 늘지 않으므로 먼저 탐색한다.
+				depth[e.To] = depth[n]
+				queue = append([]dependencyNode{e.To}, queue...)
+				continue
+			}
+			if done[status] {
+				continue
+			}
+			depth[e.To] = depth[n] + 1
+			blockers = append(blockers, Blocker{ID: e.To.ID, Task: e.To.Task, StatusV2: status, Depth: depth[e.To]})
+			queue = append(queue, e.To)
+		}
+	}
+	return blockers
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidDependency(t *testing.T) {
+	items := map[string]Item{
+		"SS_0010_org": {ID: "SS_0010_org", Tasks: map[string]Task{"lighting": {}, "comp": {}},
+			Dependencies: []Dependency{{Task: "lighting", Upstream: "stone_asset", UpstreamTask: "lookdev"}}},
+		"stone_asset": {ID: "stone_asset", Tasks: map[string]Task{"lookdev": {}, "model": {}}},
+	}
+	cases := []struct {
+		id  string
+		d   Dependency
+		err bool
+	}{{
+		id: "stone_asset",
+		d:  Dependency{Task: "model", Upstream: "SS_0010_org", UpstreamTask: "comp"}, // 다른 Task 이므로 순환이 아니다.
+	}, {
+		id:  "stone_asset",
+		d:   Dependency{Task: "lookdev", Upstream: "SS_0010_org", UpstreamTask: "lighting"},
+		err: true, // 순환
+	}, {
+		id:  "stone_asset",
+		d:   Dependency{Upstream: "SS_0010_org"},
+		err: true, // 아이템 전체는 lookdev Task를 포함하므로 순환
+	}, {
+		id:  "SS_0010_org",
+		d:   Dependency{Task: "lighting", Upstream: "stone_asset", UpstreamTask: "lookdev"},
+		err: true, // 중복
+	}, {
+		id:  "SS_0010_org",
+		d:   Dependency{Task: "fx", Upstream: "stone_asset"},
+		err: true, // Task 없음
+	}, {
+		id:  "SS_0010_org",
+		d:   Dependency{Upstream: "SS_0010_org"},
+		err: true, // 자기 자신
+	}}
+	for _, c := range cases {
+		err := validDependency(items, c.id, c.d)
+		if (err != nil) != c.err {
+			t.Fatalf("TestValidDependency(%s -> %v): 얻은 값 %v, 원하는 값 %v", c.id, c.d, err, c.err)
+		}
+	}
+}
+
+func TestDependencyBlockers(t *testing.T) {
+	items := map[string]Item{
+		"SS_0010_org": {ID: "SS_0010_org", StatusV2: "wip", Tasks: map[string]Task{"lighting": {StatusV2: "wip"}},
+			Dependencies: []Dependency{{Task: "lighting", Upstream: "stone_asset", UpstreamTask: "lookdev"}, {Upstream: "SS_0005_org"}}},
+		"stone_asset": {ID: "stone_asset", Tasks: map[string]Task{"lookdev": {StatusV2: "wip"}},
+			Dependencies: []Dependency{{Task: "lookdev", Upstream: "rock_asset", UpstreamTask: "model"}}},
+		"rock_asset":  {ID: "rock_asset", Tasks: map[string]Task{"model": {StatusV2: "ready"}}},
+		"SS_0005_org": {ID: "SS_0005_org", StatusV2: "done", Dependencies: []Dependency{{Upstream: "rock_asset"}}},
+	}
+	complete := map[string]bool{"done": true}
+	got := dependencyBlockers(items, "SS_0010_org", "", complete)
+	want := []Blocker{
+		{ID: "stone_asset", Task: "lookdev", StatusV2: "wip", Depth: 1},
+		{ID: "rock_asset", Task: "model", StatusV2: "ready", Depth: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("TestDependencyBlockers: 얻은 값 %v, 원하는 값 %v", got, want)
+	}
+}
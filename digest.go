@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ashwanthkumar/slack-go-webhook"
+)
+
+// DigestPreference 자료구조는 사용자의 다이제스트 알림 설정이다. 기본값은 off이고 사용자가 직접 켜야 한다.
+type DigestPreference struct {
+	UserID          string   `json:"userid"`
+	Frequency       string   `json:"frequency"`       // off, daily, weekly
+	Hour            int      `json:"hour"`            // 보내는 시간 0~23
+	Weekday         int      `json:"weekday"`         // weekly일 때 보내는 요일. 0:일요일 ~ 6:토요일
+	Channels        []string `json:"channels"`        // email, slack, webhook
+	SlackWebhookURL string   `json:"slackwebhookurl"` // 개인 Slack Webhook URL
+	WebhookURL      string   `json:"webhookurl"`      // 다이제스트 JSON을 POST로 받을 URL
+	LastSent        string   `json:"lastsent"`        // 마지막으로 보낸 시간 RFC3339
+	Updatetime      string   `json:"updatetime"`      // 업데이트 시간 RFC3339
+}
+
+// DigestTask 자료구조는 다이제스트에 들어가는 이번주 마감 Task이다.
+type DigestTask struct {
+	Project  string `json:"project"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Task     string `json:"task"`
+	StatusV2 string `json:"statusv2"`
+	Deadline string `json:"deadline"` // 2006-01-02
+}
+
+// DigestComment 자료구조는 다이제스트에 들어가는 새 코멘트이다.
+type DigestComment struct {
+	Project string `json:"project"`
+	Name    string `json:"name"`   // 샷, 에셋 이름
+	Source  string `json:"source"` // item, review
+	Author  string `json:"author"`
+	Text    string `json:"text"`
+	Date    string `json:"date"` // RFC3339
+}
+
+// DigestReview 자료구조는 다이제스트에 들어가는 사용자를 기다리는 리뷰이다.
+type DigestReview struct {
+	ID      string `json:"id"`
+	Project string `json:"project"`
+	Name    string `json:"name"`
+	Task    string `json:"task"`
+	Author  string `json:"author"`
+	Status  string `json:"status"` // wait: 리드의 확인을 기다림, comment: 아티스트의 수정을 기다림
+}
+
+// Digest 자료구조는 사용자 한명에게 보내는 요약 알림이다.
+type Digest struct {
+	UserID   string          `json:"userid"`
+	Since    string          `json:"since"` // 이 시간 이후의 코멘트, 리뷰를 포함한다. RFC3339
+	Date     string          `json:"date"`  // 만든 시간 RFC3339
+	Tasks    []DigestTask    `json:"tasks"`
+	Comments []DigestComment `json:"comments"`
+	Reviews  []DigestReview  `json:"reviews"`
+}
+
+// CheckError 메소드는 DigestPreference 자료구조의 에러를 체크한다.
+func (p DigestPreference) CheckError() error {
+	if p.UserID == "" {
+		return errors.New("userid를 설정해주세요")
+	}
+	if p.Frequency != "off" && p.Frequency != "daily" && p.Frequency != "weekly" {
+		return errors.New("frequency는 off, daily, weekly 중 하나여야 합니다")
+	}
+	if p.Hour < 0 || p.Hour > 23 {
+		return errors.New("hour는 0~23 사이여야 합니다")
+	}
+	if p.Weekday < 0 || p.Weekday > 6 {
+		return errors.New("weekday는 0(일요일)~6(토요일) 사이여야 합니다")
+	}
+	for _, c := range p.Channels {
+		switch c {
+		case "email":
+		case "slack":
+			if p.SlackWebhookURL == "" {
+				return errors.New("slack 채널은 slackwebhookurl이 필요합니다")
+			}
+		case "webhook":
+			if p.WebhookURL == "" {
+				return errors.New("webhook 채널은 webhookurl이 필요합니다")
+			}
+		default:
+			return fmt.Errorf("%s 는 지원하지 않는 채널입니다. email, slack, webhook 중 하나여야 합니다", c)
+		}
+	}
+	return nil
+}
+
+// Due 메소드는 now 시간에 다이제스트를 보내야 하는지 체크한다.
+func (p DigestPreference) Due(now time.Time) bool {
+	if p.Frequency != "daily" && p.Frequency != "weekly" {
+		return false
+	}
+	if now.Hour() < p.Hour {
+		return false
+	}
+	if p.Frequency == "weekly" && int(now.Weekday()) != p.Weekday {
+		return false
+	}
+	last, err := time.Parse(time.RFC3339, p.LastSent)
+	if err != nil {
+		return true
+	}
+	return last.In(now.Location()).Format("2006-01-02") != now.Format("2006-01-02")
+}
+
+// Since 메소드는 다이제스트에 포함할 코멘트, 리뷰의 시작 시간을 반환한다. 보낸 적이 없다면 주기만큼 이전 시간이다.
+func (p DigestPreference) Since(now time.Time) string {
+	if p.LastSent != "" {
+		return p.LastSent
+	}
+	if p.Frequency == "weekly" {
+		return now.AddDate(0, 0, -7).Format(time.RFC3339)
+	}
+	return now.AddDate(0, 0, -1).Format(time.RFC3339)
+}
+
+// Empty 메소드는 다이제스트에 보낼 내용이 없는지 체크한다.
+func (d Digest) Empty() bool {
+	return len(d.Tasks) == 0 && len(d.Comments) == 0 && len(d.Reviews) == 0
+}
+
+// Subject 메소드는 다이제스트 제목을 반환한다.
+func (d Digest) Subject() string {
+	date := d.Date
+	if t, err := time.Parse(time.RFC3339, d.Date); err == nil {
+		date = t.Format("2006-01-02")
+	}
+	return fmt.Sprintf("[CSI] %s 다이제스트 %s", d.UserID, date)
+}
+
+// Text 메소드는 다이제스트를 사람이 읽을 수 있는 문자열로 바꾼다.
+func (d Digest) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", d.Subject())
+	fmt.Fprintf(&b, "\n이번주 마감 Task: %d\n", len(d.Tasks))
+	for _, t := range d.Tasks {
+		fmt.Fprintf(&b, "- %s %s %s (%s) 마감: %s\n", t.Project, t.Name, t.Task, t.StatusV2, t.Deadline)
+	}
+	fmt.Fprintf(&b, "\n새 코멘트: %d\n", len(d.Comments))
+	for _, c := range d.Comments {
+		fmt.Fprintf(&b, "- %s %s (%s) %s: %s\n", c.Project, c.Name, c.Source, c.Author, c.Text)
+	}
+	fmt.Fprintf(&b, "\n기다리는 리뷰: %d\n", len(d.Reviews))
+	for _, r := range d.Reviews {
+		fmt.Fprintf(&b, "- %s %s %s (%s) by %s: https://%s/review?id=%s\n", r.Project, r.Name, r.Task, r.Status, r.Author, DNS, r.ID)
+	}
+	return b.String()
+}
+
+// buildDigest 함수는 사용자의 다이제스트를 만든다.
+// items는 프로젝트 이름을 키로 하는 아이템 리스트이고, 사용자가 배정된 아이템과 리뷰의 아이템을 포함해야 한다.
+// 리드(lead)라면 같은 팀 아티스트의 wait 상태 리뷰를, 아티스트라면 본인 리뷰 중 comment 상태인 리뷰를 기다리는 리뷰로 포함한다.
+func buildDigest(u User, lead bool, since string, now time.Time, items map[string][]Item, reviews []Review, complete map[string]bool, teams map[string]string) Digest {
+	d := Digest{
+		UserID:   u.ID,
+		Since:    since,
+		Date:     now.Format(time.RFC3339),
+		Tasks:    []DigestTask{},
+		Comments: []DigestComment{},
+		Reviews:  []DigestReview{},
+	}
+	monday, sunday, _ := weekRange(now.Format("2006-01-02"))
+	byName := make(map[string]Item) // project/name:item
+	for project, list := range items {
+		for _, i := range list {
+			byName[project+"/"+i.Name] = i
+			assigned := false
+			for name, t := range i.Tasks {
+				if !inStrings(taskUserIDs(t), u.ID) {
+					continue
+				}
+				assigned = true
+				if complete[t.StatusV2] {
+					continue
+				}
+				deadline := taskDeadline(i, t)
+				if deadline < monday || deadline > sunday {
+					continue
+				}
+				d.Tasks = append(d.Tasks, DigestTask{Project: project, ID: i.ID, Name: i.Name, Task: name, StatusV2: t.StatusV2, Deadline: deadline})
+			}
+			if !assigned {
+				continue
+			}
+			for _, c := range i.Comments {
+				if c.Date > since && c.Author != u.ID {
+					d.Comments = append(d.Comments, DigestComment{Project: project, Name: i.Name, Source: "item", Author: c.Author, Text: c.Text, Date: c.Date})
+				}
+			}
+		}
+	}
+	for _, r := range reviews {
+		if r.Author == u.ID {
+			for _, c := range r.Comments {
+				if c.Date > since && c.Author != u.ID {
+					d.Comments = append(d.Comments, DigestComment{Project: r.Project, Name: r.Name, Source: "review", Author: c.Author, Text: c.Text, Date: c.Date})
+				}
+			}
+			if r.Status == "comment" && r.Updatetime > since {
+				d.Reviews = append(d.Reviews, DigestReview{ID: r.ID.Hex(), Project: r.Project, Name: r.Name, Task: r.Task, Author: r.Author, Status: r.Status})
+			}
+			continue
+		}
+		if !lead || r.Status != "wait" || teams[u.ID] == "" {
+			continue
+		}
+		i, ok := byName[r.Project+"/"+r.Name]
+		if !ok {
+			continue
+		}
+		for _, user := range taskUserIDs(i.Tasks[r.Task]) {
+			if teams[user] == teams[u.ID] {
+				d.Reviews = append(d.Reviews, DigestReview{ID: r.ID.Hex(), Project: r.Project, Name: r.Name, Task: r.Task, Author: r.Author, Status: r.Status})
+				break
+			}
+		}
+	}
+	sort.Slice(d.Tasks, func(i, j int) bool {
+		if d.Tasks[i].Deadline != d.Tasks[j].Deadline {
+			return d.Tasks[i].Deadline < d.Tasks[j].Deadline
+		}
+		return d.Tasks[i].Project+d.Tasks[i].ID+d.Tasks[i].Task < d.Tasks[j].Project+d.Tasks[j].ID+d.Tasks[j].Task
+	})
+	sort.Slice(d.Comments, func(i, j int) bool { return d.Comments[i].Date < d.Comments[j].Date })
+	sort.Slice(d.Reviews, func(i, j int) bool {
+		return d.Reviews[i].Project+d.Reviews[i].Name+d.Reviews[i].Task < d.Reviews[j].Project+d.Reviews[j].Name+d.Reviews[j].Task
+	})
+	return d
+}
+
+// taskUserIDs 함수는 Task 아티스트의 ID 리스트를 반환한다.
+func taskUserIDs(t Task) []string {
+	var ids []string
+	for _, u := range t.Users() {
+		ids = append(ids, assigneeID(u))
+	}
+	return ids
+}
+
+// DigestChannel 인터페이스는 다이제스트를 보내는 채널이다.
+type DigestChannel interface {
+	Send(u User, p DigestPreference, d Digest) error
+}
+
+// SMTPChannel 은 다이제스트를 이메일로 보내는 채널이다.
+type SMTPChannel struct {
+	Addr    string // SMTP 서버 주소 host:port
+	From    string // 보내는 사람 메일주소
+	MailDNS string // 사용자 메일이 없을 때 ID@MailDNS 로 보낸다.
+}
+
+// Send 메소드는 다이제스트를 사용자 메일로 보낸다.
+func (c SMTPChannel) Send(u User, p DigestPreference, d Digest) error {
+	to := u.Email
+	if !regexpEmail.MatchString(to) {
+		if c.MailDNS == "" {
+			return fmt.Errorf("%s 사용자의 메일주소가 없습니다", u.ID)
+		}
+		to = u.ID + "@" + c.MailDNS
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", c.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", d.Subject()))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.Replace(d.Text(), "\n", "\r\n", -1))
+	return smtp.SendMail(c.Addr, nil, c.From, []string{to}, msg.Bytes())
+}
+
+// SlackChannel 은 다이제스트를 사용자의 Slack Webhook으로 보내는 채널이다.
+type SlackChannel struct{}
+
+// Send 메소드는 다이제스트를 Slack Webhook으로 보낸다.
+func (c SlackChannel) Send(u User, p DigestPreference, d Digest) error {
+	errs := slack.Send(p.SlackWebhookURL, "", slack.Payload{Text: d.Text()})
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// WebhookChannel 은 다이제스트 JSON을 URL로 POST 하는 채널이다.
+type WebhookChannel struct {
+	Client *http.Client
+}
+
+// Send 메소드는 다이제스트 JSON을 Webhook URL로 보낸다.
+func (c WebhookChannel) Send(u User, p DigestPreference, d Digest) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Post(p.WebhookURL, "application/json; charset=utf-8", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook 응답코드가 %d 입니다", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDigestPreferenceDue(t *testing.T) {
+	now := time.Date(2020, 11, 2, 9, 30, 0, 0, time.UTC) // 월요일
+	cases := []struct {
+		pref DigestPreference
+		want bool
+	}{
+		{pref: DigestPreference{Frequency: "off", Hour: 9}, want: false},
+		{pref: DigestPreference{Frequency: "daily", Hour: 9}, want: true},
+		{pref: DigestPreference{Frequency: "daily", Hour: 10}, want: false},
+		{pref: DigestPreference{Frequency: "daily", Hour: 9, LastSent: "2020-11-02T09:00:00Z"}, want: false},
+		{pref: DigestPreference{Frequency: "daily", Hour: 9, LastSent: "2020-11-01T09:00:00Z"}, want: true},
+		{pref: DigestPreference{Frequency: "weekly", Hour: 9, Weekday: 1}, want: true},
+		{pref: DigestPreference{Frequency: "weekly", Hour: 9, Weekday: 5}, want: false},
+	}
+	for _, c := range cases {
+		got := c.pref.Due(now)
+		if got != c.want {
+			t.Fatalf("TestDigestPreferenceDue(%v): 얻은 값 %v, 원하는 값 %v", c.pref, got, c.want)
+		}
+	}
+}
+
+func TestBuildDigest(t *testing.T) {
+	now := time.Date(2020, 11, 4, 9, 0, 0, 0, time.UTC) // 수요일
+	since := "2020-11-03T09:00:00Z"
+	items := map[string][]Item{"TEMP": {
+		{ID: "SS_0010_org", Name: "SS_0010", Tasks: map[string]Task{
+			"comp": {User: "kim(김,comp1)", StatusV2: "wip", Date: "2020-11-06T19:00:00+09:00"},
+			"fx":   {User: "kim", StatusV2: "confirm", Date: "2020-11-06T19:00:00+09:00"},
+		}, Comments: []Comment{
+			{Author: "lee", Text: "new", Date: "2020-11-03T10:00:00Z"},
+			{Author: "lee", Text: "old", Date: "2020-11-02T10:00:00Z"},
+			{Author: "kim", Text: "mine", Date: "2020-11-03T11:00:00Z"},
+		}},
+		{ID: "SS_0020_org", Name: "SS_0020", Tasks: map[string]Task{
+			"comp": {User: "kim", StatusV2: "wip", Date: "2020-11-20T19:00:00+09:00"},
+		}},
+		{ID: "SS_0030_org", Name: "SS_0030", Tasks: map[string]Task{
+			"comp": {User: "park", StatusV2: "wip", Date: "2020-11-05T19:00:00+09:00"},
+		}},
+	}}
+	reviews := []Review{
+		{Project: "TEMP", Name: "SS_0010", Task: "comp", Author: "kim", Status: "comment", Updatetime: "2020-11-03T12:00:00Z", Comments: []Comment{{Author: "choi", Text: "fix edge", Date: "2020-11-03T12:00:00Z"}}},
+		{Project: "TEMP", Name: "SS_0030", Task: "comp", Author: "park", Status: "wait"},
+	}
+	complete := map[string]bool{"confirm": true}
+	teams := map[string]string{"kim": "comp1", "park": "comp1", "choi": "comp1"}
+	// 아티스트
+	d := buildDigest(User{ID: "kim"}, false, since, now, items, reviews, complete, teams)
+	if len(d.Tasks) != 1 || d.Tasks[0].ID != "SS_0010_org" || d.Tasks[0].Task != "comp" {
+		t.Fatalf("TestBuildDigest(tasks): 얻은 값 %v, 원하는 값 %v", d.Tasks, "SS_0010_org comp")
+	}
+	if len(d.Comments) != 2 || d.Comments[0].Text != "new" || d.Comments[1].Text != "fix edge" {
+		t.Fatalf("TestBuildDigest(comments): 얻은 값 %v, 원하는 값 %v", d.Comments, "new, fix edge")
+	}
+	if len(d.Reviews) != 1 || d.Reviews[0].Status != "comment" {
+		t.Fatalf("TestBuildDigest(reviews): 얻은 값 %v, 원하는 값 %v", d.Reviews, "comment review")
+	}
+	// 리드: 같은 팀 아티스트의 wait 리뷰
+	d = buildDigest(User{ID: "choi"}, true, since, now, items, reviews, complete, teams)
+	if len(d.Reviews) != 1 || d.Reviews[0].Name != "SS_0030" || !(len(d.Tasks) == 0 && len(d.Comments) == 0) {
+		t.Fatalf("TestBuildDigest(lead): 얻은 값 %v, 원하는 값 %v", d, "SS_0030 wait review")
+	}
+}
+
+// smtpStandIn 함수는 테스트용 SMTP 서버를 실행하고 받은 메일 데이터를 채널로 보낸다.
+func smtpStandIn(t *testing.T) (string, chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mails := make(chan string, 1)
+	go func() {
+		defer ln.Close()
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost")
+		var data strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+				data.WriteString(strings.TrimSpace(line) + "\n")
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 Go ahead")
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 Bye")
+				mails <- data.String()
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	return ln.Addr().String(), mails
+}
+
+func TestSMTPChannel(t *testing.T) {
+	addr, mails := smtpStandIn(t)
+	c := SMTPChannel{Addr: addr, From: "csi@lazypic.org", MailDNS: "lazypic.org"}
+	d := Digest{UserID: "kim", Date: "2020-11-04T09:00:00Z", Tasks: []DigestTask{{Project: "TEMP", Name: "SS_0010", Task: "comp", Deadline: "2020-11-06"}}}
+	err := c.Send(User{ID: "kim"}, DigestPreference{}, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mail := <-mails
+	for _, want := range []string{"RCPT TO:<kim@lazypic.org>", "To: kim@lazypic.org", "TEMP SS_0010 comp"} {
+		if !strings.Contains(mail, want) {
+			t.Fatalf("TestSMTPChannel: 얻은 값 %v, 원하는 값 %v", mail, want)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// 이벤트 타입
+const (
+	EventItemAdd       = "item.add"
+	EventItemUpdate    = "item.update"
+	EventItemRemove    = "item.remove"
+	EventTaskUpdate    = "task.update"
+	EventPublishUpdate = "publish.update"
+	EventReviewAdd     = "review.add"
+	EventReviewUpdate  = "review.update"
+	EventReviewComment = "review.comment"
+	EventReviewRemove  = "review.remove"
+	EventUserAdd       = "user.add"
+	EventUserUpdate    = "user.update"
+	EventUserRemove    = "user.remove"
+	EventProjectAdd    = "project.add"
+	EventProjectUpdate = "project.update"
+	EventProjectRemove = "project.remove"
+	EventMessage       = "message" // 사람이 읽는 로그 메시지. Slack 채널로 보내진다.
+)
+
+// EventTypes 는 사용할 수 있는 전체 이벤트 타입이다.
+var EventTypes = []string{
+	EventItemAdd, EventItemUpdate, EventItemRemove,
+	EventTaskUpdate, EventPublishUpdate,
+	EventReviewAdd, EventReviewUpdate, EventReviewComment, EventReviewRemove,
+	EventUserAdd, EventUserUpdate, EventUserRemove,
+	EventProjectAdd, EventProjectUpdate, EventProjectRemove,
+	EventMessage,
+}
+
+// 웹훅 전송 상태
+const (
+	DeliveryPending = "pending" // 전송 대기 또는 재시도 대기
+	DeliverySuccess = "success"
+	DeliveryFailed  = "failed" // 최대 시도 횟수를 넘겨서 더이상 보내지 않는다.
+)
+
+// WebhookDefaultAttempts 는 웹훅 구독의 기본 최대 전송 시도 횟수이다.
+const WebhookDefaultAttempts = 5
+
+// EventKeepDays 는 이벤트와 웹훅 전송 기록을 보관하는 일수이다.
+const EventKeepDays = 30
+
+// EventChange 자료구조는 이벤트에서 바뀐 필드이다.
+type EventChange struct {
+	Field string      `json:"field"` // 바뀐 필드. 예) tasks.comp.statusv2, status
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// Event 자료구조는 아이템, Task, 리뷰, 퍼블리쉬, 사용자, 프로젝트가 바뀔 때 발생하는 이벤트이다.
+type Event struct {
+	ID         bson.ObjectId `json:"id" bson:"_id,omitempty"`
+	Type       string        `json:"type"`    // 이벤트 타입. 예) task.update
+	Project    string        `json:"project"` // 프로젝트. 사용자 이벤트는 빈 문자열이다.
+	Target     string        `json:"target"`  // 바뀐 대상의 ID. 아이템 ID, 리뷰 ID, 사용자 ID, 프로젝트 이름
+	Name       string        `json:"name"`    // 아이템, 리뷰의 이름
+	Task       string        `json:"task"`    // task.update, publish.update, 리뷰 이벤트의 Task
+	Changes    []EventChange `json:"changes"` // 바뀐 필드 리스트
+	UserID     string        `json:"userid"`  // 변경한 사용자 ID
+	Source     string        `json:"source"`  // 변경 출처. 예) SetDeadline2D
+	Message    string        `json:"message"` // message 이벤트의 내용
+	Createtime string        `json:"createtime"`
+}
+
+// WebhookSubscription 자료구조는 이벤트를 받을 웹훅 구독이다.
+type WebhookSubscription struct {
+	ID          bson.ObjectId `json:"id" bson:"_id,omitempty"`
+	Name        string        `json:"name"`
+	URL         string        `json:"url"`
+	Secret      string        `json:"secret"`      // HMAC-SHA256 서명 키. 비어있으면 서명하지 않는다.
+	Events      []string      `json:"events"`      // 이벤트 필터. 예) item.*, review.add. 비어있으면 전체 이벤트
+	Projects    []string      `json:"projects"`    // 프로젝트 필터. 비어있으면 전체 프로젝트
+	Active      bool          `json:"active"`      // 켜져 있는 구독만 이벤트를 받는다.
+	MaxAttempts int           `json:"maxattempts"` // 최대 전송 시도 횟수
+	Author      string        `json:"author"`
+	Createtime  string        `json:"createtime"`
+	Updatetime  string        `json:"updatetime"`
+}
+
+// WebhookDelivery 자료구조는 웹훅 구독으로 이벤트를 보낸 기록이다.
+type WebhookDelivery struct {
+	ID           bson.ObjectId `json:"id" bson:"_id,omitempty"`
+	Subscription bson.ObjectId `json:"subscription"` // 웹훅 구독 ID
+	Event        Event         `json:"event"`
+	Status       string        `json:"status"`       // pending, success, failed
+	Attempts     int           `json:"attempts"`     // 지금까지 보낸 횟수
+	NextAttempt  string        `json:"nextattempt"`  // 다음 전송 시간 RFC3339
+	ResponseCode int           `json:"responsecode"` // 마지막 응답코드. 연결에 실패했다면 0이다.
+	Error        string        `json:"error"`        // 마지막 에러
+	Createtime   string        `json:"createtime"`
+	Updatetime   string        `json:"updatetime"`
+}
+
+// validEventPattern 함수는 이벤트 필터가 올바른지 체크한다. 필터는 *, 이벤트 타입, 그룹.* 형태이다.
+func validEventPattern(pattern string) bool {
+	if pattern == "*" {
+		return true
+	}
+	for _, t := range EventTypes {
+		if pattern == t || pattern == strings.Split(t, ".")[0]+".*" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchEventPattern 함수는 이벤트 타입이 필터에 해당하는지 체크한다.
+func matchEventPattern(pattern, typ string) bool {
+	if pattern == "*" || pattern == typ {
+		return true
+	}
+	if strings.HasSuffix(pattern, ".*") {
+		return strings.HasPrefix(typ, strings.TrimSuffix(pattern, "*"))
+	}
+	return false
+}
+
+// CheckError 메소드는 웹훅 구독의 값이 올바른지 체크한다.
+func (s WebhookSubscription) CheckError() error {
+	if s.Name == "" {
+		return errors.New("name을 설정해주세요")
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url은 http:// 또는 https:// 로 시작하는 주소여야 합니다")
+	}
+	for _, e := range s.Events {
+		if !validEventPattern(e) {
+			return fmt.Errorf("%s 는 사용할 수 없는 이벤트 필터입니다", e)
+		}
+	}
+	if s.MaxAttempts < 1 || s.MaxAttempts > 20 {
+		return errors.New("maxattempts는 1~20 사이여야 합니다")
+	}
+	return nil
+}
+
+// Match 메소드는 이벤트가 웹훅 구독의 필터에 해당하는지 체크한다.
+// 프로젝트 필터가 있다면 프로젝트가 없는 사용자 이벤트는 받지 않는다.
+func (s WebhookSubscription) Match(e Event) bool {
+	if !s.Active {
+		return false
+	}
+	if len(s.Projects) != 0 && !inStrings(s.Projects, e.Project) {
+		return false
+	}
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, p := range s.Events {
+		if matchEventPattern(p, e.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// newWebhookSecret 함수는 웹훅 서명 키를 만든다.
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// itemHistoryEventType 함수는 아이템 변경 기록의 필드로 이벤트 타입과 Task를 구한다.
+func itemHistoryEventType(h ItemHistory) (string, string) {
+	if h.Field == "" {
+		if h.Old != nil {
+			return EventItemRemove, ""
+		}
+		return EventItemAdd, ""
+	}
+	if !strings.HasPrefix(h.Field, "tasks.") {
+		return EventItemUpdate, ""
+	}
+	keys := strings.SplitN(h.Field, ".", 4)
+	if len(keys) >= 3 && keys[2] == "publishes" {
+		return EventPublishUpdate, keys[1]
+	}
+	return EventTaskUpdate, keys[1]
+}
+
+// itemHistoryEvents 함수는 아이템 변경 기록을 이벤트로 바꾼다.
+// 같은 아이템, 같은 이벤트 타입, 같은 Task의 변경은 하나의 이벤트로 묶는다. 이벤트는 기록 순서대로 반환한다.
+func itemHistoryEvents(histories []ItemHistory) []Event {
+	var events []Event
+	index := make(map[string]int)
+	for _, h := range histories {
+		typ, task := itemHistoryEventType(h)
+		key := strings.Join([]string{h.Project, h.ItemID, typ, task, h.Author, h.Source}, "\x00")
+		n, ok := index[key]
+		if !ok {
+			n = len(events)
+			index[key] = n
+			events = append(events, Event{
+				Type:       typ,
+				Project:    h.Project,
+				Target:     h.ItemID,
+				Task:       task,
+				Changes:    []EventChange{},
+				UserID:     h.Author,
+				Source:     h.Source,
+				Createtime: h.Time,
+			})
+		}
+		if h.Field == "" {
+			// 추가, 삭제 기록은 문서 전체 대신 이름만 담는다.
+			doc := h.New
+			if typ == EventItemRemove {
+				doc = h.Old
+			}
+			if m, ok := doc.(bson.M); ok {
+				events[n].Name, _ = m["name"].(string)
+			}
+			continue
+		}
+		events[n].Changes = append(events[n].Changes, EventChange{Field: h.Field, Old: h.Old, New: h.New})
+	}
+	return events
+}
+
+// signPayload 함수는 웹훅 본문의 HMAC-SHA256 서명을 "sha256=16진수" 형태로 반환한다.
+func signPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff 함수는 attempts 번 실패한 뒤 다음 전송까지 기다리는 시간을 반환한다. 30초부터 두배씩 늘리고 최대 1시간이다.
+func webhookBackoff(attempts int) time.Duration {
+	d := 30 * time.Second
+	for n := 1; n < attempts && d < time.Hour; n++ {
+		d *= 2
+	}
+	if d > time.Hour {
+		d = time.Hour
+	}
+	return d
+}
+
+// deliverWebhook 함수는 이벤트 JSON을 웹훅 URL로 POST 하고 응답코드를 반환한다.
+// X-CSI-Event, X-CSI-Delivery 헤더와 서명 키가 있다면 X-CSI-Signature 헤더를 보낸다.
+func deliverWebhook(client *http.Client, s WebhookSubscription, d WebhookDelivery) (int, error) {
+	body, err := json.Marshal(d.Event)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("X-CSI-Event", d.Event.Type)
+	req.Header.Set("X-CSI-Delivery", d.ID.Hex())
+	if s.Secret != "" {
+		req.Header.Set("X-CSI-Signature", signPayload(s.Secret, body))
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook 응답코드가 %d 입니다", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// attempted 메소드는 전송 결과를 기록한 전송 기록을 반환한다.
+// 실패했다면 최대 시도 횟수 전까지 다음 전송 시간을 늦춰서 pending 상태로 두고, 넘겼다면 failed 상태로 바꾼다.
+func (d WebhookDelivery) attempted(code int, err error, maxAttempts int, now time.Time) WebhookDelivery {
+	d.Attempts++
+	d.ResponseCode = code
+	d.Updatetime = now.Format(time.RFC3339)
+	if err == nil {
+		d.Status = DeliverySuccess
+		d.Error = ""
+		d.NextAttempt = ""
+		return d
+	}
+	d.Error = err.Error()
+	if d.Attempts >= maxAttempts {
+		d.Status = DeliveryFailed
+		d.NextAttempt = ""
+		return d
+	}
+	d.Status = DeliveryPending
+	d.NextAttempt = now.Add(webhookBackoff(d.Attempts)).Format(time.RFC3339)
+	return d
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestItemHistoryEvents(t *testing.T) {
+	histories := []ItemHistory{
+		{Project: "TEMP", ItemID: "SS_0010_org", Field: "tasks.comp.statusv2", Old: "wip", New: "confirm", Author: "kim"},
+		{Project: "TEMP", ItemID: "SS_0010_org", Field: "tasks.comp.publishes.main", Old: nil, New: "v001", Author: "kim"},
+		{Project: "TEMP", ItemID: "SS_0010_org", Field: "tasks.comp.date", Old: "", New: "2020-11-06", Author: "kim"},
+		{Project: "TEMP", ItemID: "SS_0010_org", Field: "ddline2d", Old: "", New: "2020-11-10", Author: "kim"},
+		{Project: "TEMP", ItemID: "SS_0020_org", Old: bson.M{"name": "SS_0020"}, Author: "kim"},
+		{Project: "TEMP", ItemID: "SS_0030_org", New: bson.M{"name": "SS_0030"}, Author: "kim"},
+	}
+	events := itemHistoryEvents(histories)
+	cases := []struct {
+		typ     string
+		target  string
+		task    string
+		name    string
+		changes int
+	}{
+		{typ: EventTaskUpdate, target: "SS_0010_org", task: "comp", changes: 2},
+		{typ: EventPublishUpdate, target: "SS_0010_org", task: "comp", changes: 1},
+		{typ: EventItemUpdate, target: "SS_0010_org", changes: 1},
+		{typ: EventItemRemove, target: "SS_0020_org", name: "SS_0020", changes: 0},
+		{typ: EventItemAdd, target: "SS_0030_org", name: "SS_0030", changes: 0},
+	}
+	if len(events) != len(cases) {
+		t.Fatalf("TestItemHistoryEvents(%v): 얻은 값 %v, 원하는 값 %v", histories, len(events), len(cases))
+	}
+	for n, c := range cases {
+		e := events[n]
+		if e.Type != c.typ || e.Target != c.target || e.Task != c.task || e.Name != c.name || len(e.Changes) != c.changes {
+			t.Fatalf("TestItemHistoryEvents(%v): 얻은 값 %v, 원하는 값 %v", n, e, c)
+		}
+	}
+}
+
+func TestWebhookSubscriptionMatch(t *testing.T) {
+	cases := []struct {
+		sub   WebhookSubscription
+		event Event
+		want  bool
+	}{
+		{sub: WebhookSubscription{Active: true}, event: Event{Type: EventItemAdd, Project: "TEMP"}, want: true},
+		{sub: WebhookSubscription{Active: false}, event: Event{Type: EventItemAdd, Project: "TEMP"}, want: false},
+		{sub: WebhookSubscription{Active: true, Events: []string{"task.*"}}, event: Event{Type: EventTaskUpdate}, want: true},
+		{sub: WebhookSubscription{Active: true, Events: []string{"task.*"}}, event: Event{Type: EventItemUpdate}, want: false},
+		{sub: WebhookSubscription{Active: true, Events: []string{"review.add", "*"}}, event: Event{Type: EventMessage}, want: true},
+		{sub: WebhookSubscription{Active: true, Projects: []string{"TEMP"}}, event: Event{Type: EventItemAdd, Project: "CIRCLE"}, want: false},
+		{sub: WebhookSubscription{Active: true, Projects: []string{"TEMP"}}, event: Event{Type: EventUserAdd}, want: false},
+	}
+	for _, c := range cases {
+		got := c.sub.Match(c.event)
+		if got != c.want {
+			t.Fatalf("TestWebhookSubscriptionMatch(%v, %v): 얻은 값 %v, 원하는 값 %v", c.sub, c.event, got, c.want)
+		}
+	}
+}
+
+func TestWebhookSubscriptionCheckError(t *testing.T) {
+	cases := []struct {
+		sub  WebhookSubscription
+		want bool // 에러 여부
+	}{
+		{sub: WebhookSubscription{Name: "ci", URL: "https://example.com/hook", Events: []string{"item.*", "review.add"}, MaxAttempts: 5}, want: false},
+		{sub: WebhookSubscription{Name: "ci", URL: "example.com/hook", MaxAttempts: 5}, want: true},
+		{sub: WebhookSubscription{Name: "ci", URL: "https://example.com/hook", Events: []string{"item.move"}, MaxAttempts: 5}, want: true},
+		{sub: WebhookSubscription{Name: "ci", URL: "https://example.com/hook", MaxAttempts: 0}, want: true},
+		{sub: WebhookSubscription{URL: "https://example.com/hook", MaxAttempts: 5}, want: true},
+	}
+	for _, c := range cases {
+		got := c.sub.CheckError() != nil
+		if got != c.want {
+			t.Fatalf("TestWebhookSubscriptionCheckError(%v): 얻은 값 %v, 원하는 값 %v", c.sub, got, c.want)
+		}
+	}
+}
+
+func TestWebhookDeliveryAttempted(t *testing.T) {
+	now := time.Date(2020, 11, 2, 9, 0, 0, 0, time.UTC)
+	d := WebhookDelivery{Status: DeliveryPending}
+	d = d.attempted(500, errors.New("webhook 응답코드가 500 입니다"), 3, now)
+	if d.Status != DeliveryPending || d.Attempts != 1 || d.NextAttempt != "2020-11-02T09:00:30Z" {
+		t.Fatalf("TestWebhookDeliveryAttempted(1): 얻은 값 %v, 원하는 값 %v", d, "pending 2020-11-02T09:00:30Z")
+	}
+	d = d.attempted(500, errors.New("webhook 응답코드가 500 입니다"), 3, now)
+	if d.Status != DeliveryPending || d.NextAttempt != "2020-11-02T09:01:00Z" {
+		t.Fatalf("TestWebhookDeliveryAttempted(2): 얻은 값 %v, 원하는 값 %v", d, "pending 2020-11-02T09:01:00Z")
+	}
+	d = d.attempted(0, errors.New("connection refused"), 3, now)
+	if d.Status != DeliveryFailed || d.Attempts != 3 {
+		t.Fatalf("TestWebhookDeliveryAttempted(3): 얻은 값 %v, 원하는 값 %v", d, DeliveryFailed)
+	}
+	d = WebhookDelivery{Status: DeliveryPending}.attempted(200, nil, 3, now)
+	if d.Status != DeliverySuccess || d.ResponseCode != 200 {
+		t.Fatalf("TestWebhookDeliveryAttempted(4): 얻은 값 %v, 원하는 값 %v", d, DeliverySuccess)
+	}
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	var header http.Header
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer ts.Close()
+	s := WebhookSubscription{URL: ts.URL, Secret: "secret"}
+	d := WebhookDelivery{ID: bson.NewObjectId(), Event: Event{Type: EventTaskUpdate, Project: "TEMP", Target: "SS_0010_org", Task: "comp"}}
+	code, err := deliverWebhook(ts.Client(), s, d)
+	if err != nil || code != http.StatusOK {
+		t.Fatalf("TestDeliverWebhook(%v): 얻은 값 %v %v, 원하는 값 %v", s, code, err, http.StatusOK)
+	}
+	if header.Get("X-CSI-Event") != EventTaskUpdate || header.Get("X-CSI-Delivery") != d.ID.Hex() {
+		t.Fatalf("TestDeliverWebhook(%v): 얻은 값 %v, 원하는 값 %v", s, header, d)
+	}
+	if got, want := header.Get("X-CSI-Signature"), signPayload("secret", body); got != want {
+		t.Fatalf("TestDeliverWebhook(%v): 얻은 값 %v, 원하는 값 %v", s, got, want)
+	}
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/360EntSecGroup-Skylar/excelize/v2"
 	"github.com/digital-idea/ditime"
 	"gopkg.in/mgo.v2"
 )
@@ -124,3 +127,97 @@ func (r *Excelrow) checkerror(session *mgo.Session, project string) {
 		r.Findate = findate
 	}
 }
+
+// BreakdownSheet 는 에셋 브레이크다운을 입력하는 엑셀 시트 이름이다.
+const BreakdownSheet = "Breakdown"
+
+// BreakdownExcelrow 자료구조는 .xlsx 브레이크다운 시트의 한 줄이다.
+// 샷네임;에셋이름;베리에이션;LOD;군중수;메모
+type BreakdownExcelrow struct {
+	Shot            string
+	ShotError       string
+	ID              string // 샷 ID
+	Asset           string
+	AssetError      string
+	Variant         string
+	LOD             string
+	CrowdCount      string
+	CrowdCountError string
+	Note            string
+	Errornum        int
+}
+
+// readBreakdownExcelrows 함수는 엑셀파일의 브레이크다운 시트를 읽는다. 시트가 없다면 nil을 반환한다.
+func readBreakdownExcelrows(f *excelize.File) ([]BreakdownExcelrow, error) {
+	if f.GetSheetIndex(BreakdownSheet) == 0 {
+		return nil, nil
+	}
+	excelRows, err := f.GetRows(BreakdownSheet)
+	if err != nil {
+		return nil, err
+	}
+	var rows []BreakdownExcelrow
+	for n := range excelRows {
+		if n == 0 { // 첫번째줄
+			continue
+		}
+		var cells [6]string
+		for c := range cells {
+			cells[c], err = f.GetCellValue(BreakdownSheet, fmt.Sprintf("%c%d", 'A'+c, n+1))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if cells[0] == "" && cells[1] == "" { // 샷, 에셋 이름이 비어있다면 넘긴다.
+			continue
+		}
+		rows = append(rows, BreakdownExcelrow{
+			Shot:       cells[0],
+			Asset:      cells[1],
+			Variant:    cells[2],
+			LOD:        cells[3],
+			CrowdCount: cells[4],
+			Note:       cells[5],
+		})
+	}
+	return rows, nil
+}
+
+// Breakdown 메소드는 엑셀 한 줄을 Breakdown 자료구조로 바꾼다.
+func (r BreakdownExcelrow) Breakdown() Breakdown {
+	b := Breakdown{
+		Asset:   r.Asset,
+		Variant: r.Variant,
+		LOD:     r.LOD,
+		Note:    r.Note,
+	}
+	b.CrowdCount, _ = strconv.Atoi(r.CrowdCount)
+	return b
+}
+
+func (r *BreakdownExcelrow) checkerror(session *mgo.Session, project string) {
+	shot, err := Shot(session, project, r.Shot)
+	if err != nil {
+		r.ShotError = "등록된 Shot 이름이 아닙니다"
+		r.Errornum++
+	}
+	r.ID = shot.ID
+	if r.CrowdCount != "" {
+		if _, err := strconv.Atoi(r.CrowdCount); err != nil {
+			r.CrowdCountError = "숫자가 아닙니다"
+			r.Errornum++
+			return
+		}
+	}
+	asset, err := Asset(session, project, r.Asset)
+	if err != nil {
+		r.AssetError = "등록된 Asset 이름이 아닙니다"
+		r.Errornum++
+		return
+	}
+	err = r.Breakdown().CheckError(asset)
+	if err != nil {
+		r.CrowdCountError = err.Error()
+		r.Errornum++
+	}
+}
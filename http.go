@@ -176,6 +176,7 @@ func webserver(port string) {
 	http.HandleFunc("/reviewdrawingdata", handleReviewDrawingData)
 	http.HandleFunc("/review-submit", handleReviewSubmit)
 	http.HandleFunc("/upload-reviewfile", handleUploadReviewFile)
+	http.HandleFunc("/download-reviewnotes", handleDownloadReviewNotes)
 
 	// Project
 	http.HandleFunc("/projectinfo", handleProjectinfo)
@@ -258,6 +259,8 @@ func webserver(port string) {
 	http.HandleFunc("/download-json-file", handleDownloadJSONFile)
 
 	// Task
+	http.HandleFunc("/timesheet", handleTimesheet)
+	http.HandleFunc("/capacity", handleCapacity)
 	http.HandleFunc("/tasksettings", handleTasksettings)
 	http.HandleFunc("/addtasksetting", handleAddTasksetting)
 	http.HandleFunc("/rmtasksetting", handleRmTasksetting)
@@ -292,6 +295,12 @@ func webserver(port string) {
 	http.HandleFunc("/editpublishkey-submit", handleEditPublishKeySubmit)
 	http.HandleFunc("/rmpublishkey", handleRmPublishKey)
 	http.HandleFunc("/rmpublishkey-submit", handleRmPublishKeySubmit)
+	http.HandleFunc("/transcodeprofile", handleTranscodeProfile)
+	http.HandleFunc("/addtranscodeprofile", handleAddTranscodeProfile)
+	http.HandleFunc("/addtranscodeprofile-submit", handleAddTranscodeProfileSubmit)
+	http.HandleFunc("/edittranscodeprofile", handleEditTranscodeProfile)
+	http.HandleFunc("/edittranscodeprofile-submit", handleEditTranscodeProfileSubmit)
+	http.HandleFunc("/rmtranscodeprofile-submit", handleRmTranscodeProfileSubmit)
 
 	// Input
 	http.HandleFunc("/inputmode", handleInputMode)
@@ -316,6 +325,31 @@ func webserver(port string) {
 	http.HandleFunc("/api2/item", handleAPI2Item)
 	http.HandleFunc("/api/rmitem", handleAPIRmItem) // legacy
 	http.HandleFunc("/api/rmitemid", handleAPIRmItemID)
+	http.HandleFunc("/api/itemhistory", handleAPIItemHistory)
+	http.HandleFunc("/api/restoreitemfield", handleAPIRestoreItemField)
+	http.HandleFunc("/api/restoreitem", handleAPIRestoreItem)
+	http.HandleFunc("/api/items/batch", handleAPIItemsBatch)
+	http.HandleFunc("/api/v4/item/", handleAPIV4Item)
+	http.HandleFunc("/api/adddependency", handleAPIAddDependency)
+	http.HandleFunc("/api/rmdependency", handleAPIRmDependency)
+	http.HandleFunc("/api/blockers", handleAPIBlockers)
+	http.HandleFunc("/api/setbreakdown", handleAPIBreakdown)
+	http.HandleFunc("/api/rmbreakdown", handleAPIBreakdown)
+	http.HandleFunc("/api/clearbreakdownflag", handleAPIBreakdown)
+	http.HandleFunc("/api/assetshots", handleAPIAssetShots)
+	http.HandleFunc("/api/shotassets", handleAPIShotAssets)
+	http.HandleFunc("/api/addsequence", handleAPIAddItemGroup)
+	http.HandleFunc("/api/addepisode", handleAPIAddItemGroup)
+	http.HandleFunc("/api/editsequence", handleAPIEditItemGroup)
+	http.HandleFunc("/api/editepisode", handleAPIEditItemGroup)
+	http.HandleFunc("/api/rmsequence", handleAPIRmItemGroup)
+	http.HandleFunc("/api/rmepisode", handleAPIRmItemGroup)
+	http.HandleFunc("/api/addsequencecomment", handleAPIAddItemGroupComment)
+	http.HandleFunc("/api/addepisodecomment", handleAPIAddItemGroupComment)
+	http.HandleFunc("/api/sequence", handleAPIItemGroup)
+	http.HandleFunc("/api/episode", handleAPIItemGroup)
+	http.HandleFunc("/api/sequences", handleAPIItemGroups)
+	http.HandleFunc("/api/episodes", handleAPIItemGroups)
 	http.HandleFunc("/api/items", handleAPI2Items)  // legacy
 	http.HandleFunc("/api2/items", handleAPI2Items) // legacy
 	http.HandleFunc("/api3/items", handleAPI3Items)
@@ -353,6 +387,33 @@ func webserver(port string) {
 	http.HandleFunc("/api/addtask", handleAPIAddTask)
 	http.HandleFunc("/api/rmtask", handleAPIRmTask)
 	http.HandleFunc("/api/settaskuser", handleAPISetTaskUser)
+	http.HandleFunc("/api/settaskassignee", handleAPITaskAssignee)
+	http.HandleFunc("/api/addtimelog", handleAPIAddTimeLog)
+	http.HandleFunc("/api/rmtimelog", handleAPIRmTimeLog)
+	http.HandleFunc("/api/timelogs", handleAPITimeLogs)
+	http.HandleFunc("/api/approvetimesheet", handleAPIApproveTimesheet)
+	http.HandleFunc("/api/timesheetreport", handleAPITimesheetReport)
+	http.HandleFunc("/api/schedule", handleAPISchedule)
+	http.HandleFunc("/api/reschedule", handleAPIReschedule)
+	http.HandleFunc("/api/capacity", handleAPICapacity)
+	http.HandleFunc("/api/workcalendar", handleAPIWorkCalendar)
+	http.HandleFunc("/api/setworkcalendar", handleAPIEditWorkCalendar)
+	http.HandleFunc("/api/addleave", handleAPIEditWorkCalendar)
+	http.HandleFunc("/api/rmleave", handleAPIEditWorkCalendar)
+	http.HandleFunc("/api/riskreport", handleAPIRiskReport)
+	http.HandleFunc("/api/taskrisk", handleAPITaskRisk)
+	http.HandleFunc("/api/digestpreference", handleAPIDigestPreference)
+	http.HandleFunc("/api/setdigestpreference", handleAPISetDigestPreference)
+	http.HandleFunc("/api/digest", handleAPIDigest)
+	http.HandleFunc("/api/senddigest", handleAPIDigest)
+	http.HandleFunc("/api/events", handleAPIEvents)
+	http.HandleFunc("/api/webhooks", handleAPIWebhooks)
+	http.HandleFunc("/api/addwebhook", handleAPIEditWebhook)
+	http.HandleFunc("/api/setwebhook", handleAPIEditWebhook)
+	http.HandleFunc("/api/rmwebhook", handleAPIEditWebhook)
+	http.HandleFunc("/api/webhookdeliveries", handleAPIWebhookDeliveries)
+	http.HandleFunc("/api/redeliverwebhook", handleAPIRedeliverWebhook)
+	http.HandleFunc("/api/rmtaskassignee", handleAPITaskAssignee)
 	http.HandleFunc("/api/settaskusercomment", handleAPISetTaskUserComment)
 	http.HandleFunc("/api/setplatein", handleAPISetPlateIn)
 	http.HandleFunc("/api/setplateout", handleAPISetPlateOut)
@@ -365,6 +426,10 @@ func webserver(port string) {
 	http.HandleFunc("/api/sethandleout", handleAPISetHandleOut)
 	http.HandleFunc("/api/setshottype", handleAPISetShotType)
 	http.HandleFunc("/api/setusetype", handleAPISetUseType)
+	http.HandleFunc("/api/plates", handleAPIPlates)
+	http.HandleFunc("/api/addplate", handleAPIPlate)
+	http.HandleFunc("/api/setactiveplate", handleAPIPlate)
+	http.HandleFunc("/api/rmplate", handleAPIPlate)
 	http.HandleFunc("/api/setassettype", handleAPISetAssetType)
 	http.HandleFunc("/api/setoutputname", handleAPISetOutputName)
 	http.HandleFunc("/api/setrnum", handleAPISetRnum)
@@ -435,6 +500,13 @@ func webserver(port string) {
 	http.HandleFunc("/api/shottasksetting", handleAPIShotTasksetting)
 	http.HandleFunc("/api/assettasksetting", handleAPIAssetTasksetting)
 	http.HandleFunc("/api/categorytasksettings", handleAPICategoryTasksettings)
+	http.HandleFunc("/api/tasktemplates", handleAPITaskTemplates)
+	http.HandleFunc("/api/tasktemplate", handleAPITaskTemplate)
+	http.HandleFunc("/api/addtasktemplate", handleAPISetTaskTemplate)
+	http.HandleFunc("/api/edittasktemplate", handleAPISetTaskTemplate)
+	http.HandleFunc("/api/rmtasktemplate", handleAPISetTaskTemplate)
+	http.HandleFunc("/api/setprojecttasktemplate", handleAPISetProjectTaskTemplate)
+	http.HandleFunc("/api/applytasktemplate", handleAPIApplyTaskTemplate)
 
 	// restAPI Status
 	http.HandleFunc("/api/status", handleAPIStatus)
@@ -447,12 +519,28 @@ func webserver(port string) {
 	// restAPI Review
 	http.HandleFunc("/api/addreview", handleAPIAddReview)
 	http.HandleFunc("/api/review", handleAPIReview)
+	http.HandleFunc("/api/addcomparereview", handleAPIAddCompareReview)
+	http.HandleFunc("/api/comparereviewframe", handleAPICompareReviewFrame)
+	http.HandleFunc("/api/addplaylist", handleAPIAddPlaylist)
+	http.HandleFunc("/api/editplaylist", handleAPIEditPlaylist)
+	http.HandleFunc("/api/playlist", handleAPIPlaylist)
+	http.HandleFunc("/api/playlists", handleAPIPlaylists)
+	http.HandleFunc("/api/rmplaylist", handleAPIRmPlaylist)
+	http.HandleFunc("/api/reorderplaylist", handleAPIPlaylistReviews)
+	http.HandleFunc("/api/addplaylistreview", handleAPIPlaylistReviews)
+	http.HandleFunc("/api/rmplaylistreview", handleAPIPlaylistReviews)
+	http.HandleFunc("/api/playlistsession", handleAPIPlaylistSession)
+	http.HandleFunc("/api/setplaylistsession", handleAPISetPlaylistSession)
+	http.HandleFunc("/api/addplaylistnote", handleAPIAddPlaylistNote)
 	http.HandleFunc("/api/searchreview", handleAPISearchReview)
 	http.HandleFunc("/api/setreviewstatus", handleAPISetReviewStatus)
 	http.HandleFunc("/api/setreviewstage", handleAPISetReviewStage)
 	http.HandleFunc("/api/setreviewnextstatus", handleAPISetReviewNextStatus)
 	http.HandleFunc("/api/setreviewnextstage", handleAPISetReviewNextStage)
 	http.HandleFunc("/api/addreviewcomment", handleAPIAddReviewComment)
+	http.HandleFunc("/api/addreviewannotation", handleAPIAddReviewAnnotation)
+	http.HandleFunc("/api/resolvereviewcomment", handleAPIResolveReviewComment)
+	http.HandleFunc("/api/reviewthreads", handleAPIReviewThreads)
 	http.HandleFunc("/api/editreviewcomment", handleAPIEditReviewComment)
 	http.HandleFunc("/api/rmreviewcomment", handleAPIRmReviewComment)
 	http.HandleFunc("/api/rmreview", handleAPIRmReview)
@@ -470,6 +558,8 @@ func webserver(port string) {
 	http.HandleFunc("/api/uploadreviewdrawing", handleAPIUploadReviewDrawing)
 	http.HandleFunc("/api/rmreviewdrawing", handleAPIRmReviewDrawing)
 	http.HandleFunc("/api/reviewdrawingframe", handleAPIReviewDrawingFrame)
+	http.HandleFunc("/api/reviewjobs", handleAPIReviewJobs)
+	http.HandleFunc("/api/exportreviewnotes", handleAPIExportReviewNotes)
 
 	// Deprecated: 사용하지 않는 url, 과거호환성을 위해서 남겨둠
 	http.HandleFunc("/edititem", handleEditItem)                    // legacy
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+// CapacityLine 자료구조는 작업량 페이지에서 아티스트(또는 팀) 한 줄의 주간 작업량을 보여줄 때 사용한다.
+type CapacityLine struct {
+	Name  string
+	Team  string
+	Weeks []CapacityRow
+}
+
+// capacityLines 함수는 리포트 행을 아티스트(또는 팀)별 한 줄로 묶는다. 행은 이름, 주 순서로 정렬되어 있어야 한다.
+func capacityLines(rows []CapacityRow, byTeam bool) []CapacityLine {
+	var lines []CapacityLine
+	for _, row := range rows {
+		name := row.User
+		if byTeam {
+			name = row.Team
+		}
+		if len(lines) == 0 || lines[len(lines)-1].Name != name {
+			lines = append(lines, CapacityLine{Name: name, Team: row.Team})
+		}
+		lines[len(lines)-1].Weeks = append(lines[len(lines)-1].Weeks, row)
+	}
+	return lines
+}
+
+// handleCapacity 함수는 아티스트, 팀별 주간 작업량과 오버부킹, 배정되지 않은 작업을 보여주는 페이지이다.
+func handleCapacity(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel < LeadAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	type recipe struct {
+		User       User
+		Devmode    bool
+		Start      string
+		Weeks      int
+		Team       string
+		Prev       string
+		Next       string
+		WeekStarts []string
+		Users      []CapacityLine
+		Teams      []CapacityLine
+		Unassigned map[string]UnassignedRow
+		Holidays   []Leave
+	}
+	rcp := recipe{}
+	rcp.Devmode = *flagDevmode
+	u, err := getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.User = u
+	q := r.URL.Query()
+	start := q.Get("start")
+	if start == "" {
+		start = time.Now().Format("2006-01-02")
+	}
+	rcp.Weeks = 4
+	if q.Get("weeks") != "" {
+		rcp.Weeks, err = strconv.Atoi(q.Get("weeks"))
+		if err != nil {
+			http.Error(w, "weeks는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Team = q.Get("team")
+	report, err := GetCapacityReport(session, start, rcp.Weeks, rcp.Team, "")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rcp.Start = report.Start
+	rcp.Prev = addScheduleDays(report.Start, -7)
+	rcp.Next = addScheduleDays(report.Start, 7)
+	for n := 0; n < report.Weeks; n++ {
+		rcp.WeekStarts = append(rcp.WeekStarts, addScheduleDays(report.Start, n*7))
+	}
+	rcp.Users = capacityLines(report.Users, false)
+	rcp.Teams = capacityLines(report.Teams, true)
+	rcp.Unassigned = make(map[string]UnassignedRow)
+	for _, row := range report.Unassigned {
+		rcp.Unassigned[row.Week] = row
+	}
+	company, err := getWorkCalendar(session, CompanyCalendarID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Holidays = company.Leaves
+	err = TEMPLATES.ExecuteTemplate(w, "capacity", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
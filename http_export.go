@@ -313,8 +313,9 @@ func handleReportExcel(w http.ResponseWriter, r *http.Request) {
 		SessionID string
 		Devmode   bool
 		SearchOption
-		Errornum    int
-		Projectlist []string
+		Errornum      int
+		Projectlist   []string
+		BreakdownRows []BreakdownExcelrow
 	}
 	rcp := recipe{}
 	rcp.Sheet = "Sheet1"
@@ -452,6 +453,16 @@ func handleReportExcel(w http.ResponseWriter, r *http.Request) {
 	}
 
 	rcp.Rows = rows
+	// Breakdown 시트가 있다면 에셋 브레이크다운을 체크한다.
+	rcp.BreakdownRows, err = readBreakdownExcelrows(f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for n := range rcp.BreakdownRows {
+		rcp.BreakdownRows[n].checkerror(session, project)
+		rcp.Errornum += rcp.BreakdownRows[n].Errornum
+	}
 	err = TEMPLATES.ExecuteTemplate(w, "reportexcel", rcp)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -684,7 +695,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if rnum != "" {
-			_, err := SetRnum(session, project, name, rnum)
+			_, err := SetRnum(session, project, name, ssid.ID, rnum)
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -702,7 +713,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if shottype != "" {
-			_, err := SetShotType(session, project, name, shottype)
+			_, err := SetShotType(session, project, name, ssid.ID, shottype)
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -762,7 +773,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 					rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: "tag에는 특수문자를 사용할 수 없습니다"})
 					continue
 				}
-				_, err = AddTag(session, project, name+"_"+typ, removeSpaceTag)
+				_, err = AddTag(session, project, name+"_"+typ, ssid.ID, removeSpaceTag)
 				if err != nil {
 					rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 					continue
@@ -806,7 +817,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if justTimecodeIn != "" {
-			err = SetJustTimecodeIn(session, project, name, justTimecodeIn)
+			err = SetJustTimecodeIn(session, project, name, ssid.ID, justTimecodeIn)
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -824,7 +835,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if justTimecodeOut != "" {
-			err = SetJustTimecodeOut(session, project, name, justTimecodeOut)
+			err = SetJustTimecodeOut(session, project, name, ssid.ID, justTimecodeOut)
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -847,7 +858,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
 			}
-			_, err = SetDeadline2D(session, project, name, date)
+			_, err = SetDeadline2D(session, project, name, ssid.ID, date)
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -870,7 +881,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
 			}
-			_, err = SetDeadline3D(session, project, name, date)
+			_, err = SetDeadline3D(session, project, name, ssid.ID, date)
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -893,7 +904,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
 			}
-			err = SetFindate(session, project, name, date)
+			err = SetFindate(session, project, name, ssid.ID, date)
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -911,7 +922,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if finver != "" {
-			err = SetFinver(session, project, name, finver)
+			err = SetFinver(session, project, name, ssid.ID, finver)
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -934,7 +945,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
 			}
-			err = SetFrame(session, project, name, "handlein", num)
+			err = SetFrame(session, project, name, ssid.ID, "handlein", num)
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -957,7 +968,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
 			}
-			err = SetFrame(session, project, name, "handleout", num)
+			err = SetFrame(session, project, name, ssid.ID, "handleout", num)
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -969,6 +980,29 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
+	// Breakdown 시트가 있다면 샷에 에셋을 연결한다.
+	breakdownRows, err := readBreakdownExcelrows(f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, row := range breakdownRows {
+		row.checkerror(session, project)
+		if row.Errornum != 0 {
+			rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: row.Shot, Error: strings.TrimSpace(row.ShotError + " " + row.AssetError + " " + row.CrowdCountError)})
+			continue
+		}
+		err = SetBreakdown(session, project, row.ID, ssid.ID, row.Breakdown())
+		if err != nil {
+			rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: row.Shot, Error: err.Error()})
+			continue
+		}
+		err = dilog.Add(*flagDBIP, host, "Set Breakdown: "+row.Asset, project, row.ID, "csi3", ssid.ID, 180)
+		if err != nil {
+			rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: row.Shot, Error: err.Error()})
+			continue
+		}
+	}
 	err = TEMPLATES.ExecuteTemplate(w, "resultexcel", rcp)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -1054,10 +1088,10 @@ func handleJSONSubmit(w http.ResponseWriter, r *http.Request) {
 	for _, i := range rows {
 		if overwrite {
 			// 기존데이터를 삭제한다.
-			err = setItem(session, project, i)
+			err = setItem(session, project, ssid.ID, i)
 			if err == mgo.ErrNotFound {
 				// 새로운 데이터를 추가한다.
-				err = addItem(session, project, i)
+				err = addItem(session, project, ssid.ID, i)
 				if err != nil {
 					http.Error(w, err.Error(), http.StatusBadRequest)
 					return
@@ -1535,7 +1569,7 @@ func handleExportExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			} else {
 				text = Status2capString(i.Tasks[t].Status) // legacy
 			}
-			text += "\n" + i.Tasks[t].User
+			text += "\n" + i.Tasks[t].AssigneesText()
 			text += "\n" + ToNormalTime(i.Tasks[t].Predate)
 			text += "\n" + ToNormalTime(i.Tasks[t].Date)
 			f.SetCellValue(sheet, pos, text)
@@ -1982,7 +2016,7 @@ func handleDownloadExcelFile(w http.ResponseWriter, r *http.Request) {
 			} else {
 				text = Status2capString(i.Tasks[t].Status) // legacy
 			}
-			text += "\n" + i.Tasks[t].User
+			text += "\n" + i.Tasks[t].AssigneesText()
 			text += "\n" + ToNormalTime(i.Tasks[t].Predate)
 			text += "\n" + ToNormalTime(i.Tasks[t].Date)
 			f.SetCellValue(sheet, pos, text)
@@ -2132,6 +2166,16 @@ func handleDownloadExcelTemplate(w http.ResponseWriter, r *http.Request) {
 	f.SetCellValue(sheet, "O1", "Handle Out")
 	f.SetColWidth(sheet, "A", "O", 20)
 	f.SetCellStyle(sheet, "A1", "O1", style)
+	// 에셋 브레이크다운 시트
+	f.NewSheet(BreakdownSheet)
+	f.SetCellValue(BreakdownSheet, "A1", "Shot")
+	f.SetCellValue(BreakdownSheet, "B1", "Asset")
+	f.SetCellValue(BreakdownSheet, "C1", "Variant")
+	f.SetCellValue(BreakdownSheet, "D1", "LOD")
+	f.SetCellValue(BreakdownSheet, "E1", "CrowdCount")
+	f.SetCellValue(BreakdownSheet, "F1", "Note")
+	f.SetColWidth(BreakdownSheet, "A", "F", 20)
+	f.SetCellStyle(BreakdownSheet, "A1", "F1", style)
 	tempDir, err := ioutil.TempDir("", "excel")
 	if err != nil {
 		log.Println(err)
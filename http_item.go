@@ -192,6 +192,7 @@ func handleItemDetail(w http.ResponseWriter, r *http.Request) {
 		Status              []Status
 		AllStatusIDs        []string
 		Stages              []Stage
+		Blockers            []Blocker
 	}
 	rcp := recipe{}
 	rcp.Wfs = *flagWFS
@@ -254,6 +255,13 @@ func handleItemDetail(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if len(rcp.Item.Dependencies) != 0 {
+		rcp.Blockers, err = Blockers(session, project, id, "")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 	err = TEMPLATES.ExecuteTemplate(w, "detail", rcp)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -657,7 +665,7 @@ func handleAddShotSubmit(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 
-		err = addItem(session, project, i)
+		err = addItem(session, project, ssid.ID, i)
 		if err != nil {
 			s.Error = err.Error()
 			fails = append(fails, s)
@@ -1006,7 +1014,7 @@ func handleAddAssetSubmit(w http.ResponseWriter, r *http.Request) {
 			}
 			i.Tasks[task.Name] = t
 		}
-		err = addItem(session, project, i)
+		err = addItem(session, project, ssid.ID, i)
 		if err != nil {
 			a.Error = err.Error()
 			fails = append(fails, a)
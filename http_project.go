@@ -327,6 +327,21 @@ func handleEditProjectSubmit(w http.ResponseWriter, r *http.Request) {
 	renewal.Lut = r.FormValue("Lut")
 	renewal.LutInColorspace = r.FormValue("LutInColorspace")
 	renewal.LutOutColorspace = r.FormValue("LutOutColorspace")
+	renewal.ReviewProfile = r.FormValue("ReviewProfile")
+	renewal.BurnIn.TopLeft = r.FormValue("BurnIn.TopLeft")
+	renewal.BurnIn.TopRight = r.FormValue("BurnIn.TopRight")
+	renewal.BurnIn.BottomLeft = r.FormValue("BurnIn.BottomLeft")
+	renewal.BurnIn.BottomRight = r.FormValue("BurnIn.BottomRight")
+	renewal.BurnIn.FontFile = r.FormValue("BurnIn.FontFile")
+	burnInFontSize, err := strconv.Atoi(r.FormValue("BurnIn.FontSize"))
+	if err == nil {
+		renewal.BurnIn.FontSize = burnInFontSize
+	}
+	err = renewal.BurnIn.CheckError()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	renewal.Description = r.FormValue("Description")
 	renewal.NukeGizmo = r.FormValue("NukeGizmo")
 	renewal.FxElement = r.FormValue("FxElement")
@@ -382,8 +397,9 @@ func handleEditProject(w http.ResponseWriter, r *http.Request) {
 		User               `json:"user"`
 		Devmode            bool `json:"devmode"`
 		SearchOption       `json:"searchoption"`
-		DefaultColorspaces []string `json:"defaultcolorspace"`
-		OCIOColorspaces    []string `json:"ociocolorspaces"`
+		DefaultColorspaces []string           `json:"defaultcolorspace"`
+		OCIOColorspaces    []string           `json:"ociocolorspaces"`
+		TranscodeProfiles  []TranscodeProfile `json:"transcodeprofiles"`
 	}
 	rcp := recipe{}
 	err = rcp.SearchOption.LoadCookie(session, r)
@@ -409,6 +425,11 @@ func handleEditProject(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	rcp.TranscodeProfiles, err = AllTranscodeProfiles(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	rcp.DefaultColorspaces = []string{"default", "linear", "sRGB", "rec709", "Cineon", "AlexaV3LogC", "REDLog", "Gamma2.2", "ACEScg", "ACES2065-1"}
 	err = TEMPLATES.ExecuteTemplate(w, "editProject", rcp)
 	if err != nil {
@@ -147,7 +147,28 @@ func handleReviewData(w http.ResponseWriter, r *http.Request) {
 	if ext == "" {
 		ext = ".mp4" // 확장자가 없다면 기본적으로 mp4를 불러온다.
 	}
-	http.ServeFile(w, r, fmt.Sprintf("%s/%s%s", CachedAdminSetting.ReviewDataPath, id, ext))
+	http.ServeFile(w, r, reviewDataFile(CachedAdminSetting, id, ext, str2bool(q.Get("slate"))))
+}
+
+// handleDownloadReviewNotes 함수는 리뷰 페이지의 검색결과 또는 플레이리스트의 리뷰노트를 내려받는다.
+func handleDownloadReviewNotes(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel < 2 {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	q := r.URL.Query()
+	serveReviewNotes(w, session, q.Get("searchword"), q.Get("playlist"), q.Get("format"))
 }
 
 // handleReviewDrawingData 함수는 리뷰 드로잉 데이터를 전송한다.
@@ -147,6 +147,7 @@ func handleAdminSettingSubmit(w http.ResponseWriter, r *http.Request) {
 	s.InitPassword = r.FormValue("InitPassword")
 	s.ExcludeProject = r.FormValue("ExcludeProject")
 	s.OCIOConfig = r.FormValue("OCIOConfig")
+	s.OCIOBakeLut = r.FormValue("OCIOBakeLut")
 	s.FFmpeg = r.FormValue("FFmpeg")
 	threads, err := strconv.Atoi(r.FormValue("FFmpegThreads"))
 	if err != nil {
@@ -30,6 +30,7 @@ func handleAddStage(w http.ResponseWriter, r *http.Request) {
 		Devmode bool
 		SearchOption
 		Stages []Stage
+		Status []Status
 	}
 	rcp := recipe{}
 	err = rcp.SearchOption.LoadCookie(session, r)
@@ -49,6 +50,11 @@ func handleAddStage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	rcp.Status, err = AllStatus(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	err = TEMPLATES.ExecuteTemplate(w, "addstage", rcp)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -80,15 +86,17 @@ func handleAddStageSubmit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	s := Stage{
-		ID:          r.FormValue("id"),
-		Description: r.FormValue("description"),
-		TextColor:   "#" + r.FormValue("textcolor"),
-		BGColor:     "#" + r.FormValue("bgcolor"),
-		BorderColor: "#" + r.FormValue("bordercolor"),
-		Order:       order,
-		InitStage:   str2bool(r.FormValue("initstage")),
-		NextStage:   r.FormValue("nextstage"),
-		NextStatus:  r.FormValue("nextstatus"),
+		ID:                r.FormValue("id"),
+		Description:       r.FormValue("description"),
+		TextColor:         "#" + r.FormValue("textcolor"),
+		BGColor:           "#" + r.FormValue("bgcolor"),
+		BorderColor:       "#" + r.FormValue("bordercolor"),
+		Order:             order,
+		InitStage:         str2bool(r.FormValue("initstage")),
+		NextStage:         r.FormValue("nextstage"),
+		NextStatus:        r.FormValue("nextstatus"),
+		Final:             str2bool(r.FormValue("final")),
+		ApproveTaskStatus: r.FormValue("approvetaskstatus"),
 	}
 	err = s.CheckError()
 	if err != nil {
@@ -239,6 +247,7 @@ func handleEditStage(w http.ResponseWriter, r *http.Request) {
 		SearchOption
 		Stage
 		Stages []Stage
+		Status []Status
 	}
 	rcp := recipe{}
 	err = rcp.SearchOption.LoadCookie(session, r)
@@ -265,6 +274,11 @@ func handleEditStage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	rcp.Status, err = AllStatus(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	err = TEMPLATES.ExecuteTemplate(w, "editstage", rcp)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -296,15 +310,17 @@ func handleEditStageSubmit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	s := Stage{
-		ID:          r.FormValue("id"),
-		Description: r.FormValue("description"),
-		TextColor:   "#" + r.FormValue("textcolor"),
-		BGColor:     "#" + r.FormValue("bgcolor"),
-		BorderColor: "#" + r.FormValue("bordercolor"),
-		Order:       order,
-		InitStage:   str2bool(r.FormValue("initstage")),
-		NextStage:   r.FormValue("nextstage"),
-		NextStatus:  r.FormValue("nextstatus"),
+		ID:                r.FormValue("id"),
+		Description:       r.FormValue("description"),
+		TextColor:         "#" + r.FormValue("textcolor"),
+		BGColor:           "#" + r.FormValue("bgcolor"),
+		BorderColor:       "#" + r.FormValue("bordercolor"),
+		Order:             order,
+		InitStage:         str2bool(r.FormValue("initstage")),
+		NextStage:         r.FormValue("nextstage"),
+		NextStatus:        r.FormValue("nextstatus"),
+		Final:             str2bool(r.FormValue("final")),
+		ApproveTaskStatus: r.FormValue("approvetaskstatus"),
 	}
 	err = s.CheckError()
 	if err != nil {
@@ -82,6 +82,7 @@ func handleAddStatusSubmit(w http.ResponseWriter, r *http.Request) {
 		Order:       order,
 		DefaultOn:   str2bool(r.FormValue("defaulton")),
 		InitStatus:  str2bool(r.FormValue("initstatus")),
+		Complete:    str2bool(r.FormValue("complete")),
 	}
 	err = s.CheckError()
 	if err != nil {
@@ -291,6 +292,7 @@ func handleEditStatusSubmit(w http.ResponseWriter, r *http.Request) {
 		Order:       order,
 		DefaultOn:   str2bool(r.FormValue("defaulton")),
 		InitStatus:  str2bool(r.FormValue("initstatus")),
+		Complete:    str2bool(r.FormValue("complete")),
 	}
 	err = s.CheckError()
 	if err != nil {
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+// TimesheetDay 자료구조는 타임시트 페이지에서 하루 작업시간 합계를 보여줄 때 사용한다.
+type TimesheetDay struct {
+	Date  string
+	Hours float64
+}
+
+// handleTimesheet 함수는 사용자의 주간 타임시트 페이지이다. Lead 이상의 사용자는 다른 사용자의 타임시트를 보고 승인할 수 있다.
+func handleTimesheet(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel < 3 {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	type recipe struct {
+		User       User
+		Devmode    bool
+		Target     string // 타임시트를 보는 사용자 ID
+		Start      string
+		End        string
+		Prev       string
+		Next       string
+		Days       []TimesheetDay
+		Total      float64
+		Logs       []TimeLog
+		Projects   []Project
+		CanApprove bool
+	}
+	rcp := recipe{}
+	rcp.Devmode = *flagDevmode
+	u, err := getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.User = u
+	q := r.URL.Query()
+	rcp.Target = ssid.ID
+	if q.Get("user") != "" && ssid.AccessLevel >= LeadAccessLevel {
+		rcp.Target = q.Get("user")
+	}
+	rcp.CanApprove = ssid.AccessLevel >= LeadAccessLevel && rcp.Target != ssid.ID
+	date := q.Get("date")
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	rcp.Start, rcp.End, err = weekRange(date)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	monday, _ := time.Parse("2006-01-02", rcp.Start)
+	rcp.Prev = monday.AddDate(0, 0, -7).Format("2006-01-02")
+	rcp.Next = monday.AddDate(0, 0, 7).Format("2006-01-02")
+	rcp.Logs, err = searchTimeLogs(session, TimeLogFilter{UserID: rcp.Target, Start: rcp.Start, End: rcp.End})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	hours := make(map[string]float64)
+	for _, l := range rcp.Logs {
+		hours[l.Date] += l.Hours
+		rcp.Total += l.Hours
+	}
+	for n := 0; n < 7; n++ {
+		d := monday.AddDate(0, 0, n).Format("2006-01-02")
+		rcp.Days = append(rcp.Days, TimesheetDay{Date: d, Hours: hours[d]})
+	}
+	rcp.Projects, err = getProjects(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = TEMPLATES.ExecuteTemplate(w, "timesheet", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleTranscodeProfile 함수는 TranscodeProfile 리스트를 보는 페이지이다.
+func handleTranscodeProfile(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	type recipe struct {
+		User    User
+		Devmode bool
+		SearchOption
+		TranscodeProfiles []TranscodeProfile
+	}
+	rcp := recipe{}
+	err = rcp.SearchOption.LoadCookie(session, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Devmode = *flagDevmode
+	rcp.TranscodeProfiles, err = AllTranscodeProfiles(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	u, err := getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.User = u
+	err = TEMPLATES.ExecuteTemplate(w, "transcodeprofile", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleAddTranscodeProfile 함수는 TranscodeProfile을 추가하는 페이지이다.
+func handleAddTranscodeProfile(w http.ResponseWriter, r *http.Request) {
+	handleTranscodeProfileForm(w, r, "addtranscodeprofile")
+}
+
+// handleEditTranscodeProfile 함수는 TranscodeProfile을 편집하는 페이지이다.
+func handleEditTranscodeProfile(w http.ResponseWriter, r *http.Request) {
+	handleTranscodeProfileForm(w, r, "edittranscodeprofile")
+}
+
+// handleTranscodeProfileForm 함수는 TranscodeProfile 추가, 편집 페이지를 그린다.
+// 추가 페이지는 기본 프로파일 값을, 편집 페이지는 id에 해당하는 프로파일 값을 채워서 보여준다.
+func handleTranscodeProfileForm(w http.ResponseWriter, r *http.Request, templateName string) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	type recipe struct {
+		User    User
+		Devmode bool
+		SearchOption
+		TranscodeProfile
+	}
+	rcp := recipe{}
+	err = rcp.SearchOption.LoadCookie(session, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Devmode = *flagDevmode
+	u, err := getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.User = u
+	if templateName == "edittranscodeprofile" {
+		rcp.TranscodeProfile, err = GetTranscodeProfile(session, r.URL.Query().Get("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		rcp.TranscodeProfile = defaultTranscodeProfile()
+		rcp.TranscodeProfile.ID = ""
+		rcp.TranscodeProfile.Description = ""
+	}
+	err = TEMPLATES.ExecuteTemplate(w, templateName, rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleAddTranscodeProfileSubmit 함수는 TranscodeProfile을 추가합니다.
+func handleAddTranscodeProfileSubmit(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	t, err := transcodeProfileFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = AddTranscodeProfile(session, t)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/transcodeprofile", http.StatusSeeOther)
+}
+
+// handleEditTranscodeProfileSubmit 함수는 TranscodeProfile을 수정합니다.
+func handleEditTranscodeProfileSubmit(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	t, err := transcodeProfileFromForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = SetTranscodeProfile(session, t)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/transcodeprofile", http.StatusSeeOther)
+}
+
+// handleRmTranscodeProfileSubmit 함수는 TranscodeProfile을 삭제합니다.
+func handleRmTranscodeProfileSubmit(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	err = RmTranscodeProfile(session, r.FormValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/transcodeprofile", http.StatusSeeOther)
+}
+
+// transcodeProfileFromForm 함수는 Form 값을 이용해서 TranscodeProfile 자료구조를 만들고 에러를 체크한다.
+func transcodeProfileFromForm(r *http.Request) (TranscodeProfile, error) {
+	t := TranscodeProfile{
+		ID:           r.FormValue("id"),
+		Description:  r.FormValue("description"),
+		Codec:        r.FormValue("codec"),
+		Ext:          r.FormValue("ext"),
+		Bitrate:      r.FormValue("bitrate"),
+		PixFmt:       r.FormValue("pixfmt"),
+		MovFormat:    r.FormValue("movformat"),
+		BurnIn:       str2bool(r.FormValue("burnin")),
+		Audio:        r.FormValue("audio"),
+		AudioBitrate: r.FormValue("audiobitrate"),
+	}
+	var err error
+	for key, value := range map[string]*int{
+		"crf":    &t.CRF,
+		"qscale": &t.Qscale,
+		"width":  &t.Width,
+		"height": &t.Height,
+	} {
+		if r.FormValue(key) == "" {
+			continue
+		}
+		*value, err = strconv.Atoi(r.FormValue(key))
+		if err != nil {
+			return t, err
+		}
+	}
+	if r.FormValue("fps") != "" {
+		t.Fps, err = strconv.ParseFloat(r.FormValue("fps"), 64)
+		if err != nil {
+			return t, err
+		}
+	}
+	return t, t.CheckError()
+}
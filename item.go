@@ -57,6 +57,7 @@ type Version struct {
 }
 
 // Item 자료구조는 하나의 항목에 대한 자료구조이다.
+// patch 태그가 있는 필드는 /api/v4/item 에서 수정할 수 있다. 태그는 "최소 권한,검사규칙,..." 형태이다. itempatch.go 참고
 type Item struct {
 	Project string `json:"project"` // 프로젝트명
 	ID      string `json:"id"`      // ID
@@ -65,74 +66,78 @@ type Item struct {
 	// 현장에서 사용하는 카메라 데이터 이름. 슈퍼바이저 툴과 연동하기 위해서 Key로 사용된다.
 	// 일반적으로 스캔이름과 같지만 항상 동일하지 않다.
 	// 카메라 데이터 A037C012_160708_R717.[11694428-1172226].ari 형태에서 A037C012_160708_R717 부분이 데이터 이름이다.
-	Dataname string `json:"dataname"` // 영화카메라(Red,Alexa등)이 자동 생성시키는 이미지 파일명이다.
+	Dataname string `json:"dataname" patch:"artist"` // 영화카메라(Red,Alexa등)이 자동 생성시키는 이미지 파일명이다.
 
 	// 작업이 필요한 정보
-	Scanname         string          `json:"scanname"`         // 스캔이름
-	Platesize        string          `json:"platesize"`        // 플레이트 이미지사이즈
-	Name             string          `json:"name"`             // 샷이름 SS_0010
-	Season           string          `json:"season"`           // 시즌명
-	Episode          string          `json:"episode"`          // 에피소드명
-	Seq              string          `json:"seq"`              // 시퀀스이름 SS_0010 에서 SS문자에 해당하는값. 에셋이면 "" 문자열이 들어간다.
-	Cut              string          `json:"cut"`              // 시퀀스이름 SS_0010 에서 0010문자에 해당하는값. 에셋이면 "" 문자열이 들어간다.
-	Type             string          `json:"type"`             // org, org1, src, asset..
-	Assettype        string          `json:"assettype"`        // char, env, prop, comp, plant, vehicle, group
-	CrowdAsset       bool            `json:"crowdasset"`       // 군중씬에서 사용하는 에셋인지 여부 체크
-	UseType          string          `json:"usetype"`          // 재스캔상황시 실제로 사용해야하는 타입표기
-	Scantime         string          `json:"scantime"`         // 스캔 등록시간 RFC3339
-	Thumpath         string          `json:"thumpath"`         // 썸네일경로
-	Thummov          string          `json:"thummov"`          // 썸네일 mov 경로
-	Editmov          string          `json:"editmov"`          // Edit(편집본) mov 경로
-	Beforemov        string          `json:"beforemov"`        // 전에 들어갈 mov. 만약 2개 이상이라면 space로 구분한다.
-	Aftermov         string          `json:"aftermov"`         // 후에 들어갈 mov. 만약 2개 이상이라면 space로 구분한다.
-	Retimeplate      string          `json:"retimeplate"`      // 리타임 플레이트 경로
-	Platepath        string          `json:"platepath"`        // 플레이트 경로
-	Shottype         string          `json:"shottype"`         // "", "2d", "3d"
-	Ddline3d         string          `json:"ddline3d"`         // 3D 데드라인 RFC3339
-	Ddline2d         string          `json:"ddline2d"`         // 2D 데드라인 RFC3339
-	Rnum             string          `json:"rnum"`             // 롤넘버, 영화를 권으로 나누었을 때 이 샷의 권 번호 예) A0001. A는 1권을 H는 8권을 의미한다.
-	Tag              []string        `json:"tag"`              // 태그리스트
-	Assettags        []string        `json:"assettags"`        // 에셋그룹 태그
-	Finname          string          `json:"finname"`          // 파이널 파일이름
-	Finver           string          `json:"finver"`           // 파이널된 버젼
-	Findate          string          `json:"findate"`          // 파이널 데이터가 나간 날짜
-	Clientver        string          `json:"clientver"`        // 클라이언트에게 보낸 버전
-	Dsize            string          `json:"dsize"`            // 언디스토션 사이즈 legacy
-	Rendersize       string          `json:"rendersize"`       // 특수상황시 렌더사이즈. 예) 5k플레이트를 3D에서 2k영역만 잡아서 최종 아웃풋까지 이어질 때
-	Undistortionsize string          `json:"undistortionsize"` // 언디스토션 사이즈 legacy
-	OverscanRatio    float64         `json:"overscanratio"`    // 오버스캔 비율
-	Status           string          `json:"status"`           // 샷 상태. legacy
-	StatusV2         string          `json:"statusv2"`         // 샷 상태.
-	Updatetime       string          `json:"updatetime"`       // 업데이트 시간 RFC3339
-	Focal            string          `json:"focal"`            // 렌즈 미리수
-	Stereotype       string          `json:"stereotype"`       // parallel(default), conversions
-	Stereoeye        string          `json:"stereoeye"`        // left(default), right
-	Outputname       string          `json:"outputname"`       // 프로젝트중 클라이언트가 제시하는 아웃풋 이름
-	OCIOcc           string          `json:"ociocc"`           // Neutural Grading Pipeline에 사용하는 .cc 파일의 경로.
-	Note             Comment         `json:"note"`             // 작업내용
-	Sources          []Source        `json:"links"`            // 연결소스
-	References       []Source        `json:"references"`       // 레퍼런스
-	Comments         []Comment       `json:"comments"`         // 수정내용
-	Tasks            map[string]Task `json:"tasks"`            // Task 리스트
+	Scanname         string          `json:"scanname" patch:"artist"`                // 스캔이름
+	Platesize        string          `json:"platesize"`                              // 플레이트 이미지사이즈
+	Name             string          `json:"name"`                                   // 샷이름 SS_0010
+	Season           string          `json:"season" patch:"artist"`                  // 시즌명
+	Episode          string          `json:"episode" patch:"artist"`                 // 에피소드명
+	Seq              string          `json:"seq" patch:"artist"`                     // 시퀀스이름 SS_0010 에서 SS문자에 해당하는값. 에셋이면 "" 문자열이 들어간다.
+	Cut              string          `json:"cut"`                                    // 시퀀스이름 SS_0010 에서 0010문자에 해당하는값. 에셋이면 "" 문자열이 들어간다.
+	Type             string          `json:"type"`                                   // org, org1, src, asset..
+	Assettype        string          `json:"assettype" patch:"lead,asset,assettype"` // char, env, prop, comp, plant, vehicle, group
+	CrowdAsset       bool            `json:"crowdasset"`                             // 군중씬에서 사용하는 에셋인지 여부 체크
+	UseType          string          `json:"usetype"`                                // 재스캔상황시 실제로 사용해야하는 타입표기. legacy: ActivePlate를 따른다.
+	Plates           []Plate         `json:"plates"`                                 // 플레이트 버전 리스트. 재스캔, 소스 플레이트
+	ActivePlate      string          `json:"activeplate"`                            // 사용중인 플레이트 버전 타입. 예) org1
+	Scantime         string          `json:"scantime"`                               // 스캔 등록시간 RFC3339
+	Thumpath         string          `json:"thumpath"`                               // 썸네일경로
+	Thummov          string          `json:"thummov" patch:"artist"`                 // 썸네일 mov 경로
+	Editmov          string          `json:"editmov" patch:"artist"`                 // Edit(편집본) mov 경로
+	Beforemov        string          `json:"beforemov" patch:"artist"`               // 전에 들어갈 mov. 만약 2개 이상이라면 space로 구분한다.
+	Aftermov         string          `json:"aftermov" patch:"artist"`                // 후에 들어갈 mov. 만약 2개 이상이라면 space로 구분한다.
+	Retimeplate      string          `json:"retimeplate" patch:"artist,shot"`        // 리타임 플레이트 경로
+	Platepath        string          `json:"platepath" patch:"artist"`               // 플레이트 경로
+	Shottype         string          `json:"shottype" patch:"lead,shot,shottype"`    // "", "2d", "3d"
+	Ddline3d         string          `json:"ddline3d" patch:"lead,fulltime"`         // 3D 데드라인 RFC3339
+	Ddline2d         string          `json:"ddline2d" patch:"lead,fulltime"`         // 2D 데드라인 RFC3339
+	Rnum             string          `json:"rnum" patch:"lead,shot,rnum"`            // 롤넘버, 영화를 권으로 나누었을 때 이 샷의 권 번호 예) A0001. A는 1권을 H는 8권을 의미한다.
+	Tag              []string        `json:"tag"`                                    // 태그리스트
+	Assettags        []string        `json:"assettags"`                              // 에셋그룹 태그
+	Finname          string          `json:"finname" patch:"pm"`                     // 파이널 파일이름
+	Finver           string          `json:"finver" patch:"pm"`                      // 파이널된 버젼
+	Findate          string          `json:"findate" patch:"pm,fulltime"`            // 파이널 데이터가 나간 날짜
+	Clientver        string          `json:"clientver" patch:"pm"`                   // 클라이언트에게 보낸 버전
+	Dsize            string          `json:"dsize"`                                  // 언디스토션 사이즈 legacy
+	Rendersize       string          `json:"rendersize"`                             // 특수상황시 렌더사이즈. 예) 5k플레이트를 3D에서 2k영역만 잡아서 최종 아웃풋까지 이어질 때
+	Undistortionsize string          `json:"undistortionsize"`                       // 언디스토션 사이즈 legacy
+	OverscanRatio    float64         `json:"overscanratio" patch:"artist"`           // 오버스캔 비율
+	Status           string          `json:"status"`                                 // 샷 상태. legacy
+	StatusV2         string          `json:"statusv2"`                               // 샷 상태.
+	Updatetime       string          `json:"updatetime"`                             // 업데이트 시간 RFC3339
+	Focal            string          `json:"focal" patch:"artist"`                   // 렌즈 미리수
+	Stereotype       string          `json:"stereotype" patch:"artist"`              // parallel(default), conversions
+	Stereoeye        string          `json:"stereoeye" patch:"artist"`               // left(default), right
+	Outputname       string          `json:"outputname" patch:"pm,shot,required"`    // 프로젝트중 클라이언트가 제시하는 아웃풋 이름
+	OCIOcc           string          `json:"ociocc" patch:"artist,shot"`             // Neutural Grading Pipeline에 사용하는 .cc 파일의 경로.
+	Note             Comment         `json:"note"`                                   // 작업내용
+	Sources          []Source        `json:"links"`                                  // 연결소스
+	References       []Source        `json:"references"`                             // 레퍼런스
+	Comments         []Comment       `json:"comments"`                               // 수정내용
+	Tasks            map[string]Task `json:"tasks"`                                  // Task 리스트
+	Dependencies     []Dependency    `json:"dependencies"`                           // 의존관계. 이 아이템(Task)보다 먼저 끝나야 하는 상위 아이템(Task) 리스트
+	Breakdown        []Breakdown     `json:"breakdown"`                              // 에셋 브레이크다운. 샷에서 사용하는 에셋과 샷별 사용정보
 
 	//시간에 관련된 데이터이다.
-	ScanFrame       int                    `json:"scanframe"`       // 스캔 프레임수
-	ScanTimecodeIn  string                 `json:"scantimecodein"`  // 스캔플레이트 타임코드 In
-	ScanTimecodeOut string                 `json:"scantimecodeout"` // 스캔플레이트 타임코드 Out
-	ScanIn          int                    `json:"scanin"`          // 스캔 Frame In
-	ScanOut         int                    `json:"scanout"`         // 스캔 Frame Out
-	HandleIn        int                    `json:"handlein"`        // 핸들 Frame In
-	HandleOut       int                    `json:"handleout"`       // 핸들 Frame Out
-	JustIn          int                    `json:"justin"`          // 저스트 Frame In
-	JustOut         int                    `json:"justout"`         // 저스트 Frame Out
-	JustTimecodeIn  string                 `json:"justtimecodein"`  // 저스트 타임코드 In
-	JustTimecodeOut string                 `json:"justtimecodeout"` // 저스트 타임코드 Out
-	PlateIn         int                    `json:"platein"`         // 플레이트 Frame In
-	PlateOut        int                    `json:"plateout"`        // 플레이트 Frame Out
-	Soundfile       string                 `json:"soundfile"`       // 사운드파일 필요시 사운드파일 경로
-	Rollmedia       string                 `json:"rollmedia"`       // 현장데이터의 Rollmedia 문자. 수동으로 현장데이터와 연결할 때 사용한다.
-	ObjectidIn      int                    `json:"objectidin"`      // ObjectID 시작번호. Deep이미지의 DeepID를 만들기 위해서 파이프라인상 필요하다.
-	ObjectidOut     int                    `json:"objectidout"`     // ObjectID 끝번호. Deep이미지의 DeepID를 만들기 위해서 파인라인상 필요하다.
+	ScanFrame       int                    `json:"scanframe" patch:"artist"`                     // 스캔 프레임수
+	ScanTimecodeIn  string                 `json:"scantimecodein" patch:"artist,shot,timecode"`  // 스캔플레이트 타임코드 In
+	ScanTimecodeOut string                 `json:"scantimecodeout" patch:"artist,shot,timecode"` // 스캔플레이트 타임코드 Out
+	ScanIn          int                    `json:"scanin" patch:"artist"`                        // 스캔 Frame In
+	ScanOut         int                    `json:"scanout" patch:"artist"`                       // 스캔 Frame Out
+	HandleIn        int                    `json:"handlein" patch:"artist"`                      // 핸들 Frame In
+	HandleOut       int                    `json:"handleout" patch:"artist"`                     // 핸들 Frame Out
+	JustIn          int                    `json:"justin" patch:"artist"`                        // 저스트 Frame In
+	JustOut         int                    `json:"justout" patch:"artist"`                       // 저스트 Frame Out
+	JustTimecodeIn  string                 `json:"justtimecodein" patch:"artist,shot,timecode"`  // 저스트 타임코드 In
+	JustTimecodeOut string                 `json:"justtimecodeout" patch:"artist,shot,timecode"` // 저스트 타임코드 Out
+	PlateIn         int                    `json:"platein" patch:"artist"`                       // 플레이트 Frame In
+	PlateOut        int                    `json:"plateout" patch:"artist"`                      // 플레이트 Frame Out
+	Soundfile       string                 `json:"soundfile" patch:"artist"`                     // 사운드파일 필요시 사운드파일 경로
+	Rollmedia       string                 `json:"rollmedia" patch:"artist"`                     // 현장데이터의 Rollmedia 문자. 수동으로 현장데이터와 연결할 때 사용한다.
+	ObjectidIn      int                    `json:"objectidin" patch:"artist"`                    // ObjectID 시작번호. Deep이미지의 DeepID를 만들기 위해서 파이프라인상 필요하다.
+	ObjectidOut     int                    `json:"objectidout" patch:"artist"`                   // ObjectID 끝번호. Deep이미지의 DeepID를 만들기 위해서 파인라인상 필요하다.
 	OnsetCam        `json:"onsetcam"`      // 현장 카메라 정보
 	ProductionCam   `json:"productioncam"` // 포스트 프로덕션 카메라 정보
 }
@@ -168,7 +173,8 @@ type Task struct {
 	Mov          string               `json:"mov"`          // mov 경로
 	Mdate        string               `json:"mdate"`        // mov 업데이트 날짜 RFC3339
 	ExpectDay    int                  `json:"expectday"`    // 예측 맨데이
-	ResultDay    int                  `json:"resultday"`    // 실제 맨데이
+	ResultDay    int                  `json:"resultday"`    // 실제 맨데이. Assignees가 있다면 아티스트별 실제 맨데이의 합이다.
+	Assignees    []Assignee           `json:"assignees"`    // 작업 아티스트 리스트. 첫번째 lead 아티스트가 User에 설정된다.
 	UserNote     string               `json:"usernote"`     // 아티스트와 관련된 엘리먼트등의 정보를 입력하기 위해 사용.
 	TaskLevel    `json:"tasklevel"`   // 샷 레벨
 	Publishes    map[string][]Publish // 퍼블리쉬 정보, string값은 "Primary Key"가 된다.
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/digital-idea/ditime"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ItemBatchOperation 자료구조는 여러 아이템에 한번에 적용할 수정 작업이다.
+type ItemBatchOperation struct {
+	IDs   []string `json:"ids"`   // 아이템 ID 리스트. 예) SS_0010_org
+	Op    string   `json:"op"`    // 작업. setdeadline2d, setdeadline3d, addtag, rmtag, settaskuser, settaskstatus, settaskdate, settaskpredate, settaskstartdate
+	Task  string   `json:"task"`  // Task 작업에 사용하는 Task 이름
+	Value string   `json:"value"` // 설정할 값
+}
+
+// ItemBatchResult 자료구조는 일괄수정 작업의 아이템별 결과이다.
+type ItemBatchResult struct {
+	ID       string        `json:"id"`       // 아이템 ID
+	Changes  []ItemHistory `json:"changes"`  // 바뀌는 필드 리스트
+	Warnings []string      `json:"warnings"` // 저장 후 경고 메시지. 예) settaskuser의 오버부킹 경고
+	Error    string        `json:"error"`    // 에러 메시지. 에러가 없다면 빈 문자열이다.
+}
+
+// itemBatchTaskOps 는 Task가 필요한 일괄수정 작업 리스트이다.
+var itemBatchTaskOps = map[string]bool{
+	"settaskuser":      true,
+	"settaskstatus":    true,
+	"settaskdate":      true,
+	"settaskpredate":   true,
+	"settaskstartdate": true,
+}
+
+// itemBatchIDs 함수는 작업 리스트에 등장하는 아이템 ID를 중복없이 처음 등장한 순서대로 반환한다.
+func itemBatchIDs(ops []ItemBatchOperation) []string {
+	var ids []string
+	found := make(map[string]bool)
+	for _, op := range ops {
+		for _, id := range op.IDs {
+			if found[id] {
+				continue
+			}
+			found[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// applyItemBatchOperation 함수는 아이템 하나에 일괄수정 작업을 메모리에서 적용한다.
+// 단일 수정 API와 같은 규칙으로 값을 검사하고, DB에는 쓰지 않는다.
+func applyItemBatchOperation(item *Item, op ItemBatchOperation, globalStatus []Status) error {
+	task := strings.ToLower(op.Task)
+	var t Task
+	if itemBatchTaskOps[op.Op] {
+		if task == "" {
+			return fmt.Errorf("%s 작업은 task를 설정해야 합니다", op.Op)
+		}
+		var found bool
+		t, found = item.Tasks[task]
+		if !found {
+			return fmt.Errorf("%s 에 %s task가 존재하지 않습니다", item.ID, task)
+		}
+	}
+	switch op.Op {
+	case "setdeadline2d", "setdeadline3d", "settaskdate", "settaskpredate", "settaskstartdate":
+		fullTime, err := ditime.ToFullTime(19, op.Value)
+		if err != nil {
+			return err
+		}
+		switch op.Op {
+		case "setdeadline2d":
+			item.Ddline2d = fullTime
+		case "setdeadline3d":
+			item.Ddline3d = fullTime
+		case "settaskdate":
+			t.Date = fullTime
+		case "settaskpredate":
+			t.Predate = fullTime
+		case "settaskstartdate":
+			t.Startdate = fullTime
+		}
+	case "addtag":
+		tag := strings.Replace(op.Value, " ", "", -1) // 태그는 공백을 제거한다.
+		if tag == "" {
+			return errors.New("추가할 태그가 비어있습니다")
+		}
+		for _, t := range item.Tag {
+			if t == tag {
+				return errors.New(op.Value + "태그는 이미 존재하고 있습니다 추가할 수 없습니다")
+			}
+		}
+		item.Tag = append(item.Tag, tag)
+	case "rmtag":
+		var tags []string
+		for _, t := range item.Tag {
+			if t == op.Value {
+				continue
+			}
+			tags = append(tags, t)
+		}
+		item.Tag = tags
+		// RmTag와 같이 롤넘버가 있다면 권 태그는 지워지지 않아야 한다.
+		item.setRnumTag()
+	case "settaskuser":
+		t.SetLeadUser(op.Value)
+	case "settaskstatus":
+		hasStatus := false
+		for _, s := range globalStatus {
+			if s.ID == op.Value {
+				hasStatus = true
+				break
+			}
+		}
+		if !hasStatus {
+			return fmt.Errorf("%s status가 존재하지 않습니다", op.Value)
+		}
+		t.StatusV2 = op.Value
+	default:
+		return fmt.Errorf("%s 는 지원하지 않는 작업입니다", op.Op)
+	}
+	if itemBatchTaskOps[op.Op] {
+		item.Tasks[task] = t
+		if op.Op == "settaskstatus" {
+			item.updateStatusV2(globalStatus)
+		}
+	}
+	item.Updatetime = time.Now().Format(time.RFC3339)
+	return nil
+}
+
+// applyItemBatch 함수는 작업 리스트를 순서대로 아이템에 적용하고 아이템별 결과를 반환한다.
+// items는 아이템 ID를 키로 사용하고 작업이 적용된 값으로 바뀐다. 에러가 하나라도 있으면 false를 반환한다.
+func applyItemBatch(items map[string]*Item, ops []ItemBatchOperation, globalStatus []Status) ([]ItemBatchResult, bool) {
+	ids := itemBatchIDs(ops)
+	errs := make(map[string]string)
+	befores := make(map[string]bson.M)
+	for _, id := range ids {
+		item, ok := items[id]
+		if !ok {
+			errs[id] = id + " 아이템이 존재하지 않습니다"
+			continue
+		}
+		doc, err := itemDocument(*item)
+		if err != nil {
+			errs[id] = err.Error()
+			continue
+		}
+		befores[id] = doc
+	}
+	for _, op := range ops {
+		for _, id := range op.IDs {
+			if errs[id] != "" {
+				continue // 이미 에러가 난 아이템은 첫 에러만 보고한다.
+			}
+			err := applyItemBatchOperation(items[id], op, globalStatus)
+			if err != nil {
+				errs[id] = fmt.Sprintf("%s: %s", op.Op, err.Error())
+			}
+		}
+	}
+	ok := true
+	var results []ItemBatchResult
+	for _, id := range ids {
+		result := ItemBatchResult{ID: id, Changes: []ItemHistory{}, Warnings: []string{}}
+		if errs[id] != "" {
+			result.Error = errs[id]
+			ok = false
+			results = append(results, result)
+			continue
+		}
+		after, err := itemDocument(*items[id])
+		if err != nil {
+			result.Error = err.Error()
+			ok = false
+			results = append(results, result)
+			continue
+		}
+		changes := diffItemDocuments("", befores[id], after)
+		for i := range changes {
+			changes[i].ItemID = id
+		}
+		result.Changes = append(result.Changes, changes...)
+		results = append(results, result)
+	}
+	return results, ok
+}
+
+// itemBatchUpdate 함수는 일괄수정으로 바뀐 필드만 저장하는 업데이트 문서를 만든다. 값이 없어진 필드는 $unset 한다.
+// 아이템 전체를 쓰지 않기 때문에 아이템을 읽은 뒤 다른 곳에서 수정한 필드를 덮어쓰지 않는다.
+func itemBatchUpdate(changes []ItemHistory, updatetime string) bson.M {
+	set := bson.M{"updatetime": updatetime}
+	unset := bson.M{}
+	for _, h := range changes {
+		if h.New == nil {
+			unset[h.Field] = ""
+			continue
+		}
+		set[h.Field] = h.New
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update
+}
+
+// itemDocument 함수는 아이템을 DB에 저장되는 형태의 문서로 바꾼다.
+func itemDocument(item Item) (bson.M, error) {
+	data, err := bson.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.M
+	err = bson.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestApplyItemBatch(t *testing.T) {
+	globalStatus := []Status{{ID: "wip", Order: 1}, {ID: "done", Order: 2}}
+	items := map[string]*Item{
+		"SS_0010_org": {ID: "SS_0010_org", Tag: []string{"fx"}, Tasks: map[string]Task{"comp": {StatusV2: "wip"}}},
+		"SS_0020_org": {ID: "SS_0020_org", Tasks: map[string]Task{"comp": {StatusV2: "wip"}}},
+	}
+	cases := []struct {
+		ops     []ItemBatchOperation
+		ok      bool
+		changes map[string]int // 아이템별 바뀐 필드 수
+	}{{
+		ops: []ItemBatchOperation{
+			{IDs: []string{"SS_0010_org", "SS_0020_org"}, Op: "settaskstatus", Task: "comp", Value: "done"},
+			{IDs: []string{"SS_0010_org"}, Op: "addtag", Value: "crowd"},
+		},
+		ok:      true,
+		changes: map[string]int{"SS_0010_org": 3, "SS_0020_org": 2}, // statusv2, tasks.comp.statusv2, tag
+	}, {
+		ops: []ItemBatchOperation{
+			{IDs: []string{"SS_0010_org"}, Op: "addtag", Value: "fx"},
+		},
+		ok: false,
+	}, {
+		ops: []ItemBatchOperation{
+			{IDs: []string{"SS_0010_org"}, Op: "settaskuser", Task: "fx", Value: "kim"},
+		},
+		ok: false,
+	}, {
+		ops: []ItemBatchOperation{
+			{IDs: []string{"SS_0030_org"}, Op: "rmtag", Value: "fx"},
+		},
+		ok: false,
+	}, {
+		ops: []ItemBatchOperation{
+			{IDs: []string{"SS_0020_org"}, Op: "settaskstatus", Task: "comp", Value: "none"},
+		},
+		ok: false,
+	}}
+	for n, c := range cases {
+		// 매번 새 아이템으로 시작한다.
+		copied := make(map[string]*Item)
+		for id, item := range items {
+			i := *item
+			i.Tasks = make(map[string]Task)
+			for k, v := range item.Tasks {
+				i.Tasks[k] = v
+			}
+			copied[id] = &i
+		}
+		results, ok := applyItemBatch(copied, c.ops, globalStatus)
+		if ok != c.ok {
+			t.Fatalf("TestApplyItemBatch(%d): 얻은 값 %v, 원하는 값 %v (%v)", n, ok, c.ok, results)
+		}
+		for _, r := range results {
+			if want, found := c.changes[r.ID]; found && len(r.Changes) != want {
+				t.Fatalf("TestApplyItemBatch(%d, %s): 얻은 값 %v, 원하는 값 %v", n, r.ID, r.Changes, want)
+			}
+		}
+	}
+}
+
+func TestApplyItemBatchRmRnumTag(t *testing.T) {
+	// 롤넘버가 있다면 rmtag로 권 태그를 지워도 남아있어야 한다.
+	item := Item{ID: "SS_0010_org", Rnum: "B0010", Tag: []string{"2권", "fx"}}
+	err := applyItemBatchOperation(&item, ItemBatchOperation{IDs: []string{item.ID}, Op: "rmtag", Value: "2권"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(item.Tag, []string{"2권", "fx"}) {
+		t.Fatalf("TestApplyItemBatchRmRnumTag(%v): 얻은 값 %v, 원하는 값 %v", "2권", item.Tag, []string{"2권", "fx"})
+	}
+}
+
+func TestItemBatchUpdate(t *testing.T) {
+	changes := []ItemHistory{
+		{Field: "ddline2d", Old: "", New: "2020-12-01T19:00:00+09:00"},
+		{Field: "tasks.comp.user", Old: "kim", New: "lee"},
+		{Field: "tag", Old: []interface{}{"fx"}, New: nil},
+	}
+	got := itemBatchUpdate(changes, "2020-11-02T09:00:00+09:00")
+	want := bson.M{
+		"$set": bson.M{
+			"ddline2d":        "2020-12-01T19:00:00+09:00",
+			"tasks.comp.user": "lee",
+			"updatetime":      "2020-11-02T09:00:00+09:00",
+		},
+		"$unset": bson.M{"tag": ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("TestItemBatchUpdate(%v): 얻은 값 %v, 원하는 값 %v", changes, got, want)
+	}
+	got = itemBatchUpdate(nil, "2020-11-02T09:00:00+09:00")
+	if _, ok := got["$unset"]; ok {
+		t.Fatalf("TestItemBatchUpdate(%v): 얻은 값 %v, 원하는 값 %v", nil, got, "$set only")
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// ItemGroupSeq 는 시퀀스 그룹이다. 아이템의 Seq 값으로 묶인다.
+	ItemGroupSeq = "seq"
+	// ItemGroupEpisode 는 에피소드 그룹이다. 아이템의 Episode 값으로 묶인다.
+	ItemGroupEpisode = "episode"
+)
+
+// ItemGroup 자료구조는 프로젝트의 시퀀스 또는 에피소드 정보이다.
+// 시퀀스, 에피소드 전체에 해당하는 설명, 마감일, 상태, 코멘트를 샷마다 복사하지 않고 한곳에서 관리한다.
+type ItemGroup struct {
+	ID          bson.ObjectId `json:"id" bson:"_id,omitempty"` // ID
+	Project     string        `json:"project"`                 // 프로젝트
+	Kind        string        `json:"kind"`                    // seq, episode
+	Name        string        `json:"name"`                    // 이름. 아이템의 Seq 또는 Episode 값이다. 예) SS, E01
+	Description string        `json:"description"`             // 설명
+	Supervisor  string        `json:"supervisor"`              // 담당 슈퍼바이저 ID
+	StatusV2    string        `json:"statusv2"`                // 상태
+	Ddline2d    string        `json:"ddline2d"`                // 2D 마감일 RFC3339
+	Ddline3d    string        `json:"ddline3d"`                // 3D 마감일 RFC3339
+	Comments    []Comment     `json:"comments"`                // 코멘트
+	Createtime  string        `json:"createtime"`              // 생성시간 RFC3339
+	Updatetime  string        `json:"updatetime"`              // 업데이트 시간 RFC3339
+}
+
+// ItemGroupProgress 자료구조는 시퀀스, 에피소드에 속한 아이템의 진행상황이다.
+type ItemGroupProgress struct {
+	Total    int            `json:"total"`    // 전체 아이템 수
+	Status   map[string]int `json:"status"`   // 상태별 아이템 수
+	Complete int            `json:"complete"` // 끝난 상태인 아이템 수
+	Percent  float64        `json:"percent"`  // 끝난 아이템 비율 0~100
+}
+
+// CheckError 메소드는 ItemGroup 자료구조의 에러를 체크한다.
+func (g ItemGroup) CheckError() error {
+	if g.Project == "" {
+		return errors.New("project를 설정해주세요")
+	}
+	if g.Kind != ItemGroupSeq && g.Kind != ItemGroupEpisode {
+		return fmt.Errorf("%s 는 시퀀스, 에피소드 종류가 아닙니다", g.Kind)
+	}
+	if g.Name == "" {
+		return errors.New("name을 설정해주세요")
+	}
+	if strings.ContainsAny(g.Name, " \t\n") {
+		return errors.New("name에 공백을 사용할 수 없습니다")
+	}
+	return nil
+}
+
+// itemGroupKind 함수는 API 경로에서 그룹 종류를 구한다. 예) /api/addsequence -> seq, /api/episodes -> episode
+func itemGroupKind(path string) string {
+	if strings.Contains(path, "episode") {
+		return ItemGroupEpisode
+	}
+	return ItemGroupSeq
+}
+
+// itemGroupProgress 함수는 아이템 리스트의 상태를 모아서 진행상황을 계산한다. complete는 끝난 상태로 취급하는 Status ID 맵이다.
+func itemGroupProgress(items []Item, complete map[string]bool) ItemGroupProgress {
+	p := ItemGroupProgress{Status: make(map[string]int)}
+	for _, i := range items {
+		p.Total++
+		p.Status[i.StatusV2]++
+		if complete[i.StatusV2] {
+			p.Complete++
+		}
+	}
+	if p.Total != 0 {
+		p.Percent = float64(p.Complete) / float64(p.Total) * 100
+	}
+	return p
+}
+
+// CanEdit 메소드는 사용자가 시퀀스, 에피소드를 수정할 수 있는지 체크한다. 담당 슈퍼바이저와 Lead 이상 권한이 수정할 수 있다.
+func (g ItemGroup) CanEdit(userID string, level AccessLevel) bool {
+	return g.Supervisor == userID || level >= LeadAccessLevel
+}
+
+// itemGroupStatusQuery 함수는 seqstatus:, episodestatus: 검색어의 아이템 쿼리를 만든다.
+// names가 nil이면 $in 쿼리를 MongoDB가 거부하여 검색 전체가 실패하므로 아무것도 찾지 않는 빈 리스트를 사용한다.
+func itemGroupStatusQuery(kind string, names []string) bson.M {
+	if names == nil {
+		names = []string{}
+	}
+	return bson.M{kind: bson.M{"$in": names}}
+}
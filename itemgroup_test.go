@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestItemGroupKind(t *testing.T) {
+	cases := []struct {
+		path string
+		want string
+	}{{
+		path: "/api/addsequence",
+		want: ItemGroupSeq,
+	}, {
+		path: "/api/sequences",
+		want: ItemGroupSeq,
+	}, {
+		path: "/api/addepisodecomment",
+		want: ItemGroupEpisode,
+	}}
+	for _, c := range cases {
+		got := itemGroupKind(c.path)
+		if got != c.want {
+			t.Fatalf("TestItemGroupKind(%v): 얻은 값 %v, 원하는 값 %v", c.path, got, c.want)
+		}
+	}
+}
+
+func TestItemGroupCheckError(t *testing.T) {
+	cases := []struct {
+		group ItemGroup
+		want  bool // 에러 여부
+	}{{
+		group: ItemGroup{Project: "TEMP", Kind: ItemGroupSeq, Name: "SS"},
+		want:  false,
+	}, {
+		group: ItemGroup{Project: "TEMP", Kind: "shot", Name: "SS"},
+		want:  true,
+	}, {
+		group: ItemGroup{Project: "TEMP", Kind: ItemGroupEpisode, Name: "E 01"},
+		want:  true,
+	}, {
+		group: ItemGroup{Kind: ItemGroupEpisode, Name: "E01"},
+		want:  true,
+	}}
+	for _, c := range cases {
+		got := c.group.CheckError() != nil
+		if got != c.want {
+			t.Fatalf("TestItemGroupCheckError(%v): 얻은 값 %v, 원하는 값 %v", c.group, got, c.want)
+		}
+	}
+}
+
+func TestItemGroupProgress(t *testing.T) {
+	complete := map[string]bool{"done": true, "omit": true}
+	items := []Item{{StatusV2: "wip"}, {StatusV2: "done"}, {StatusV2: "omit"}, {StatusV2: "wip"}}
+	got := itemGroupProgress(items, complete)
+	if got.Total != 4 || got.Complete != 2 || got.Percent != 50 || got.Status["wip"] != 2 {
+		t.Fatalf("TestItemGroupProgress(%v): 얻은 값 %v, 원하는 값 %v", items, got, "total 4, complete 2, percent 50, wip 2")
+	}
+	empty := itemGroupProgress(nil, complete)
+	if empty.Total != 0 || empty.Percent != 0 {
+		t.Fatalf("TestItemGroupProgress(%v): 얻은 값 %v, 원하는 값 %v", nil, empty, "total 0, percent 0")
+	}
+}
+
+func TestItemGroupStatusQuery(t *testing.T) {
+	cases := []struct {
+		kind  string
+		names []string
+		want  string
+	}{
+		{kind: ItemGroupSeq, names: []string{"SS", "OPN"}, want: `map[seq:map[$in:[SS OPN]]]`},
+		{kind: ItemGroupEpisode, names: []string{}, want: `map[episode:map[$in:[]]]`},
+		{kind: ItemGroupSeq, names: nil, want: `map[seq:map[$in:[]]]`}, // 이름을 구하지 못하면 아무것도 찾지 않는다.
+	}
+	for _, c := range cases {
+		// DB에 전달되는 형태로 확인한다. nil 리스트는 null로 저장된다.
+		data, err := bson.Marshal(itemGroupStatusQuery(c.kind, c.names))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var doc bson.M
+		err = bson.Unmarshal(data, &doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := fmt.Sprint(doc)
+		if got != c.want {
+			t.Fatalf("TestItemGroupStatusQuery(%v, %v): 얻은 값 %v, 원하는 값 %v", c.kind, c.names, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ItemHistory 자료구조는 아이템 필드가 바뀐 기록이다.
+type ItemHistory struct {
+	ID      bson.ObjectId `json:"id" bson:"_id,omitempty"` // ID
+	Project string        `json:"project"`                 // 프로젝트
+	ItemID  string        `json:"itemid"`                  // 아이템 ID. 예) SS_0010_org
+	Field   string        `json:"field"`                   // 바뀐 필드의 DB 경로. 예) tasks.comp.statusv2. 아이템 삭제, 복원 기록은 빈 문자열이다.
+	Old     interface{}   `json:"old"`                     // 이전 값. 필드가 없었다면 nil이다.
+	New     interface{}   `json:"new"`                     // 바뀐 값. 필드가 제거되었다면 nil이다.
+	Author  string        `json:"author"`                  // 변경한 사용자 ID
+	Time    string        `json:"time"`                    // 변경시간 RFC3339
+	Source  string        `json:"source"`                  // 변경 출처. 예) SetDeadline2D, review approve: 5f87f82641a789486f3970d1
+}
+
+// itemHistoryIgnoreFields 는 변경 기록을 남기지 않는 아이템 최상위 필드이다. 모든 변경마다 바뀌는 값이다.
+var itemHistoryIgnoreFields = map[string]bool{
+	"_id":        true,
+	"updatetime": true,
+}
+
+// diffItemDocuments 함수는 변경 전후의 아이템 문서를 비교해서 바뀐 필드 리스트를 반환한다.
+// 하위 문서는 필드 단위로 비교하고, 리스트는 하나의 값으로 비교한다. 필드 경로는 정렬된 순서로 반환한다.
+func diffItemDocuments(prefix string, before, after bson.M) []ItemHistory {
+	keys := make(map[string]bool)
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	var sorted []string
+	for k := range keys {
+		if prefix == "" && itemHistoryIgnoreFields[k] {
+			continue
+		}
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	var changes []ItemHistory
+	for _, k := range sorted {
+		field := k
+		if prefix != "" {
+			field = prefix + "." + k
+		}
+		o, n := before[k], after[k]
+		od, oIsDoc := o.(bson.M)
+		nd, nIsDoc := n.(bson.M)
+		if oIsDoc && nIsDoc {
+			changes = append(changes, diffItemDocuments(field, od, nd)...)
+			continue
+		}
+		if reflect.DeepEqual(o, n) {
+			continue
+		}
+		changes = append(changes, ItemHistory{Field: field, Old: o, New: n})
+	}
+	return changes
+}
+
+// setDocumentPath 함수는 문서의 필드 경로에 값을 설정한다. 값이 nil이면 필드를 제거한다.
+// 경로의 숫자는 리스트의 인덱스로 사용한다. 예) tasks.comp.publishes.main.0.status
+func setDocumentPath(doc bson.M, path string, value interface{}) error {
+	if path == "" {
+		return errors.New("필드 경로가 비어있습니다")
+	}
+	keys := strings.Split(path, ".")
+	var parent interface{} = doc
+	for i, k := range keys {
+		last := i == len(keys)-1
+		switch p := parent.(type) {
+		case bson.M:
+			if last {
+				if value == nil {
+					delete(p, k)
+				} else {
+					p[k] = value
+				}
+				return nil
+			}
+			if _, ok := p[k]; !ok {
+				if value == nil {
+					return nil // 제거할 필드의 상위 문서가 이미 없다.
+				}
+				p[k] = bson.M{}
+			}
+			parent = p[k]
+		case []interface{}:
+			n, err := strconv.Atoi(k)
+			if err != nil || n < 0 || n >= len(p) {
+				return fmt.Errorf("%s 경로의 %s 는 리스트 인덱스가 아닙니다", path, k)
+			}
+			if last {
+				p[n] = value
+				return nil
+			}
+			parent = p[n]
+		default:
+			return fmt.Errorf("%s 경로의 %s 상위 값이 문서가 아닙니다", path, k)
+		}
+	}
+	return nil
+}
+
+// revertItemDocument 함수는 현재 아이템 문서에 변경 기록을 최근 기록부터 거꾸로 적용해서 변경 전 문서를 만든다.
+// histories는 시간순으로 정렬되어 있어야 한다.
+func revertItemDocument(doc bson.M, histories []ItemHistory) error {
+	for i := len(histories) - 1; i >= 0; i-- {
+		h := histories[i]
+		if h.Field == "" {
+			continue // 삭제, 복원 기록은 복원의 시작 문서로만 사용한다.
+		}
+		err := setDocumentPath(doc, h.Field, h.Old)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestDiffItemDocuments(t *testing.T) {
+	before := bson.M{
+		"id":         "SS_0010_org",
+		"updatetime": "2020-11-01T09:00:00+09:00",
+		"justin":     1001,
+		"tag":        []interface{}{"fx"},
+		"tasks": bson.M{
+			"comp": bson.M{"statusv2": "wip", "user": "kim"},
+		},
+	}
+	after := bson.M{
+		"id":         "SS_0010_org",
+		"updatetime": "2020-11-02T09:00:00+09:00",
+		"justin":     1009,
+		"tag":        []interface{}{"fx", "crowd"},
+		"ddline2d":   "2020-12-01T19:00:00+09:00",
+		"tasks": bson.M{
+			"comp": bson.M{"statusv2": "done", "user": "kim"},
+			"fx":   bson.M{"statusv2": "wip"},
+		},
+	}
+	got := diffItemDocuments("", before, after)
+	want := []ItemHistory{
+		{Field: "ddline2d", Old: nil, New: "2020-12-01T19:00:00+09:00"},
+		{Field: "justin", Old: 1001, New: 1009},
+		{Field: "tag", Old: []interface{}{"fx"}, New: []interface{}{"fx", "crowd"}},
+		{Field: "tasks.comp.statusv2", Old: "wip", New: "done"},
+		{Field: "tasks.fx", Old: nil, New: bson.M{"statusv2": "wip"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("TestDiffItemDocuments: 얻은 값 %v, 원하는 값 %v", got, want)
+	}
+	// 변경 기록을 거꾸로 적용하면 변경 전 문서가 되어야 한다.
+	err := revertItemDocument(after, got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after["updatetime"] = before["updatetime"]
+	if !reflect.DeepEqual(after, before) {
+		t.Fatalf("TestDiffItemDocuments(revert): 얻은 값 %v, 원하는 값 %v", after, before)
+	}
+}
+
+func TestSetDocumentPath(t *testing.T) {
+	doc := bson.M{
+		"tasks": bson.M{
+			"comp": bson.M{
+				"publishes": bson.M{
+					"main": []interface{}{bson.M{"status": "usethis"}, bson.M{"status": "notuse"}},
+				},
+			},
+		},
+	}
+	cases := []struct {
+		path  string
+		value interface{}
+		err   bool
+	}{{
+		path:  "tasks.comp.publishes.main.1.status",
+		value: "usethis",
+	}, {
+		path:  "tasks.comp.publishes.main.5.status",
+		value: "usethis",
+		err:   true,
+	}, {
+		path:  "tasks.lighting.user",
+		value: "park",
+	}, {
+		path:  "tasks.comp.publishes",
+		value: nil,
+	}}
+	for _, c := range cases {
+		err := setDocumentPath(doc, c.path, c.value)
+		if (err != nil) != c.err {
+			t.Fatalf("TestSetDocumentPath(%v): 얻은 값 %v, 원하는 값 %v", c.path, err, c.err)
+		}
+	}
+	want := bson.M{
+		"tasks": bson.M{
+			"comp":     bson.M{},
+			"lighting": bson.M{"user": "park"},
+		},
+	}
+	if !reflect.DeepEqual(doc, want) {
+		t.Fatalf("TestSetDocumentPath: 얻은 값 %v, 원하는 값 %v", doc, want)
+	}
+}
+
+func TestRestoreItemFieldLevel(t *testing.T) {
+	cases := []struct {
+		field string
+		want  AccessLevel
+	}{
+		{field: "rnum", want: LeadAccessLevel},
+		{field: "finname", want: PmAccessLevel},
+		{field: "platepath", want: ArtistAccessLevel},
+		{field: "tasks.comp.statusv2", want: PmAccessLevel}, // patch 태그가 없는 필드
+	}
+	for _, c := range cases {
+		got := restoreItemFieldLevel(c.field)
+		if got != c.want {
+			t.Fatalf("TestRestoreItemFieldLevel(%v): 얻은 값 %v, 원하는 값 %v", c.field, got, c.want)
+		}
+	}
+}
+
+func TestRestoreRnumTag(t *testing.T) {
+	cases := []struct {
+		tags []string
+		rnum string
+		want []string
+	}{
+		{tags: []string{"2권", "fx"}, rnum: "C0010", want: []string{"3권", "fx"}},
+		{tags: []string{"2권", "fx"}, rnum: "", want: []string{"fx"}},
+		{tags: nil, rnum: "A0010", want: []string{"1권"}},
+	}
+	for _, c := range cases {
+		item := Item{Rnum: "B0010", Tag: c.tags}
+		restoreRnumTag(&item, c.rnum)
+		if item.Rnum != c.rnum || !reflect.DeepEqual(item.Tag, c.want) {
+			t.Fatalf("TestRestoreRnumTag(%v): 얻은 값 %v, 원하는 값 %v", c.rnum, item.Tag, c.want)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digital-idea/ditime"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// itemPatchLevels 는 patch 태그에 사용하는 권한 이름과 AccessLevel 이다.
+var itemPatchLevels = map[string]AccessLevel{
+	"guest":      GuestAccessLevel,
+	"clients":    ClientsAccessLevel,
+	"artist":     ArtistAccessLevel,
+	"lead":       LeadAccessLevel,
+	"pm":         PmAccessLevel,
+	"supervisor": SupervisorAccessLevel,
+}
+
+// ItemPatchField 자료구조는 patch 태그로 수정할 수 있는 아이템 필드 정보이다.
+type ItemPatchField struct {
+	Field string      `json:"field"` // json 필드명. 예) platein
+	Level AccessLevel `json:"level"` // 수정에 필요한 최소 권한
+	Rules []string    `json:"rules"` // 검사규칙. shot, asset, required, timecode, shottype, rnum, assettype, fulltime
+	index int         // Item 자료구조의 필드 인덱스
+	key   string      // DB 필드명
+}
+
+// ItemPatchError 는 patch 문서의 필드, 값, 권한 검사에 실패했을 때 반환하는 에러이다. DB 에러와 구분할 때 사용한다.
+type ItemPatchError struct {
+	Err error
+}
+
+func (e ItemPatchError) Error() string {
+	return e.Err.Error()
+}
+
+// itemPatchFields 는 json 필드명을 키로 하는 수정 가능한 아이템 필드 리스트이다.
+var itemPatchFields = parseItemPatchFields()
+
+// parseItemPatchFields 함수는 Item 자료구조의 patch 태그를 읽어서 수정 가능한 필드 리스트를 만든다.
+// 태그가 잘못되었다면 서버가 시작될 때 알 수 있도록 panic 한다.
+func parseItemPatchFields() map[string]ItemPatchField {
+	fields := make(map[string]ItemPatchField)
+	t := reflect.TypeOf(Item{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("patch")
+		if tag == "" {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		opts := strings.Split(tag, ",")
+		level, ok := itemPatchLevels[opts[0]]
+		if !ok {
+			panic(fmt.Sprintf("%s 필드의 patch 태그 권한 %s 가 잘못되었습니다", f.Name, opts[0]))
+		}
+		for _, rule := range opts[1:] {
+			if _, ok := itemPatchRules[rule]; !ok {
+				panic(fmt.Sprintf("%s 필드의 patch 태그 검사규칙 %s 가 잘못되었습니다", f.Name, rule))
+			}
+		}
+		fields[name] = ItemPatchField{Field: name, Level: level, Rules: opts[1:], index: i, key: strings.ToLower(f.Name)}
+	}
+	return fields
+}
+
+// itemPatchRules 는 patch 태그의 검사규칙이다. 값을 검사하고 필요하면 DB에 저장하는 형태로 바꾼 값을 반환한다.
+var itemPatchRules = map[string]func(item *Item, value interface{}) (interface{}, error){
+	"shot": func(item *Item, value interface{}) (interface{}, error) {
+		if item.Type == "asset" {
+			return nil, fmt.Errorf("%s 는 %s type 입니다. 변경할 수 없습니다", item.Name, item.Type)
+		}
+		return value, nil
+	},
+	"asset": func(item *Item, value interface{}) (interface{}, error) {
+		if item.Type != "asset" {
+			return nil, fmt.Errorf("%s 아이템은 %s 타입입니다. 처리할 수 없습니다", item.Name, item.Type)
+		}
+		return value, nil
+	},
+	"required": func(item *Item, value interface{}) (interface{}, error) {
+		if reflect.ValueOf(value).IsZero() {
+			return nil, errors.New("빈 값으로 설정할 수 없습니다")
+		}
+		return value, nil
+	},
+	"timecode": func(item *Item, value interface{}) (interface{}, error) {
+		timecode, _ := value.(string)
+		if !(regexpTimecode.MatchString(timecode) || timecode == "") {
+			return nil, fmt.Errorf("%s 문자열은 00:00:00:00 형식의 문자열이 아닙니다", timecode)
+		}
+		return value, nil
+	},
+	"shottype": func(item *Item, value interface{}) (interface{}, error) {
+		typ, _ := value.(string)
+		return value, validShottype(typ)
+	},
+	"rnum": func(item *Item, value interface{}) (interface{}, error) {
+		rnum, _ := value.(string)
+		if rnum == "" {
+			return value, nil
+		}
+		// 소문자로 입력해도 대문자로 저장한다.
+		rnum = strings.ToUpper(rnum)
+		if !regexpRnum.MatchString(rnum) {
+			return nil, fmt.Errorf("%s 롤넘버는 A0001 형식이 아닙니다", rnum)
+		}
+		return rnum, nil
+	},
+	"assettype": func(item *Item, value interface{}) (interface{}, error) {
+		typ, _ := value.(string)
+		_, err := validAssettype(typ)
+		return value, err
+	},
+	"fulltime": func(item *Item, value interface{}) (interface{}, error) {
+		date, _ := value.(string)
+		if date == "" {
+			return value, nil
+		}
+		return ditime.ToFullTime(19, date)
+	},
+}
+
+// applyItemPatch 함수는 json merge 문서를 아이템에 적용하고 바뀐 필드 리스트를 정렬해서 반환한다.
+// 모든 필드를 먼저 검사하고 하나라도 실패하면 아이템을 바꾸지 않는다. null 값은 필드를 빈 값으로 설정한다.
+func applyItemPatch(item *Item, patch map[string]json.RawMessage, level AccessLevel) ([]string, error) {
+	var keys []string
+	for k := range patch {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make(map[string]reflect.Value)
+	for _, k := range keys {
+		f, ok := itemPatchFields[k]
+		if !ok {
+			return nil, fmt.Errorf("%s 는 수정할 수 없는 필드입니다", k)
+		}
+		if f.Level > level {
+			return nil, fmt.Errorf("권한이 낮아서 %s 필드를 수정할 수 없습니다", k)
+		}
+		v := reflect.New(reflect.TypeOf(*item).Field(f.index).Type)
+		if !bytes.Equal(bytes.TrimSpace(patch[k]), []byte("null")) {
+			err := json.Unmarshal(patch[k], v.Interface())
+			if err != nil {
+				return nil, fmt.Errorf("%s 필드의 값이 잘못되었습니다: %v", k, err)
+			}
+		}
+		value := v.Elem().Interface()
+		for _, rule := range f.Rules {
+			var err error
+			value, err = itemPatchRules[rule](item, value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", k, err)
+			}
+		}
+		values[k] = reflect.ValueOf(value)
+	}
+	iv := reflect.ValueOf(item).Elem()
+	for _, k := range keys {
+		iv.Field(itemPatchFields[k].index).Set(values[k])
+		switch k {
+		case "rnum":
+			item.setRnumTag() // 롤넘버에 따른 테그 셋팅
+		case "assettype":
+			item.setAssettags()
+		}
+	}
+	item.Updatetime = time.Now().Format(time.RFC3339)
+	return keys, nil
+}
+
+// itemPatchSet 함수는 applyItemPatch 로 바뀐 필드만 DB에 저장하는 $set 문서를 만든다.
+// 롤넘버, 에셋타입이 바뀌면 같이 바뀌는 태그와 업데이트 시간도 포함한다.
+func itemPatchSet(item Item, fields []string) bson.M {
+	iv := reflect.ValueOf(item)
+	set := bson.M{"updatetime": item.Updatetime}
+	for _, k := range fields {
+		f := itemPatchFields[k]
+		set[f.key] = iv.Field(f.index).Interface()
+		switch k {
+		case "rnum":
+			set["tag"] = item.Tag
+		case "assettype":
+			set["assettags"] = item.Assettags
+		}
+	}
+	return bson.M{"$set": set}
+}
+
+// itemPatchFormValue 함수는 기존 API의 form 문자열을 필드 타입에 맞는 json 값으로 바꾼다.
+func itemPatchFormValue(field, value string) (json.RawMessage, error) {
+	f, ok := itemPatchFields[field]
+	if !ok {
+		return nil, fmt.Errorf("%s 는 수정할 수 없는 필드입니다", field)
+	}
+	var v interface{} = value
+	switch reflect.TypeOf(Item{}).Field(f.index).Type.Kind() {
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+		v = n
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, err
+		}
+		v = n
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+		v = b
+	}
+	return json.Marshal(v)
+}
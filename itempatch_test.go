@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+func TestApplyItemPatch(t *testing.T) {
+	cases := []struct {
+		item  Item
+		patch string
+		level AccessLevel
+		want  []string
+		err   bool
+	}{{
+		item:  Item{Name: "SS_0010", Type: "org"},
+		patch: `{"platein":1001, "scantimecodein":"01:00:00:00", "scanname":"A001C002"}`,
+		level: ArtistAccessLevel,
+		want:  []string{"platein", "scanname", "scantimecodein"},
+	}, {
+		item:  Item{Name: "SS_0010", Type: "org"},
+		patch: `{"scantimecodein":"01:00:00"}`,
+		level: ArtistAccessLevel,
+		err:   true,
+	}, {
+		item:  Item{Name: "SS_0010", Type: "org"},
+		patch: `{"shottype":"2d"}`,
+		level: ArtistAccessLevel, // 샷타입은 팀장 이상 수정할 수 있다.
+		err:   true,
+	}, {
+		item:  Item{Name: "SS_0010", Type: "org"},
+		patch: `{"shottype":"4d"}`,
+		level: LeadAccessLevel,
+		err:   true,
+	}, {
+		item:  Item{Name: "stone", Type: "asset"},
+		patch: `{"rnum":"A0001"}`,
+		level: LeadAccessLevel,
+		err:   true,
+	}, {
+		item:  Item{Name: "SS_0010", Type: "org"},
+		patch: `{"rnum":"a0001"}`,
+		level: LeadAccessLevel,
+		want:  []string{"rnum"},
+	}, {
+		item:  Item{Name: "SS_0010", Type: "org"},
+		patch: `{"rnum":"B12"}`,
+		level: LeadAccessLevel, // 롤넘버는 A0001 형식이어야 한다.
+		err:   true,
+	}, {
+		item:  Item{Name: "SS_0010", Type: "org"},
+		patch: `{"rnum":"ZZZ"}`,
+		level: LeadAccessLevel,
+		err:   true,
+	}, {
+		item:  Item{Name: "SS_0010", Type: "org"},
+		patch: `{"platein":"1001"}`,
+		level: ArtistAccessLevel,
+		err:   true,
+	}, {
+		item:  Item{Name: "SS_0010", Type: "org"},
+		patch: `{"project":"TEMP"}`,
+		level: AdminAccessLevel,
+		err:   true,
+	}}
+	for _, c := range cases {
+		var patch map[string]json.RawMessage
+		err := json.Unmarshal([]byte(c.patch), &patch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before := c.item
+		got, err := applyItemPatch(&c.item, patch, c.level)
+		if (err != nil) != c.err {
+			t.Fatalf("TestApplyItemPatch(%v): 얻은 값 %v, 원하는 값 %v", c.patch, err, c.err)
+		}
+		if c.err {
+			if !reflect.DeepEqual(c.item, before) {
+				t.Fatalf("TestApplyItemPatch(%v): 얻은 값 %v, 원하는 값 %v", c.patch, c.item, before)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("TestApplyItemPatch(%v): 얻은 값 %v, 원하는 값 %v", c.patch, got, c.want)
+		}
+	}
+	// 롤넘버를 설정하면 권 태그가 추가되어야 한다.
+	item := Item{Name: "SS_0010", Type: "org", Tag: []string{"fx"}}
+	_, err := applyItemPatch(&item, map[string]json.RawMessage{"rnum": json.RawMessage(`"B0010"`)}, LeadAccessLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(item.Tag, []string{"2권", "fx"}) {
+		t.Fatalf("TestApplyItemPatch(rnum): 얻은 값 %v, 원하는 값 %v", item.Tag, []string{"2권", "fx"})
+	}
+}
+
+func TestItemPatchSet(t *testing.T) {
+	// 소문자 롤넘버는 대문자로 저장되고, 바뀐 필드와 권 태그, 업데이트 시간만 $set 문서에 들어가야 한다.
+	item := Item{Name: "SS_0010", Type: "org", Tag: []string{"fx"}, Scanname: "A001C002"}
+	patch := map[string]json.RawMessage{"rnum": json.RawMessage(`"c0010"`), "scantimecodein": json.RawMessage(`"01:00:00:00"`)}
+	fields, err := applyItemPatch(&item, patch, LeadAccessLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := bson.M{"$set": bson.M{
+		"rnum":           "C0010",
+		"tag":            []string{"3권", "fx"},
+		"scantimecodein": "01:00:00:00",
+		"updatetime":     item.Updatetime,
+	}}
+	got := itemPatchSet(item, fields)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("TestItemPatchSet(%v): 얻은 값 %v, 원하는 값 %v", patch, got, want)
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // JPEG 이미지 정보를 읽기 위해 필요하다.
+	"strings"
+	"unicode/utf16"
+)
+
+// PDF 페이지 크기. A4 가로 방향이며 단위는 포인트(1/72 inch)이다.
+const (
+	pdfPageWidth  = 842.0
+	pdfPageHeight = 595.0
+)
+
+// pdfFontName 은 PDF에 사용하는 폰트이다. 한글을 표기하기 위해 PDF 뷰어가 기본으로 가지고 있는 Adobe-Korea1 CID 폰트를 사용하므로 폰트를 파일에 포함하지 않는다.
+const pdfFontName = "HYSMyeongJo-Medium"
+
+// pdfImage 자료구조는 PDF에 포함되는 JPEG 이미지이다.
+type pdfImage struct {
+	Data       []byte
+	Width      int
+	Height     int
+	ColorSpace string
+}
+
+// pdfDocument 자료구조는 텍스트와 JPEG 이미지로 이루어진 간단한 PDF 문서를 만든다.
+// 외부 라이브러리 없이 PDF 1.4 형식을 직접 작성한다.
+type pdfDocument struct {
+	pages  []*bytes.Buffer // 페이지별 컨텐츠 스트림
+	images []pdfImage      // 문서에 포함된 이미지. 이미지 이름은 /Im{index} 이다.
+}
+
+// newPDFDocument 함수는 비어있는 PDF 문서를 생성한다.
+func newPDFDocument() *pdfDocument {
+	return &pdfDocument{}
+}
+
+// AddPage 메소드는 새 페이지를 추가한다. 이후 그리기 메소드는 새 페이지에 그린다.
+func (d *pdfDocument) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// page 메소드는 현재 페이지 컨텐츠를 반환한다. 페이지가 없다면 추가한다.
+func (d *pdfDocument) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+// Text 메소드는 x, y 위치에 텍스트를 쓴다. 좌표는 페이지 왼쪽 위가 원점이고 y는 텍스트의 기준선이다.
+func (d *pdfDocument) Text(x, y, size float64, s string) {
+	fmt.Fprintf(d.page(), "BT /F1 %s Tf %s %s Td <%s> Tj ET\n", pdfNumber(size), pdfNumber(x), pdfNumber(pdfPageHeight-y), pdfHexText(s))
+}
+
+// Color 메소드는 이후 그리는 텍스트와 선의 색상을 설정한다. 값은 0~1 사이의 값이다.
+func (d *pdfDocument) Color(r, g, b float64) {
+	fmt.Fprintf(d.page(), "%s %s %s rg %s %s %s RG\n", pdfNumber(r), pdfNumber(g), pdfNumber(b), pdfNumber(r), pdfNumber(g), pdfNumber(b))
+}
+
+// Line 메소드는 두 점을 잇는 선을 그린다.
+func (d *pdfDocument) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(d.page(), "0.5 w %s %s m %s %s l S\n", pdfNumber(x1), pdfNumber(pdfPageHeight-y1), pdfNumber(x2), pdfNumber(pdfPageHeight-y2))
+}
+
+// Image 메소드는 JPEG 이미지를 x, y 위치(이미지 왼쪽 위)에 w, h 크기로 그린다.
+func (d *pdfDocument) Image(data []byte, x, y, w, h float64) error {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if format != "jpeg" {
+		return fmt.Errorf("%s 이미지는 PDF에 넣을 수 없습니다. JPEG 이미지만 사용가능합니다", format)
+	}
+	colorSpace := "DeviceRGB"
+	switch config.ColorModel {
+	case color.GrayModel:
+		colorSpace = "DeviceGray"
+	case color.CMYKModel:
+		colorSpace = "DeviceCMYK"
+	}
+	d.images = append(d.images, pdfImage{Data: data, Width: config.Width, Height: config.Height, ColorSpace: colorSpace})
+	fmt.Fprintf(d.page(), "q %s 0 0 %s %s %s cm /Im%d Do Q\n", pdfNumber(w), pdfNumber(h), pdfNumber(x), pdfNumber(pdfPageHeight-y-h), len(d.images)-1)
+	return nil
+}
+
+// Bytes 메소드는 PDF 파일 데이터를 반환한다.
+func (d *pdfDocument) Bytes() []byte {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	var buf bytes.Buffer
+	var offsets []int
+	// object 함수는 다음 오브젝트를 쓰고 오브젝트 번호를 반환한다. 오브젝트 번호는 1부터 시작한다.
+	object := func(body string, stream []byte) int {
+		offsets = append(offsets, buf.Len())
+		n := len(offsets)
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\n", n, body)
+		if stream != nil {
+			buf.WriteString("stream\n")
+			buf.Write(stream)
+			buf.WriteString("\nendstream\n")
+		}
+		buf.WriteString("endobj\n")
+		return n
+	}
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// 1: Catalog, 2: Pages 오브젝트는 페이지 번호를 알아야 하므로 번호만 예약하고 마지막에 쓴다.
+	catalog := object("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	pagesOffset := len(offsets)
+	offsets = append(offsets, 0)
+	descriptor := object(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 6 /FontBBox [-28 -148 1001 880] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 60 >>", pdfFontName), nil)
+	cidFont := object(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Korea1) /Supplement 1 >> /FontDescriptor %d 0 R /DW 1000 /W [1 95 500] >>", pdfFontName, descriptor), nil)
+	font := object(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /UniKS-UCS2-H /DescendantFonts [%d 0 R] >>", pdfFontName, cidFont), nil)
+	var xobjects []string
+	for i, img := range d.images {
+		n := object(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>", img.Width, img.Height, img.ColorSpace, len(img.Data)), img.Data)
+		xobjects = append(xobjects, fmt.Sprintf("/Im%d %d 0 R", i, n))
+	}
+	resources := fmt.Sprintf("<< /Font << /F1 %d 0 R >> /XObject << %s >> >>", font, strings.Join(xobjects, " "))
+	var kids []string
+	for _, p := range d.pages {
+		content := object(fmt.Sprintf("<< /Length %d >>", p.Len()), p.Bytes())
+		n := object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R >>", pdfNumber(pdfPageWidth), pdfNumber(pdfPageHeight), resources, content), nil)
+		kids = append(kids, fmt.Sprintf("%d 0 R", n))
+	}
+	offsets[pagesOffset] = buf.Len()
+	fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", pagesOffset+1, strings.Join(kids, " "), len(kids))
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, o := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", o)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, catalog, xref)
+	return buf.Bytes()
+}
+
+// pdfNumber 함수는 숫자를 PDF 숫자 형식으로 바꾼다.
+func pdfNumber(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// pdfHexText 함수는 문자열을 UniKS-UCS2-H 인코딩의 16진수 문자열로 바꾼다. UCS2로 표기할 수 없는 문자는 ?로 바꾼다.
+func pdfHexText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r > 0xFFFF || utf16.IsSurrogate(r) || r < 0x20 {
+			r = '?'
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	return b.String()
+}
+
+// pdfTextWidth 함수는 PDF 폰트로 쓴 문자열의 대략적인 너비를 반환한다. ASCII 문자는 글자 크기의 절반, 나머지 문자는 글자 크기만큼 차지한다.
+func pdfTextWidth(s string, size float64) float64 {
+	var w float64
+	for _, r := range s {
+		if r < 0x80 {
+			w += size / 2
+			continue
+		}
+		w += size
+	}
+	return w
+}
+
+// pdfWrapText 함수는 문자열을 width 너비에 맞게 여러 줄로 나눈다. 줄바꿈 문자는 유지한다.
+// 공백 단위로 나누고, 한 단어가 width보다 길면 글자 단위로 나눈다.
+func pdfWrapText(s string, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n") {
+		line := ""
+		for _, word := range strings.Split(paragraph, " ") {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if pdfTextWidth(candidate, size) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = ""
+			for _, r := range word {
+				if pdfTextWidth(line+string(r), size) > width && line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				line += string(r)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Plate 자료구조는 샷의 플레이트 버전 정보이다.
+// 재스캔, 소스 플레이트를 별도의 아이템으로 만들지 않고 하나의 샷 아이템 안에서 관리한다.
+type Plate struct {
+	Type            string `json:"type"`            // 플레이트 버전 타입. 예) org, org1, src1
+	Scanname        string `json:"scanname"`        // 스캔이름
+	Dataname        string `json:"dataname"`        // 데이터 이름
+	Platesize       string `json:"platesize"`       // 플레이트 이미지사이즈
+	Platepath       string `json:"platepath"`       // 플레이트 경로
+	Scantime        string `json:"scantime"`        // 스캔 등록시간 RFC3339
+	ScanFrame       int    `json:"scanframe"`       // 스캔 프레임수
+	ScanTimecodeIn  string `json:"scantimecodein"`  // 스캔플레이트 타임코드 In
+	ScanTimecodeOut string `json:"scantimecodeout"` // 스캔플레이트 타임코드 Out
+	JustTimecodeIn  string `json:"justtimecodein"`  // 저스트 타임코드 In
+	JustTimecodeOut string `json:"justtimecodeout"` // 저스트 타임코드 Out
+	ScanIn          int    `json:"scanin"`          // 스캔 Frame In
+	ScanOut         int    `json:"scanout"`         // 스캔 Frame Out
+	PlateIn         int    `json:"platein"`         // 플레이트 Frame In
+	PlateOut        int    `json:"plateout"`        // 플레이트 Frame Out
+	JustIn          int    `json:"justin"`          // 저스트 Frame In
+	JustOut         int    `json:"justout"`         // 저스트 Frame Out
+}
+
+// CheckError 메소드는 Plate 자료구조의 에러를 체크한다.
+func (p Plate) CheckError() error {
+	if p.Type == "" {
+		return errors.New("플레이트 type을 설정해주세요")
+	}
+	if strings.ContainsAny(p.Type, " \t\n") {
+		return errors.New("플레이트 type에 공백을 사용할 수 없습니다")
+	}
+	return nil
+}
+
+// plateParentType 함수는 재스캔, 소스 타입이 속하는 샷 타입을 반환한다. 예) left1 -> left, org2 -> org, src1 -> org
+func plateParentType(typ string) string {
+	if strings.HasPrefix(typ, "left") {
+		return "left"
+	}
+	return "org"
+}
+
+// CurrentPlate 메소드는 아이템에 설정된 플레이트 정보를 typ 플레이트 버전으로 반환한다.
+func (i Item) CurrentPlate(typ string) Plate {
+	return Plate{
+		Type:            typ,
+		Scanname:        i.Scanname,
+		Dataname:        i.Dataname,
+		Platesize:       i.Platesize,
+		Platepath:       i.Platepath,
+		Scantime:        i.Scantime,
+		ScanFrame:       i.ScanFrame,
+		ScanTimecodeIn:  i.ScanTimecodeIn,
+		ScanTimecodeOut: i.ScanTimecodeOut,
+		JustTimecodeIn:  i.JustTimecodeIn,
+		JustTimecodeOut: i.JustTimecodeOut,
+		ScanIn:          i.ScanIn,
+		ScanOut:         i.ScanOut,
+		PlateIn:         i.PlateIn,
+		PlateOut:        i.PlateOut,
+		JustIn:          i.JustIn,
+		JustOut:         i.JustOut,
+	}
+}
+
+// Plate 메소드는 typ 플레이트 버전을 반환한다.
+func (i Item) Plate(typ string) (Plate, bool) {
+	for _, p := range i.Plates {
+		if p.Type == typ {
+			return p, true
+		}
+	}
+	return Plate{}, false
+}
+
+// initPlates 메소드는 플레이트 버전 리스트가 비어있다면 현재 아이템의 플레이트 정보를 첫번째 버전으로 등록한다.
+func (i *Item) initPlates() {
+	if len(i.Plates) != 0 {
+		return
+	}
+	i.Plates = []Plate{i.CurrentPlate(i.Type)}
+	i.ActivePlate = i.Type
+}
+
+// syncActivePlate 메소드는 아이템에서 직접 수정된 플레이트 정보를 사용중인 플레이트 버전에 반영한다.
+func (i *Item) syncActivePlate() {
+	for n, p := range i.Plates {
+		if p.Type == i.ActivePlate {
+			i.Plates[n] = i.CurrentPlate(p.Type)
+			return
+		}
+	}
+}
+
+// ResolveActivePlate 메소드는 사용중인 플레이트 버전의 정보를 아이템의 플레이트 정보로 설정한다.
+// 기존 기능(샷 정보, 검색, 엑셀)은 아이템의 플레이트 정보를 사용하므로 사용중인 플레이트 버전을 따르게 된다.
+func (i *Item) ResolveActivePlate() {
+	p, ok := i.Plate(i.ActivePlate)
+	if !ok {
+		return
+	}
+	i.Scanname = p.Scanname
+	i.Dataname = p.Dataname
+	i.Platesize = p.Platesize
+	i.Platepath = p.Platepath
+	i.Scantime = p.Scantime
+	i.ScanFrame = p.ScanFrame
+	i.ScanTimecodeIn = p.ScanTimecodeIn
+	i.ScanTimecodeOut = p.ScanTimecodeOut
+	i.JustTimecodeIn = p.JustTimecodeIn
+	i.JustTimecodeOut = p.JustTimecodeOut
+	i.ScanIn = p.ScanIn
+	i.ScanOut = p.ScanOut
+	i.PlateIn = p.PlateIn
+	i.PlateOut = p.PlateOut
+	i.JustIn = p.JustIn
+	i.JustOut = p.JustOut
+	i.UseType = p.Type // legacy
+}
+
+// AddPlate 메소드는 플레이트 버전을 추가한다. 같은 타입의 버전이 있다면 교체한다.
+func (i *Item) AddPlate(p Plate) error {
+	err := p.CheckError()
+	if err != nil {
+		return err
+	}
+	i.initPlates()
+	i.syncActivePlate()
+	for n, old := range i.Plates {
+		if old.Type == p.Type {
+			i.Plates[n] = p
+			if p.Type == i.ActivePlate {
+				i.ResolveActivePlate()
+			}
+			return nil
+		}
+	}
+	i.Plates = append(i.Plates, p)
+	return nil
+}
+
+// SetActivePlate 메소드는 사용할 플레이트 버전을 설정한다.
+func (i *Item) SetActivePlate(typ string) error {
+	i.initPlates()
+	if _, ok := i.Plate(typ); !ok {
+		return fmt.Errorf("%s 에 %s 플레이트가 존재하지 않습니다", i.ID, typ)
+	}
+	i.syncActivePlate()
+	i.ActivePlate = typ
+	i.ResolveActivePlate()
+	return nil
+}
+
+// RmPlate 메소드는 플레이트 버전을 삭제한다. 사용중인 플레이트 버전은 삭제할 수 없다.
+func (i *Item) RmPlate(typ string) error {
+	if typ == i.ActivePlate {
+		return fmt.Errorf("%s 플레이트는 사용중이라 삭제할 수 없습니다", typ)
+	}
+	plates := []Plate{}
+	for _, p := range i.Plates {
+		if p.Type == typ {
+			continue
+		}
+		plates = append(plates, p)
+	}
+	if len(plates) == len(i.Plates) {
+		return fmt.Errorf("%s 에 %s 플레이트가 존재하지 않습니다", i.ID, typ)
+	}
+	i.Plates = plates
+	return nil
+}
+
+// migratePlateBlockers 함수는 재스캔 아이템을 플레이트 버전으로 옮기면 잃게 되는 데이터를 이유 리스트로 반환한다.
+// 플레이트 버전으로 옮길 때는 플레이트와 코멘트만 원본 샷으로 옮겨지기 때문이다.
+func migratePlateBlockers(i Item) []string {
+	var reasons []string
+	var tasks []string
+	for name := range i.Tasks {
+		tasks = append(tasks, name)
+	}
+	sort.Strings(tasks)
+	for _, name := range tasks {
+		t := i.Tasks[name]
+		if len(t.Users()) != 0 {
+			reasons = append(reasons, name+" Task에 아티스트가 배정되어 있습니다")
+		}
+		if len(t.Publishes) != 0 {
+			reasons = append(reasons, name+" Task에 퍼블리쉬가 있습니다")
+		}
+	}
+	if len(i.Dependencies) != 0 {
+		reasons = append(reasons, "의존관계가 있습니다")
+	}
+	if len(i.Breakdown) != 0 {
+		reasons = append(reasons, "브레이크다운이 있습니다")
+	}
+	return reasons
+}
+
+// newPlateComments 함수는 comments 중 parent에 없는 코멘트만 반환한다. 같은 코멘트를 두번 옮기지 않을 때 사용한다.
+func newPlateComments(parent, comments []Comment) []Comment {
+	var results []Comment
+	for _, c := range comments {
+		exist := false
+		for _, p := range parent {
+			if p.Key() == c.Key() && p.Author == c.Author && p.Text == c.Text {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			results = append(results, c)
+		}
+	}
+	return results
+}
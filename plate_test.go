@@ -0,0 +1,89 @@
+package main
+
+import "testing"
+
+func TestRegexpRescanType(t *testing.T) {
+	cases := []struct {
+		typ  string
+		want bool
+	}{
+		{typ: "org", want: false},
+		{typ: "left", want: false},
+		{typ: "org1", want: true},
+		{typ: "left12", want: true},
+		{typ: "src", want: true},
+		{typ: "src2", want: true},
+		{typ: "lsrc", want: false},
+		{typ: "asset", want: false},
+	}
+	for _, c := range cases {
+		got := regexpRescanType.MatchString(c.typ)
+		if got != c.want {
+			t.Fatalf("TestRegexpRescanType(%v): 얻은 값 %v, 원하는 값 %v", c.typ, got, c.want)
+		}
+	}
+}
+
+func TestActivePlate(t *testing.T) {
+	i := Item{ID: "SS_0010_org", Type: "org", Scanname: "A001", PlateIn: 1001, PlateOut: 1100}
+	err := i.AddPlate(Plate{Type: "org1", Scanname: "A002", PlateIn: 1001, PlateOut: 1120})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(i.Plates) != 2 || i.ActivePlate != "org" || i.Scanname != "A001" {
+		t.Fatalf("TestActivePlate(%v): 얻은 값 %v, 원하는 값 %v", "AddPlate", i.Plates, "org, org1 플레이트와 사용중인 org")
+	}
+	// 아이템에서 직접 수정한 값은 다른 플레이트로 바꿀 때 이전 플레이트 버전에 남아야 한다.
+	i.PlateOut = 1110
+	err = i.SetActivePlate("org1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i.Scanname != "A002" || i.PlateOut != 1120 || i.UseType != "org1" {
+		t.Fatalf("TestActivePlate(%v): 얻은 값 %v, 원하는 값 %v", "SetActivePlate", i.CurrentPlate(i.ActivePlate), "A002, 1120")
+	}
+	org, _ := i.Plate("org")
+	if org.PlateOut != 1110 {
+		t.Fatalf("TestActivePlate(%v): 얻은 값 %v, 원하는 값 %v", "syncActivePlate", org.PlateOut, 1110)
+	}
+	if i.RmPlate("org1") == nil {
+		t.Fatalf("TestActivePlate(%v): 얻은 값 %v, 원하는 값 %v", "RmPlate", nil, "사용중인 플레이트 삭제 에러")
+	}
+	err = i.RmPlate("org")
+	if err != nil || len(i.Plates) != 1 {
+		t.Fatalf("TestActivePlate(%v): 얻은 값 %v, 원하는 값 %v", "RmPlate", i.Plates, "org1 플레이트")
+	}
+	if i.SetActivePlate("src1") == nil {
+		t.Fatalf("TestActivePlate(%v): 얻은 값 %v, 원하는 값 %v", "SetActivePlate", nil, "없는 플레이트 에러")
+	}
+}
+
+func TestMigratePlateBlockers(t *testing.T) {
+	cases := []struct {
+		item Item
+		want int // 이유 개수
+	}{
+		{item: Item{ID: "SS_0010_org1", Tasks: map[string]Task{"comp": {StatusV2: "none"}}}, want: 0},
+		{item: Item{ID: "SS_0010_org1", Tasks: map[string]Task{"comp": {User: "kim"}}}, want: 1},
+		{item: Item{ID: "SS_0010_org1", Tasks: map[string]Task{"comp": {User: "kim", Publishes: map[string][]Publish{"main": {{Path: "/show/a.exr"}}}}}}, want: 2},
+		{item: Item{ID: "SS_0010_org1", Dependencies: []Dependency{{Upstream: "SS_0020_org"}}}, want: 1},
+	}
+	for _, c := range cases {
+		got := migratePlateBlockers(c.item)
+		if len(got) != c.want {
+			t.Fatalf("TestMigratePlateBlockers(%v): 얻은 값 %v, 원하는 값 %v", c.item, got, c.want)
+		}
+	}
+}
+
+func TestNewPlateComments(t *testing.T) {
+	parent := []Comment{{Date: "2020-11-02T09:00:00+09:00", Author: "kim", Text: "edge"}}
+	comments := []Comment{
+		{Date: "2020-11-02T09:00:00+09:00", Author: "kim", Text: "edge"}, // 이미 옮겨진 코멘트
+		{Date: "2020-11-03T09:00:00+09:00", Author: "lee", Text: "grain"},
+	}
+	got := newPlateComments(parent, comments)
+	if len(got) != 1 || got[0].Text != "grain" {
+		t.Fatalf("TestNewPlateComments(%v): 얻은 값 %v, 원하는 값 %v", comments, got, "grain")
+	}
+}
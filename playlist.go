@@ -0,0 +1,154 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Playlist 자료구조는 데일리 세션에서 순서대로 볼 리뷰 목록이다.
+type Playlist struct {
+	ID         bson.ObjectId   `json:"id" bson:"_id,omitempty"` // ID
+	Project    string          `json:"project"`                 // 프로젝트
+	Title      string          `json:"title"`                   // 플레이리스트 제목
+	Owner      string          `json:"owner"`                   // 플레이리스트를 만든 사용자 ID
+	Date       string          `json:"date"`                    // 데일리 세션 날짜 2006-01-02
+	Attendees  []string        `json:"attendees"`               // 참석자 ID 리스트
+	Reviews    []string        `json:"reviews"`                 // 리뷰 ID 리스트. 리스트 순서가 재생 순서이다.
+	Createtime string          `json:"createtime"`              // 생성시간 RFC3339
+	Updatetime string          `json:"updatetime"`              // 업데이트 시간 RFC3339
+	Session    PlaylistSession `json:"session"`                 // 데일리 세션의 동기화 상태
+}
+
+// PlaylistSession 자료구조는 데일리 세션에 참석한 모든 사람이 같은 리뷰를 보기 위한 동기화 상태이다.
+// 클라이언트는 Revision 값이 바뀌었을 때 현재 리뷰와 프레임을 다시 맞춘다.
+type PlaylistSession struct {
+	Active     bool   `json:"active"`     // 세션 진행 여부
+	Host       string `json:"host"`       // 마지막으로 세션 상태를 바꾼 사용자 ID
+	Current    string `json:"current"`    // 현재 보고 있는 리뷰 ID
+	Frame      int    `json:"frame"`      // 현재 보고 있는 프레임
+	Playing    bool   `json:"playing"`    // 재생 여부
+	Revision   int    `json:"revision"`   // 세션 상태가 바뀔 때마다 1씩 증가한다.
+	Updatetime string `json:"updatetime"` // 세션 상태가 바뀐 시간 RFC3339
+}
+
+// regexpPlaylistDate 는 플레이리스트 날짜 형식이다.
+var regexpPlaylistDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// CheckError 메소드는 Playlist 자료구조의 에러를 체크한다.
+func (p Playlist) CheckError() error {
+	if p.Project == "" {
+		return errors.New("project를 설정해주세요")
+	}
+	if p.Title == "" {
+		return errors.New("title을 설정해주세요")
+	}
+	if !regexpPlaylistDate.MatchString(p.Date) {
+		return errors.New("date는 2006-01-02 형식이어야 합니다")
+	}
+	for _, id := range p.Reviews {
+		if !bson.IsObjectIdHex(id) {
+			return fmt.Errorf("%s 는 리뷰 ID 형식이 아닙니다", id)
+		}
+	}
+	if hasDuplicate(p.Reviews) {
+		return errors.New("플레이리스트에 같은 리뷰가 중복되어 있습니다")
+	}
+	return nil
+}
+
+// HasReview 메소드는 플레이리스트에 리뷰가 포함되어 있는지 체크한다.
+func (p Playlist) HasReview(id string) bool {
+	for _, r := range p.Reviews {
+		if r == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CanEdit 메소드는 사용자가 플레이리스트를 수정할 수 있는지 체크한다. 만든 사람과 팀장 이상의 권한을 가진 사용자가 수정할 수 있다.
+func (p Playlist) CanEdit(userID string, level AccessLevel) bool {
+	return p.Owner == userID || level >= LeadAccessLevel
+}
+
+// CanControl 메소드는 사용자가 데일리 세션을 진행할 수 있는지 체크한다. 수정 권한이 있는 사용자와 참석자가 진행할 수 있다.
+func (p Playlist) CanControl(userID string, level AccessLevel) bool {
+	if p.CanEdit(userID, level) {
+		return true
+	}
+	for _, a := range p.Attendees {
+		if a == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDuplicate 함수는 리스트에 중복된 값이 있는지 체크한다.
+func hasDuplicate(list []string) bool {
+	keys := make(map[string]bool)
+	for _, v := range list {
+		if keys[v] {
+			return true
+		}
+		keys[v] = true
+	}
+	return false
+}
+
+// reorderList 함수는 현재 리스트와 새로운 순서를 받아서 새로운 순서가 같은 항목으로만 이루어져 있는지 체크하고 새로운 순서를 반환한다.
+func reorderList(current, order []string) ([]string, error) {
+	if len(current) != len(order) {
+		return nil, fmt.Errorf("리뷰 개수가 다릅니다. 현재 %d개, 입력 %d개", len(current), len(order))
+	}
+	keys := make(map[string]bool)
+	for _, v := range current {
+		keys[v] = true
+	}
+	for _, v := range order {
+		if !keys[v] {
+			return nil, fmt.Errorf("%s 리뷰는 플레이리스트에 없거나 중복되었습니다", v)
+		}
+		delete(keys, v)
+	}
+	return order, nil
+}
+
+// insertList 함수는 리스트의 index 위치에 값을 추가한다. index가 범위를 벗어나면 마지막에 추가한다.
+func insertList(list []string, value string, index int) []string {
+	if index < 0 || index >= len(list) {
+		return append(list, value)
+	}
+	result := append([]string{}, list[:index]...)
+	result = append(result, value)
+	return append(result, list[index:]...)
+}
+
+// commaList 함수는 콤마로 구분된 문자열을 공백을 제거한 리스트로 바꾼다. 빈 항목은 제외한다.
+func commaList(s string) []string {
+	var results []string
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		results = append(results, v)
+	}
+	return results
+}
+
+// removeList 함수는 리스트에서 값을 제거한 새로운 리스트를 반환한다.
+func removeList(list []string, value string) []string {
+	results := []string{}
+	for _, v := range list {
+		if v == value {
+			continue
+		}
+		results = append(results, v)
+	}
+	return results
+}
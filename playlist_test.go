@@ -0,0 +1,67 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReorderList(t *testing.T) {
+	current := []string{"a", "b", "c"}
+	cases := []struct {
+		order []string
+		want  []string
+		err   bool
+	}{{
+		order: []string{"c", "a", "b"},
+		want:  []string{"c", "a", "b"},
+	}, {
+		order: []string{"a", "b"},
+		err:   true,
+	}, {
+		order: []string{"a", "a", "b"},
+		err:   true,
+	}, {
+		order: []string{"a", "b", "d"},
+		err:   true,
+	}}
+	for _, c := range cases {
+		got, err := reorderList(current, c.order)
+		if c.err {
+			if err == nil {
+				t.Fatalf("TestReorderList(%v): 에러가 발생해야 합니다", c.order)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("TestReorderList(%v): 얻은 값 %v, 원하는 값 %v", c.order, got, c.want)
+		}
+	}
+}
+
+func TestInsertList(t *testing.T) {
+	cases := []struct {
+		index int
+		want  []string
+	}{{
+		index: 0,
+		want:  []string{"x", "a", "b"},
+	}, {
+		index: 1,
+		want:  []string{"a", "x", "b"},
+	}, {
+		index: -1,
+		want:  []string{"a", "b", "x"},
+	}, {
+		index: 5,
+		want:  []string{"a", "b", "x"},
+	}}
+	for _, c := range cases {
+		got := insertList([]string{"a", "b"}, "x", c.index)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("TestInsertList(%v): 얻은 값 %v, 원하는 값 %v", c.index, got, c.want)
+		}
+	}
+}
@@ -1,219 +1,428 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/alfg/mp4"
 	"github.com/amarburg/go-quicktime"
-	"go.mongodb.org/mongo-driver/mongo"
 	"gopkg.in/mgo.v2"
 )
 
 // ProcessMain 함수는 CSI가 실행되면서 처리될 프로세싱을 진행한다.
 func ProcessMain() {
-	// 버퍼 채널을 만든다.
-	jobs := make(chan Review, *flagProcessBufferSize) // 작업을 대기할 버퍼를 만든다.
+	// 서버가 비정상 종료되어 연산중으로 남아있는 작업을 복구한다.
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	err = recoverReviewJobs(session)
+	if err != nil {
+		log.Println(err)
+	}
+	session.Close()
 	// worker 프로세스를 지정한 개수만큼 실행시킨다.
 	for w := 1; w <= *flagMaxProcessNum; w++ {
-		go worker(jobs)
+		go worker()
 	}
-	// queueingItem을 실행시킨다.
-	go queueingItem(jobs)
-
-	// ProcessMain()이 종료되지 않는 역할을 한다.
-	select {}
+	// scheduler가 ProcessMain()이 종료되지 않는 역할을 한다.
+	scheduler()
 }
 
-// worker 합수는 Review 데이터를 jobs로 보낸다.
-func worker(jobs <-chan Review) {
-	for job := range jobs {
-		// job은 리뷰타입이다.
-		switch job.Type {
-		case "image":
-			processingReviewImageItem(job)
-		default:
-			processingReviewClipItem(job)
+// scheduler 함수는 wait 상태의 리뷰를 큐에 등록하고, 재시도 시간이 된 작업이 있으면 worker를 깨운다.
+func scheduler() {
+	for {
+		wakeup := reviewQueue.Wakeup()
+		wait := time.Minute
+		session, err := mgo.Dial(*flagDBIP)
+		if err != nil {
+			log.Println(err)
+		} else {
+			n, err := enqueueWaitReviews(session)
+			if err != nil {
+				log.Println(err)
+			}
+			if n > 0 {
+				reviewQueue.Notify()
+			}
+			next, err := nextReviewJobRetrytime(session)
+			if err == nil && time.Until(next) < wait {
+				wait = time.Until(next)
+			}
+			session.Close()
+		}
+		if wait < time.Second {
+			wait = time.Second
+		}
+		select {
+		case <-wakeup:
+		case <-time.After(wait):
+			reviewQueue.Notify()
 		}
 	}
 }
 
-// queueingItem 은 연산할 Review 아이템을 jobs 채널에 전송한다.
-func queueingItem(jobs chan<- Review) {
+// worker 함수는 큐에서 작업을 가지고 와서 연산한다. 작업이 없으면 새로운 작업이 등록될 때까지 기다린다.
+func worker() {
 	for {
-		if *flagDebug {
-			fmt.Println("10 sec")
+		wakeup := reviewQueue.Wakeup()
+		session, err := mgo.Dial(*flagDBIP)
+		if err != nil {
+			log.Println(err)
+			time.Sleep(time.Second * 10)
+			continue
 		}
-		time.Sleep(time.Second * 10)
-		// ProcessStatus가 wait인 item을 가져온다.
-		review, err := GetWaitProcessStatusReview() // 이 함수로 반환되는 아이템은 리뷰 아이템은 상태가 queued가 된 리뷰 아이템이다.
+		job, err := claimReviewJob(session)
 		if err != nil {
-			// 가지고 올 문서가 없다면 기다렸다가 continue.
-			if err == mongo.ErrNoDocuments {
-				if *flagDebug {
-					log.Println(err)
-				}
-				continue
+			session.Close()
+			if err != mgo.ErrNotFound {
+				log.Println(err)
+			}
+			select {
+			case <-wakeup:
+			case <-time.After(time.Minute):
 			}
 			continue
 		}
 		if *flagDebug {
-			fmt.Println(review)
+			log.Printf("review job: %s (attempt %d/%d)\n", job.ID, job.Attempts, job.MaxAttempts)
 		}
-		jobs <- review
+		processReviewJob(session, job)
+		session.Close()
 	}
 }
 
-func processingReviewClipItem(review Review) {
-	session, err := mgo.Dial(*flagDBIP)
+// processReviewJob 함수는 작업에 해당하는 리뷰를 연산하고 결과에 따라 작업상태를 바꾼다.
+func processReviewJob(session *mgo.Session, job ReviewJob) {
+	review, err := getReview(session, job.ID)
 	if err != nil {
-		log.Println(err)
+		// 리뷰가 삭제되었다면 다시 시도할 필요가 없다.
+		job.Attempts = job.MaxAttempts
+		_, err = retryReviewJob(session, job, err.Error())
+		if err != nil {
+			log.Println(err)
+		}
 		return
 	}
-	defer session.Close()
-	reviewID := review.ID.Hex()
-	// 연산 상태를 queued 으로 바꾼다. 바꾸는 이유는 ffmpeg 연산이 10초이상 진행될 때 상태가 바뀌지 않아서 이전에 연산중인 데이터가 다시 연산될 수 있기 때문이다.
-	err = setReviewProcessStatus(session, reviewID, "processing")
+	// job은 리뷰타입이다.
+	switch review.Type {
+	case "image":
+		err = processingReviewImageItem(session, review)
+	case "sequence":
+		err = processingReviewSequenceItem(session, review)
+	case "compare":
+		err = processingReviewCompareItem(session, review)
+	default:
+		err = processingReviewClipItem(session, review)
+	}
 	if err != nil {
-		err = setErrReview(session, reviewID, err.Error())
-		if err != nil {
-			log.Println(err)
+		failed, e := retryReviewJob(session, job, err.Error())
+		if e != nil {
+			log.Println(e)
+		}
+		if failed {
+			e = setErrReview(session, job.ID, err.Error())
+		} else {
+			e = setReviewProcessStatus(session, job.ID, "queued")
+		}
+		if e != nil {
+			log.Println(e)
 		}
 		return
 	}
+	err = doneReviewJob(session, job.ID)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func processingReviewClipItem(session *mgo.Session, review Review) error {
+	reviewID := review.ID.Hex()
+	err := setReviewProcessStatus(session, reviewID, "processing")
+	if err != nil {
+		return err
+	}
 	// ffmpeg 경로를 체크한다.
 	if _, err := os.Stat(CachedAdminSetting.FFmpeg); os.IsNotExist(err) {
-		err = setErrReview(session, reviewID, "ffmpeg가 존재하지 않습니다")
-		if err != nil {
-			log.Println(err)
-		}
-		return
+		return errors.New("ffmpeg가 존재하지 않습니다")
 	}
 	// ReviewDataPath가 존재하는지 경로를 체크한다.
 	if _, err := os.Stat(CachedAdminSetting.ReviewDataPath); os.IsNotExist(err) {
-		err = setErrReview(session, reviewID, "admin 셋팅에 ReviewDataPath가 존재하지 않습니다")
-		if err != nil {
-			log.Println(err)
-		}
-		return
+		return errors.New("admin 셋팅에 ReviewDataPath가 존재하지 않습니다")
 	}
 	// review데이터가 atom 구조를 같는지 체크한다.
 	err = checkQuicktimeFileStruct(review)
 	if err != nil {
-		err = setErrReview(session, reviewID, err.Error())
+		return err
+	}
+	// 리뷰 동영상을 생성한다.
+	err = renderReviewMedia(session, review, FFmpegOptions{Input: review.Path})
+	if err != nil {
+		return err
+	}
+	// 연산이 끝나고 해당 파일을 삭제해야 한다면 삭제를 진행한다.
+	if review.RemoveAfterProcess {
+		err = os.Remove(review.Path)
 		if err != nil {
-			log.Println(err)
+			return err
 		}
-		return
 	}
-	// mp4를 생성한다.
-	err = genMp4(CachedAdminSetting, review)
+	// 연산 상태를 done 으로 바꾼다.
+	return setReviewProcessStatus(session, reviewID, "done")
+}
+
+func processingReviewSequenceItem(session *mgo.Session, review Review) error {
+	reviewID := review.ID.Hex()
+	err := setReviewProcessStatus(session, reviewID, "processing")
 	if err != nil {
-		err = setErrReview(session, reviewID, err.Error())
+		return err
+	}
+	// ffmpeg 경로를 체크한다.
+	if _, err := os.Stat(CachedAdminSetting.FFmpeg); os.IsNotExist(err) {
+		return errors.New("ffmpeg가 존재하지 않습니다")
+	}
+	// ReviewDataPath가 존재하는지 경로를 체크한다.
+	if _, err := os.Stat(CachedAdminSetting.ReviewDataPath); os.IsNotExist(err) {
+		return errors.New("admin 셋팅에 ReviewDataPath가 존재하지 않습니다")
+	}
+	// 빠진 프레임은 연산을 실패시키지 않고 리뷰 로그에 기록한다.
+	missing := missingFrames(review.Path, review.FrameIn, review.FrameOut)
+	reviewLog := ""
+	if len(missing) > 0 {
+		reviewLog = fmt.Sprintf("missing frames(%d): %s", len(missing), framesToString(missing))
+	}
+	err = setReviewLog(session, reviewID, reviewLog)
+	if err != nil {
+		return err
+	}
+	// 빠진 프레임을 앞 프레임으로 채운 연속된 시퀀스를 만든다.
+	dir, err := ioutil.TempDir("", "csi-review-seq")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	pattern, err := linkSeqFrames(review.Path, review.FrameIn, review.FrameOut, dir)
+	if err != nil {
+		return err
+	}
+	fps := review.Fps
+	if fps <= 0 {
+		fps = 24
+	}
+	// 리뷰 동영상을 생성한다.
+	err = renderReviewMedia(session, review, FFmpegOptions{
+		Input:     pattern,
+		InputArgs: []string{"-framerate", strconv.FormatFloat(fps, 'f', -1, 64), "-start_number", "0"},
+	})
+	if err != nil {
+		return err
+	}
+	// 연산 상태를 done 으로 바꾼다.
+	return setReviewProcessStatus(session, reviewID, "done")
+}
+
+// processingReviewCompareItem 함수는 Playlist에 저장된 원본 리뷰 동영상을 합성해서 비교 리뷰 동영상을 만든다.
+// 원본 리뷰 동영상에 이미 컬러 변환이 적용되어 있으므로 컬러 변환과 슬레이트 버전은 생성하지 않는다.
+func processingReviewCompareItem(session *mgo.Session, review Review) error {
+	reviewID := review.ID.Hex()
+	err := setReviewProcessStatus(session, reviewID, "processing")
+	if err != nil {
+		return err
+	}
+	// ffmpeg 경로를 체크한다.
+	if _, err := os.Stat(CachedAdminSetting.FFmpeg); os.IsNotExist(err) {
+		return errors.New("ffmpeg가 존재하지 않습니다")
+	}
+	// ReviewDataPath가 존재하는지 경로를 체크한다.
+	if _, err := os.Stat(CachedAdminSetting.ReviewDataPath); os.IsNotExist(err) {
+		return errors.New("admin 셋팅에 ReviewDataPath가 존재하지 않습니다")
+	}
+	p, err := getProject(session, review.Project)
+	if err != nil {
+		return err
+	}
+	var sources []Review
+	var inputs []string
+	var labels []string
+	for _, id := range review.Playlist {
+		s, err := getReview(session, id)
 		if err != nil {
-			log.Println(err)
+			return fmt.Errorf("%s 원본 리뷰를 가지고 올 수 없습니다: %v", id, err)
 		}
-		return
+		sources = append(sources, s)
+		inputs = append(inputs, reviewDataFile(CachedAdminSetting, id, s.Ext, false))
+		labels = append(labels, fmt.Sprintf("%s_%d", versionString(p, s.MainVersion), s.SubVersion))
 	}
-	// 생성된 .mp4 파일이 mp4 자료구조를 같는지 체크한다.
-	err = checkMp4FileStruct(CachedAdminSetting, review)
+	err = checkCompareSources(review.CompareMode, sources)
+	if err != nil {
+		return err
+	}
+	profile, err := reviewTranscodeProfile(session, p)
 	if err != nil {
-		err = setErrReview(session, reviewID, err.Error())
+		return err
+	}
+	// 각 화면은 프로파일 높이에 맞추고, 합성된 동영상은 다시 스케일하지 않는다.
+	height := profile.Height
+	if height <= 0 {
+		height = 1080
+	}
+	profile.Width = 0
+	profile.Height = 0
+	profile.Audio = "none"
+	fps := review.Fps
+	if fps <= 0 {
+		fps = 24
+	}
+	profile.Fps = fps
+	opt := FFmpegOptions{
+		Input:         inputs[0],
+		ExtraInputs:   inputs[1:],
+		FilterComplex: compareFilterGraph(review.CompareMode, labels, height, fps, review.Wipe, p.BurnIn.FontFile),
+		Output:        reviewDataFile(CachedAdminSetting, reviewID, profile.Ext, false),
+		Threads:       CachedAdminSetting.FFmpegThreads,
+	}
+	err = genReviewMedia(CachedAdminSetting, profile, opt)
+	if err != nil {
+		return err
+	}
+	// 생성된 파일의 확장자가 리뷰 정보와 다르다면 리뷰 정보를 갱신한다.
+	if review.Ext != profile.Ext {
+		err = setReviewExt(session, reviewID, profile.Ext)
 		if err != nil {
-			log.Println(err)
+			return err
 		}
-		return
 	}
-	// 연산이 끝나고 해당 파일을 삭제해야 한다면 삭제를 진행한다.
-	if review.RemoveAfterProcess {
-		err = os.Remove(review.Path)
+	// 생성된 .mp4 파일이 mp4 자료구조를 같는지 체크한다.
+	if profile.Ext == ".mp4" {
+		err = checkMp4FileStruct(CachedAdminSetting, review)
 		if err != nil {
-			err = setErrReview(session, reviewID, err.Error())
-			if err != nil {
-				log.Println(err)
-			}
-			return
+			return err
 		}
-	}
-	// 연산 상태를 done 으로 바꾼다.
-	err = setReviewProcessStatus(session, reviewID, "done")
-	if err != nil {
-		err = setErrReview(session, reviewID, err.Error())
+		err = setReviewMp4Duration(session, review)
 		if err != nil {
-			log.Println(err)
+			return err
 		}
-		return
 	}
-	return
+	// 연산 상태를 done 으로 바꾼다.
+	return setReviewProcessStatus(session, reviewID, "done")
 }
 
-func processingReviewImageItem(review Review) {
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		log.Println(err)
-		return
-	}
-	defer session.Close()
+func processingReviewImageItem(session *mgo.Session, review Review) error {
 	reviewID := review.ID.Hex()
-	// 연산 상태를 queued 으로 바꾼다. 바꾸는 이유는 ffmpeg 연산이 10초이상 진행될 때 상태가 바뀌지 않아서 이전에 연산중인 데이터가 다시 연산될 수 있기 때문이다.
-	err = setReviewProcessStatus(session, reviewID, "processing")
+	err := setReviewProcessStatus(session, reviewID, "processing")
 	if err != nil {
-		err = setErrReview(session, reviewID, err.Error())
-		if err != nil {
-			log.Println(err)
-		}
-		return
+		return err
 	}
 	// ReviewDataPath가 존재하는지 경로를 체크한다.
 	if _, err := os.Stat(CachedAdminSetting.ReviewDataPath); os.IsNotExist(err) {
-		err = setErrReview(session, reviewID, "admin 셋팅에 ReviewDataPath가 존재하지 않습니다")
-		if err != nil {
-			log.Println(err)
-		}
-		return
+		return errors.New("admin 셋팅에 ReviewDataPath가 존재하지 않습니다")
 	}
 	// image를 리뷰폴더에 복사한다.
 	input, err := ioutil.ReadFile(review.Path)
 	if err != nil {
-		log.Println(err)
-		return
+		return err
 	}
 	per, err := strconv.ParseInt(CachedAdminSetting.ReviewDataPathPermission, 8, 64)
 	if err != nil {
-		log.Println(err)
-		return
+		return err
 	}
 	err = ioutil.WriteFile(CachedAdminSetting.ReviewDataPath+"/"+reviewID+review.Ext, input, os.FileMode(per))
 	if err != nil {
-		log.Println(err)
-		return
+		return err
 	}
 	// 연산이 끝나고 해당 파일을 삭제해야 한다면 삭제를 진행한다.
 	if review.RemoveAfterProcess {
 		err = os.Remove(review.Path)
 		if err != nil {
-			err = setErrReview(session, reviewID, err.Error())
-			if err != nil {
-				log.Println(err)
-			}
-			return
+			return err
 		}
 	}
 	// 연산 상태를 done 으로 바꾼다.
-	err = setReviewProcessStatus(session, reviewID, "done")
+	return setReviewProcessStatus(session, reviewID, "done")
+}
+
+// renderReviewMedia 함수는 프로젝트의 트랜스코드 프로파일, 컬러 변환, BurnIn 설정을 이용해서 리뷰 동영상을 생성한다.
+// opt에는 입력 정보만 설정해서 전달한다.
+func renderReviewMedia(session *mgo.Session, review Review, opt FFmpegOptions) error {
+	reviewID := review.ID.Hex()
+	p, err := getProject(session, review.Project)
+	if err != nil {
+		return err
+	}
+	// 프로젝트에 설정된 트랜스코드 프로파일을 가지고 온다.
+	profile, err := reviewTranscodeProfile(session, p)
 	if err != nil {
-		err = setErrReview(session, reviewID, err.Error())
+		return err
+	}
+	// 프로젝트의 LUT, 컬러스페이스 설정을 이용해서 적용할 컬러 변환을 결정한다.
+	ct := planColorTransform(CachedAdminSetting, p, review.InColorspace)
+	opt.Output = reviewDataFile(CachedAdminSetting, reviewID, profile.Ext, false)
+	opt.Threads = CachedAdminSetting.FFmpegThreads
+	if ct.Type != "none" {
+		// OCIO로 생성한 LUT는 렌더링이 끝나면 필요없으므로 임시폴더에 저장한다.
+		dir, err := ioutil.TempDir("", "csi-review-lut")
 		if err != nil {
-			log.Println(err)
+			return err
+		}
+		defer os.RemoveAll(dir)
+		opt.ColorFilters, err = ct.Filters(CachedAdminSetting, dir)
+		if err != nil {
+			return err
+		}
+	}
+	// 클린 버전 리뷰 동영상을 생성한다.
+	err = genReviewMedia(CachedAdminSetting, profile, opt)
+	if err != nil {
+		return err
+	}
+	// 프로파일에 BurnIn이 설정되어 있다면 슬레이트 버전 리뷰 동영상을 생성한다.
+	if profile.BurnIn {
+		opt.Overlays, err = reviewBurnInFilters(session, p, review)
+		if err != nil {
+			return err
+		}
+		opt.Output = reviewDataFile(CachedAdminSetting, reviewID, profile.Ext, true)
+		err = genReviewMedia(CachedAdminSetting, profile, opt)
+		if err != nil {
+			return err
+		}
+	}
+	err = setReviewSlated(session, reviewID, profile.BurnIn)
+	if err != nil {
+		return err
+	}
+	// 적용된 컬러 변환을 리뷰에 기록한다.
+	err = setReviewColorTransform(session, reviewID, ct)
+	if err != nil {
+		return err
+	}
+	// 생성된 파일의 확장자가 리뷰 정보와 다르다면 리뷰 정보를 갱신한다.
+	if review.Ext != profile.Ext {
+		err = setReviewExt(session, reviewID, profile.Ext)
+		if err != nil {
+			return err
+		}
+	}
+	// 생성된 .mp4 파일이 mp4 자료구조를 같는지 체크한다.
+	if profile.Ext == ".mp4" {
+		err = checkMp4FileStruct(CachedAdminSetting, review)
+		if err != nil {
+			return err
+		}
+		err = setReviewMp4Duration(session, review)
+		if err != nil {
+			return err
 		}
-		return
 	}
-	return
+	return nil
 }
 
 // checkQuicktimeFileStruct 함수는 리뷰 아이템 정보를 이용해서 atom 구조가 정상인지 체크한다.
@@ -252,48 +461,92 @@ func checkMp4FileStruct(admin Setting, item Review) error {
 	return nil
 }
 
-// genMp4 는 리뷰 아이템 정보를 이용해서 .mp4 동영상을 만든다.
-func genMp4(admin Setting, item Review) error {
-	args := []string{
-		"-y",
-		"-i",
-		item.Path,
-		"-c:v",
-		"libx264",
-		"-qscale:v",
-		"7",
-		"-an",
-		"-pix_fmt",
-		"yuv420p", // 이 옵션이 없다면 Prores로 동영상을 만들때 크롬에서만 재생된다.
-		"-threads",
-		strconv.Itoa(CachedAdminSetting.FFmpegThreads), // 웹서버의 부하를 줄이기 위해서 서버수가 적다면 쓰레드 1개만 사용한다.
-		admin.ReviewDataPath + "/" + item.ID.Hex() + ".mp4",
-	}
-	err := exec.Command(admin.FFmpeg, args...).Run()
+// mp4Duration 함수는 .mp4 파일의 mvhd 정보를 이용해서 동영상 길이(초)를 반환한다.
+func mp4Duration(path string) (float64, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	f, err := mp4.OpenFromReader(file, info.Size())
+	if err != nil {
+		return 0, err
+	}
+	if f.Moov == nil || f.Moov.Mvhd == nil || f.Moov.Mvhd.Timescale == 0 {
+		return 0, errors.New(path + " 파일에서 동영상 길이를 읽을 수 없습니다")
+	}
+	return float64(f.Moov.Mvhd.Duration) / float64(f.Moov.Mvhd.Timescale), nil
 }
 
-// genOgg 는 리뷰 아이템 정보를 이용해서 .ogg 동영상을 만든다.
-func genOgg(admin Setting, item Review) error {
-	args := []string{
-		"-y",
-		"-i",
-		item.Path,
-		"-c:v",
-		"libtheora",
-		"-qscale:v",
-		"7",
-		"-an",
-		"-threads",
-		strconv.Itoa(CachedAdminSetting.FFmpegThreads), // 웹서버의 부하를 줄이기 위해서 서버수가 적다면 쓰레드 1개만 사용한다.
-		admin.ReviewDataPath + "/" + item.ID.Hex() + ".ogg",
-	}
-	err := exec.Command(admin.FFmpeg, args...).Run()
+// setReviewMp4Duration 함수는 생성된 .mp4 리뷰 동영상의 길이를 리뷰에 기록한다.
+// 비교 리뷰에서 프레임을 맞출 때 사용하는 정보이므로 길이를 읽지 못해도 연산을 실패시키지 않는다.
+func setReviewMp4Duration(session *mgo.Session, review Review) error {
+	duration, err := mp4Duration(reviewDataFile(CachedAdminSetting, review.ID.Hex(), ".mp4", false))
 	if err != nil {
-		return err
+		log.Println(err)
+		return nil
+	}
+	return setReviewDuration(session, review.ID.Hex(), duration)
+}
+
+// reviewTranscodeProfile 함수는 프로젝트에 설정된 리뷰 트랜스코드 프로파일을 반환한다.
+// 프로젝트에 프로파일이 설정되어 있지 않다면 기본 프로파일을 반환한다.
+func reviewTranscodeProfile(session *mgo.Session, p Project) (TranscodeProfile, error) {
+	profile := defaultTranscodeProfile()
+	if p.ReviewProfile != "" {
+		var err error
+		profile, err = GetTranscodeProfile(session, p.ReviewProfile)
+		if err != nil {
+			return TranscodeProfile{}, fmt.Errorf("%s 프로젝트의 리뷰 프로파일 %s 을(를) 가지고 올 수 없습니다: %v", p.ID, p.ReviewProfile, err)
+		}
+	}
+	return profile.ResolveMov(p), nil
+}
+
+// genReviewMedia 함수는 트랜스코드 프로파일과 FFmpeg 옵션을 이용해서 리뷰 동영상을 만든다.
+func genReviewMedia(admin Setting, profile TranscodeProfile, opt FFmpegOptions) error {
+	out, err := exec.Command(admin.FFmpeg, profile.FFmpegArgs(opt)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, lastLines(string(out), 5))
 	}
 	return nil
 }
+
+// reviewBurnInFilters 함수는 프로젝트 BurnIn 설정과 리뷰, 아이템 정보를 이용해서 슬레이트 버전에 사용할 drawtext 필터를 만든다.
+func reviewBurnInFilters(session *mgo.Session, p Project, review Review) ([]string, error) {
+	burnIn := p.BurnIn
+	if burnIn.IsEmpty() {
+		burnIn = defaultBurnIn()
+	}
+	// 에셋 리뷰처럼 샷 아이템이 없을 수 있다. 이때는 빈 아이템 정보를 사용한다.
+	item, err := Shot(session, review.Project, review.Name)
+	if err != nil && err != mgo.ErrNotFound {
+		return nil, err
+	}
+	startFrame := CachedAdminSetting.ProductionStartFrame
+	if item.JustIn > 0 {
+		startFrame = item.JustIn
+	}
+	return burnIn.Filters(newBurnInData(review, item, p), startFrame, item.JustTimecodeIn)
+}
+
+// reviewDataFile 함수는 리뷰 데이터 파일 경로를 반환한다. slate가 true라면 슬레이트 버전 경로를 반환한다.
+func reviewDataFile(admin Setting, id, ext string, slate bool) string {
+	if slate {
+		return admin.ReviewDataPath + "/" + id + "_slate" + ext
+	}
+	return admin.ReviewDataPath + "/" + id + ext
+}
+
+// lastLines 함수는 문자열의 마지막 n 줄을 반환한다. FFmpeg 로그를 리뷰 로그에 남길 때 사용한다.
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
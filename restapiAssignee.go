@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/digital-idea/dilog"
+	"github.com/digital-idea/ditime"
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPITaskAssignee 함수는 Task에 아티스트를 설정(/api/settaskassignee)하거나 삭제(/api/rmtaskassignee)하는 핸들러이다.
+func handleAPITaskAssignee(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		Project string `json:"project"`
+		ID      string `json:"id"`
+		Task    string `json:"task"`
+		Assignee
+		Result Task   `json:"result"`
+		UserID string `json:"userid"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	rcp.UserID, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	rcp.Project = r.FormValue("project")
+	if rcp.Project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = r.FormValue("id")
+	if rcp.ID == "" {
+		http.Error(w, "id(SS_0010_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Task = r.FormValue("task")
+	if rcp.Task == "" {
+		http.Error(w, "task를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.User = r.FormValue("user")
+	if rcp.User == "" {
+		http.Error(w, "user를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	if rcp.UserID == "unknown" && r.FormValue("userid") != "" {
+		rcp.UserID = r.FormValue("userid")
+	}
+	var msg string
+	switch r.URL.Path {
+	case "/api/settaskassignee":
+		rcp.Role = r.FormValue("role")
+		if rcp.Role == "" {
+			rcp.Role = "support"
+		}
+		if r.FormValue("share") != "" {
+			rcp.Share, err = strconv.ParseFloat(r.FormValue("share"), 64)
+			if err != nil {
+				http.Error(w, "share는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+				return
+			}
+		}
+		if r.FormValue("resultday") != "" {
+			rcp.ResultDay, err = strconv.Atoi(r.FormValue("resultday"))
+			if err != nil {
+				http.Error(w, "resultday는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+				return
+			}
+		}
+		if r.FormValue("startdate") != "" {
+			rcp.Startdate, err = ditime.ToFullTime(19, r.FormValue("startdate"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if r.FormValue("enddate") != "" {
+			rcp.Enddate, err = ditime.ToFullTime(19, r.FormValue("enddate"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		rcp.Result, err = SetTaskAssignee(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, rcp.Assignee)
+		msg = fmt.Sprintf("Set Task Assignee: %s %s, Role: %s, Share: %g%%", rcp.Task, rcp.User, rcp.Role, rcp.Share)
+	case "/api/rmtaskassignee":
+		rcp.Result, err = RmTaskAssignee(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, rcp.User)
+		msg = fmt.Sprintf("Rm Task Assignee: %s %s", rcp.Task, rcp.User)
+	default:
+		http.Error(w, "지원하지 않는 URL입니다", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, msg, rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, rcp.Project, fmt.Sprintf("%s\nProject: %s, ID: %s, Author: %s", msg, rcp.Project, rcp.ID, rcp.UserID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
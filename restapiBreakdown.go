@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIBreakdown 함수는 샷에 에셋을 연결(/api/setbreakdown), 삭제(/api/rmbreakdown)하거나 플래그를 해제(/api/clearbreakdownflag)하는 핸들러이다.
+func handleAPIBreakdown(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		Project string `json:"project"`
+		ID      string `json:"id"`
+		Breakdown
+		UserID string `json:"userid"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	rcp.UserID, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	rcp.Project = r.FormValue("project")
+	if rcp.Project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = r.FormValue("id")
+	if rcp.ID == "" {
+		http.Error(w, "id(SS_0010_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Asset = r.FormValue("asset")
+	if rcp.UserID == "unknown" && r.FormValue("userid") != "" {
+		rcp.UserID = r.FormValue("userid")
+	}
+	var msg string
+	switch r.URL.Path {
+	case "/api/setbreakdown":
+		rcp.Variant = r.FormValue("variant")
+		rcp.LOD = r.FormValue("lod")
+		rcp.Note = r.FormValue("note")
+		if r.FormValue("crowdcount") != "" {
+			rcp.CrowdCount, err = strconv.Atoi(r.FormValue("crowdcount"))
+			if err != nil {
+				http.Error(w, "crowdcount는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+				return
+			}
+		}
+		err = SetBreakdown(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Breakdown)
+		msg = fmt.Sprintf("Set Breakdown: %s, Variant: %s, LOD: %s, CrowdCount: %d", rcp.Asset, rcp.Variant, rcp.LOD, rcp.CrowdCount)
+	case "/api/rmbreakdown":
+		if rcp.Asset == "" {
+			http.Error(w, "asset을 설정해주세요", http.StatusBadRequest)
+			return
+		}
+		err = RmBreakdown(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Asset)
+		msg = "Rm Breakdown: " + rcp.Asset
+	case "/api/clearbreakdownflag":
+		err = ClearBreakdownFlag(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Asset)
+		msg = "Clear Breakdown Flag: " + rcp.Asset
+	default:
+		http.Error(w, "지원하지 않는 URL입니다", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, msg, rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, rcp.Project, fmt.Sprintf("%s\nProject: %s, ID: %s, Author: %s", msg, rcp.Project, rcp.ID, rcp.UserID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIAssetShots 함수는 에셋을 사용하는 샷 리스트를 반환하는 핸들러이다.
+func handleAPIAssetShots(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	asset := q.Get("asset")
+	if asset == "" {
+		http.Error(w, "asset을 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	shots, err := AssetShots(session, project, asset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := json.Marshal(shots)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIShotAssets 함수는 샷에서 사용하는 에셋 리스트를 반환하는 핸들러이다.
+func handleAPIShotAssets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	id := q.Get("id")
+	if id == "" {
+		http.Error(w, "id(SS_0010_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	assets, err := ShotAssets(session, project, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := json.Marshal(assets)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
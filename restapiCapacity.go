@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPICapacity 함수는 아티스트, 팀별 주간 작업량과 배정되지 않은 작업을 반환하는 핸들러이다.
+// Lead 미만의 사용자는 본인의 작업량만 볼 수 있다.
+func handleAPICapacity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	start := q.Get("start")
+	if start == "" {
+		start = time.Now().Format("2006-01-02")
+	}
+	weeks := 4
+	if q.Get("weeks") != "" {
+		weeks, err = strconv.Atoi(q.Get("weeks"))
+		if err != nil {
+			http.Error(w, "weeks는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+	}
+	team := q.Get("team")
+	user := q.Get("user")
+	if accessLevel < LeadAccessLevel {
+		team = ""
+		user = userID
+	}
+	report, err := GetCapacityReport(session, start, weeks, team, user)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIWorkCalendar 함수는 사용자의 근무 캘린더를 반환하는 핸들러이다. user가 없다면 토큰 사용자의 캘린더를 반환한다.
+func handleAPIWorkCalendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	user := r.URL.Query().Get("user")
+	if user == "" {
+		user = userID
+	}
+	cal, err := getWorkCalendar(session, user)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(cal)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIEditWorkCalendar 함수는 근무 캘린더를 수정하는 핸들러이다.
+// /api/setworkcalendar, /api/addleave, /api/rmleave 를 처리한다.
+// 본인의 캘린더는 누구나, 다른 사용자의 캘린더는 Lead 이상, 회사 휴일(company)은 PM 이상 수정할 수 있다.
+func handleAPIEditWorkCalendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	user := r.FormValue("user")
+	if user == "" {
+		user = userID
+	}
+	switch {
+	case user == CompanyCalendarID && accessLevel < PmAccessLevel:
+		http.Error(w, "회사 휴일을 수정할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	case user != userID && accessLevel < LeadAccessLevel:
+		http.Error(w, "다른 사용자의 근무 캘린더를 수정할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	var cal WorkCalendar
+	var msg string
+	switch r.URL.Path {
+	case "/api/setworkcalendar":
+		hours := 0.0
+		if r.FormValue("hoursperday") != "" {
+			hours, err = strconv.ParseFloat(r.FormValue("hoursperday"), 64)
+			if err != nil {
+				http.Error(w, "hoursperday는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+				return
+			}
+		}
+		var workdays []int
+		for _, d := range strings.Split(r.FormValue("workdays"), ",") {
+			d = strings.TrimSpace(d)
+			if d == "" {
+				continue
+			}
+			n, err := strconv.Atoi(d)
+			if err != nil {
+				http.Error(w, "workdays는 1,2,3,4,5 처럼 숫자로 입력되어야 합니다", http.StatusBadRequest)
+				return
+			}
+			workdays = append(workdays, n)
+		}
+		cal, err = SetWorkCalendar(session, user, hours, workdays)
+		msg = fmt.Sprintf("Set WorkCalendar: %s %gh %v", user, hours, workdays)
+	case "/api/addleave":
+		l := Leave{
+			Start: r.FormValue("start"),
+			End:   r.FormValue("end"),
+			Type:  r.FormValue("type"),
+			Note:  r.FormValue("note"),
+		}
+		if l.End == "" {
+			l.End = l.Start
+		}
+		if l.Type == "" {
+			l.Type = "leave"
+			if user == CompanyCalendarID {
+				l.Type = "holiday"
+			}
+		}
+		cal, err = AddLeave(session, user, l)
+		msg = fmt.Sprintf("Add Leave: %s %s~%s %s", user, l.Start, l.End, l.Type)
+	case "/api/rmleave":
+		cal, err = RmLeave(session, user, r.FormValue("start"))
+		msg = fmt.Sprintf("Rm Leave: %s %s", user, r.FormValue("start"))
+	default:
+		http.Error(w, "지원하지 않는 URL입니다", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, msg, "", "", "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(cal)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// handleAPIAddCompareReview 함수는 2개 이상의 리뷰를 비교하는 compare 리뷰를 추가하는 핸들러이다.
+func handleAPIAddCompareReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	var ids []string
+	for _, id := range strings.Split(r.FormValue("ids"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if !bson.IsObjectIdHex(id) {
+			http.Error(w, id+" 는 리뷰 ID 형식이 아닙니다", http.StatusBadRequest)
+			return
+		}
+		ids = append(ids, id)
+	}
+	mode := r.FormValue("mode")
+	if mode == "" {
+		mode = "sidebyside"
+	}
+	wipe := 0.5
+	if r.FormValue("wipe") != "" {
+		wipe, err = strconv.ParseFloat(r.FormValue("wipe"), 64)
+		if err != nil || wipe <= 0 || wipe >= 1 {
+			http.Error(w, "wipe는 0과 1 사이의 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+	}
+	var sources []Review
+	for _, id := range ids {
+		s, err := getReview(session, id)
+		if err != nil {
+			http.Error(w, id+" 리뷰를 가지고 올 수 없습니다: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		sources = append(sources, s)
+	}
+	err = checkCompareSources(mode, sources)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	first := sources[0]
+	review := Review{
+		ID:            bson.NewObjectId(),
+		Project:       first.Project,
+		Name:          first.Name,
+		Task:          first.Task,
+		Createtime:    time.Now().Format(time.RFC3339),
+		Author:        userID,
+		Status:        "wait",
+		ProcessStatus: "wait",
+		Playlist:      ids,
+		Description:   r.FormValue("description"),
+		Fps:           first.Fps,
+		Type:          "compare",
+		Ext:           ".mp4",
+		CompareMode:   mode,
+		Wipe:          wipe,
+	}
+	// 비교 리뷰의 버전은 원본 리뷰 중 가장 높은 버전을 사용한다.
+	for _, s := range sources {
+		if s.MainVersion > review.MainVersion || (s.MainVersion == review.MainVersion && s.SubVersion > review.SubVersion) {
+			review.MainVersion = s.MainVersion
+			review.SubVersion = s.SubVersion
+		}
+	}
+	user, err := getUser(session, userID)
+	if err == nil {
+		review.AuthorNameKor = user.LastNameKor + user.FirstNameKor
+	}
+	review.Stage = r.FormValue("stage")
+	if review.Stage == "" {
+		review.Stage, err = GetInitStageID(session)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	review.Updatetime = review.Createtime
+	err = addReview(session, review)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// 연산 큐에 등록하고 대기중인 worker를 바로 깨운다.
+	err = addReviewJob(session, review)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	review.ProcessStatus = "queued"
+	reviewQueue.Notify()
+
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("AddCompareReview: %s, %s, %s", review.Name, review.Task, strings.Join(ids, ",")), review.Project, review.Name, "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPICompareReviewFrame 함수는 compare 리뷰의 프레임에 대응되는 원본 리뷰들의 프레임 정보를 반환하는 핸들러이다.
+func handleAPICompareReviewFrame(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		ID      string               `json:"id"`
+		Mode    string               `json:"mode"`
+		Frame   int                  `json:"frame"`
+		Sources []CompareSourceFrame `json:"sources"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	rcp.ID = r.FormValue("id")
+	if !bson.IsObjectIdHex(rcp.ID) {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Frame, err = strconv.Atoi(r.FormValue("frame"))
+	if err != nil || rcp.Frame < 1 {
+		http.Error(w, "frame은 1 이상의 숫자로 입력되어야 합니다", http.StatusBadRequest)
+		return
+	}
+	review, err := getReview(session, rcp.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if review.Type != "compare" {
+		http.Error(w, rcp.ID+" 리뷰는 compare 타입이 아닙니다", http.StatusBadRequest)
+		return
+	}
+	var sources []Review
+	for _, id := range review.Playlist {
+		s, err := getReview(session, id)
+		if err != nil {
+			http.Error(w, id+" 원본 리뷰를 가지고 올 수 없습니다: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sources = append(sources, s)
+	}
+	rcp.Mode = review.CompareMode
+	rcp.Sources = compareFrames(review, sources, rcp.Frame)
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
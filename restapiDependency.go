@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIAddDependency 함수는 아이템(Task)에 상위 아이템(Task) 의존관계를 추가하는 핸들러이다.
+func handleAPIAddDependency(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		Project string `json:"project"`
+		ID      string `json:"id"`
+		Dependency
+		UserID string `json:"userid"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	rcp.UserID, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	rcp.Project = r.FormValue("project")
+	if rcp.Project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = r.FormValue("id")
+	if rcp.ID == "" {
+		http.Error(w, "id(SS_0010_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Task = r.FormValue("task")
+	rcp.Upstream = r.FormValue("upstream")
+	if rcp.Upstream == "" {
+		http.Error(w, "upstream(stone_asset)을 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.UpstreamTask = r.FormValue("upstreamtask")
+	if rcp.UserID == "unknown" && r.FormValue("userid") != "" {
+		rcp.UserID = r.FormValue("userid")
+	}
+	err = AddDependency(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Dependency)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	msg := fmt.Sprintf("Add Dependency: %s -> %s", dependencyNode{ID: rcp.ID, Task: rcp.Task}, dependencyNode{ID: rcp.Upstream, Task: rcp.UpstreamTask})
+	// log
+	err = dilog.Add(*flagDBIP, host, msg, rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, rcp.Project, fmt.Sprintf("%s\nProject: %s, Author: %s", msg, rcp.Project, rcp.UserID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIRmDependency 함수는 아이템(Task)의 의존관계를 삭제하는 핸들러이다.
+func handleAPIRmDependency(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		Project      string `json:"project"`
+		ID           string `json:"id"`
+		Task         string `json:"task"`
+		Upstream     string `json:"upstream"`
+		UpstreamTask string `json:"upstreamtask"`
+		UserID       string `json:"userid"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	rcp.UserID, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	rcp.Project = r.FormValue("project")
+	if rcp.Project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = r.FormValue("id")
+	if rcp.ID == "" {
+		http.Error(w, "id(SS_0010_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Task = r.FormValue("task")
+	rcp.Upstream = r.FormValue("upstream")
+	rcp.UpstreamTask = r.FormValue("upstreamtask")
+	if rcp.UserID == "unknown" && r.FormValue("userid") != "" {
+		rcp.UserID = r.FormValue("userid")
+	}
+	err = RmDependency(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, rcp.Upstream, rcp.UpstreamTask)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	msg := fmt.Sprintf("Rm Dependency: %s -> %s", dependencyNode{ID: rcp.ID, Task: rcp.Task}, dependencyNode{ID: rcp.Upstream, Task: rcp.UpstreamTask})
+	// log
+	err = dilog.Add(*flagDBIP, host, msg, rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, rcp.Project, fmt.Sprintf("%s\nProject: %s, Author: %s", msg, rcp.Project, rcp.UserID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIBlockers 함수는 아이템(Task)의 끝나지 않은 모든 상위 아이템(Task)과 상태를 반환하는 핸들러이다.
+func handleAPIBlockers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	id := q.Get("id")
+	if id == "" {
+		http.Error(w, "id(SS_0010_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	blockers, err := Blockers(session, project, id, q.Get("task"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := json.Marshal(blockers)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digital-idea/dilog"
+	"github.com/digital-idea/ditime"
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIDigestPreference 함수는 토큰 사용자의 다이제스트 알림 설정을 반환하는 핸들러이다.
+func handleAPIDigestPreference(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	p, err := getDigestPreference(session, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPISetDigestPreference 함수는 토큰 사용자의 다이제스트 알림 설정을 저장하는 핸들러이다.
+func handleAPISetDigestPreference(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	p, err := getDigestPreference(session, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if r.FormValue("frequency") != "" {
+		p.Frequency = r.FormValue("frequency")
+	}
+	for _, v := range []struct {
+		key   string
+		field *int
+	}{{"hour", &p.Hour}, {"weekday", &p.Weekday}} {
+		if r.FormValue(v.key) == "" {
+			continue
+		}
+		n, err := strconv.Atoi(r.FormValue(v.key))
+		if err != nil {
+			http.Error(w, v.key+"는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+		*v.field = n
+	}
+	if _, ok := r.Form["channels"]; ok {
+		p.Channels = []string{}
+		for _, c := range strings.Split(r.FormValue("channels"), ",") {
+			c = strings.TrimSpace(c)
+			if c != "" && !inStrings(p.Channels, c) {
+				p.Channels = append(p.Channels, c)
+			}
+		}
+	}
+	if _, ok := r.Form["slackwebhookurl"]; ok {
+		p.SlackWebhookURL = r.FormValue("slackwebhookurl")
+	}
+	if _, ok := r.Form["webhookurl"]; ok {
+		p.WebhookURL = r.FormValue("webhookurl")
+	}
+	p, err = SetDigestPreference(session, p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, "Set DigestPreference: "+p.Frequency+" "+strings.Join(p.Channels, ","), "", "", "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIDigest 함수는 토큰 사용자의 다이제스트를 처리하는 핸들러이다.
+// GET /api/digest 는 보낼 내용을 미리 보여주고, POST /api/senddigest 는 설정된 채널로 바로 보낸다.
+func handleAPIDigest(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/senddigest" && r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.URL.Path == "/api/digest" && r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	p, err := getDigestPreference(session, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	var d Digest
+	switch r.URL.Path {
+	case "/api/digest":
+		since := p.Since(now)
+		if r.URL.Query().Get("since") != "" {
+			since, err = ditime.ToFullTime(0, r.URL.Query().Get("since"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		d, err = BuildUserDigest(session, userID, since, now)
+	case "/api/senddigest":
+		d, err = SendDigest(session, p, now, digestChannels())
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
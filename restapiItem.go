@@ -27,7 +27,7 @@ func handleAPIRmItemID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer session.Close()
-	_, level, err := TokenHandler(r, session)
+	userID, level, err := TokenHandler(r, session)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
@@ -62,7 +62,7 @@ func handleAPIRmItemID(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	err = rmItemID(session, project, id)
+	err = rmItemID(session, project, id, userID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -242,7 +242,7 @@ func handleAPIRmItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer session.Close()
-	_, _, err = TokenHandler(r, session)
+	userID, _, err := TokenHandler(r, session)
 	if err != nil {
 		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
 		return
@@ -280,7 +280,7 @@ func handleAPIRmItem(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	err = rmItem(session, project, name, typ)
+	err = rmItem(session, project, name, userID, typ)
 	if err != nil {
 		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
 		return
@@ -815,7 +815,7 @@ func handleAPI2SetTaskMov(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	rcp.Mov = dipath.Win2lin(rcp.Mov) // 내부적으로 모든 경로는 unix 경로를 사용한다.
-	id, err := setTaskMov(session, rcp.Project, rcp.Name, rcp.Task, rcp.Mov)
+	id, err := setTaskMov(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Task, rcp.Mov)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -905,7 +905,7 @@ func handleAPISetTaskExpectDay(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	rcp.ExpectDay = num
-	err = setTaskExpectDay(session, rcp.Project, rcp.ID, rcp.Task, rcp.ExpectDay)
+	err = setTaskExpectDay(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, rcp.ExpectDay)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -983,7 +983,7 @@ func handleAPISetTaskUserComment(w http.ResponseWriter, r *http.Request) {
 	}
 	rcp.Task = task
 	rcp.UserComment = r.FormValue("usercomment")
-	err = setTaskUserComment(session, rcp.Project, rcp.ID, rcp.Task, rcp.UserComment)
+	err = setTaskUserComment(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, rcp.UserComment)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1072,7 +1072,7 @@ func handleAPISetTaskResultDay(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	rcp.ResultDay = num
-	err = setTaskResultDay(session, rcp.Project, rcp.ID, rcp.Task, rcp.ResultDay)
+	err = setTaskResultDay(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, rcp.ResultDay)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1170,7 +1170,7 @@ func handleAPISetUnDistortionSize(w http.ResponseWriter, r *http.Request) {
 			rcp.Size = v
 		}
 	}
-	id, err := SetImageSize(session, rcp.Project, rcp.Name, "undistortionsize", rcp.Size)
+	id, err := SetImageSize(session, rcp.Project, rcp.Name, rcp.UserID, "undistortionsize", rcp.Size)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1201,6 +1201,51 @@ func handleAPISetUnDistortionSize(w http.ResponseWriter, r *http.Request) {
 
 // handleAPISetJustIn 함수는 아이템에 JustIn 값을 설정한다.
 func handleAPISetJustIn(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "frame", "justin")
+}
+
+// handleAPISetPlateIn 함수는 아이템에 PlateIn 값을 설정한다.
+func handleAPISetPlateIn(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "frame", "platein")
+}
+
+// handleAPISetPlateOut 함수는 아이템에 PlateOut 값을 설정한다.
+func handleAPISetPlateOut(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "frame", "plateout")
+}
+
+// handleAPISetScanIn 함수는 아이템에 ScanIn 값을 설정한다.
+func handleAPISetScanIn(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "frame", "scanin")
+}
+
+// handleAPISetScanOut 함수는 아이템에 ScanOut 값을 설정한다.
+func handleAPISetScanOut(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "frame", "scanout")
+}
+
+// handleAPISetScanFrame 함수는 아이템에 ScanFrame 값을 설정한다.
+func handleAPISetScanFrame(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "frame", "scanframe")
+}
+
+// handleAPISetHandleIn 함수는 아이템에 HandleIn 값을 설정한다.
+func handleAPISetHandleIn(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "frame", "handlein")
+}
+
+// handleAPISetJustOut 함수는 아이템에 JustOut 값을 설정한다.
+func handleAPISetJustOut(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "frame", "justout")
+}
+
+// handleAPISetHandleOut 함수는 아이템에 HandleOut 값을 설정한다.
+func handleAPISetHandleOut(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "frame", "handleout")
+}
+
+// handleAPIPlateSize 함수는 아이템의 PlateSize를 설정한다.
+func handleAPISetPlateSize(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
@@ -1208,7 +1253,8 @@ func handleAPISetJustIn(w http.ResponseWriter, r *http.Request) {
 	type Recipe struct {
 		Project string `json:"project"`
 		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
+		ID      string `json:"id"`
+		Size    string `json:"size"`
 		UserID  string `json:"userid"`
 		Error   string `json:"error"`
 	}
@@ -1255,33 +1301,33 @@ func handleAPISetJustIn(w http.ResponseWriter, r *http.Request) {
 			if rcp.UserID == "unknown" && v != "" {
 				rcp.UserID = v
 			}
-		case "frame":
+		case "size":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
+			if !regexpImageSize.MatchString(v) {
+				http.Error(w, "2048x1152 형태로 입력해주세요", http.StatusBadRequest)
 				return
 			}
-			rcp.Frame = n
+			rcp.Size = v
 		}
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "justin", rcp.Frame)
+	id, err := SetImageSize(session, rcp.Project, rcp.Name, rcp.UserID, "platesize", rcp.Size)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	rcp.ID = id
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Just In: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Platesize: %s", rcp.Size), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Just In: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Platesize: %s\nProject: %s, Name: %s, Author: %s", rcp.Size, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1293,18 +1339,40 @@ func handleAPISetJustIn(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
-// handleAPISetPlateIn 함수는 아이템에 PlateIn 값을 설정한다.
-func handleAPISetPlateIn(w http.ResponseWriter, r *http.Request) {
+// PostFormValueInList 는 PostForm 쿼리시 Value값이 1개라면 값을 리턴한다.
+func PostFormValueInList(key string, values []string) (string, error) {
+	if len(values) != 1 {
+		return "", errors.New(key + "값이 여러개 입니다")
+	}
+	if key == "startdate" && values[0] == "" { // Task 시작일은 빈 문자를 허용한다.
+		return "", nil
+	}
+	if key == "predate" && values[0] == "" { // 1차마감일은 빈 문자를 허용한다.
+		return "", nil
+	}
+	if key == "date" && values[0] == "" { // 2차마감일은 빈 문자를 허용한다.
+		return "", nil
+	}
+	if key == "shottype" && values[0] == "" { // 샷타입은 빈 문자를 허용한다.
+		return "", nil
+	}
+	if values[0] == "" {
+		return "", errors.New(key + "값이 빈 문자입니다")
+	}
+	return values[0], nil
+}
+
+// handleAPISetCameraPubPath 함수는 아이템의 Camera PubPath를 설정한다.
+func handleAPISetCameraPubPath(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
 		Project string `json:"project"`
-		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
+		ID      string `json:"id"`
+		Path    string `json:"path"`
 		UserID  string `json:"userid"`
-		Error   string `json:"error"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -1324,79 +1392,62 @@ func handleAPISetPlateIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
-		}
+	project := r.FormValue("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Project = project
+	id := r.FormValue("id")
+	if id == "" {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = id
+	path := r.FormValue("path")
+	if path == "" {
+		http.Error(w, "path를 설정해주세요", http.StatusBadRequest)
+		return
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "platein", rcp.Frame)
+	rcp.Path = path
+	err = SetCameraPubPath(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Path)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Plate In: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Camera Pub Path: %s", rcp.Path), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Plate In: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Camera Pub Path: %s\nProject: %s, ID: %s, Author: %s", rcp.Path, rcp.Project, rcp.ID, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetPlateOut 함수는 아이템에 PlateOut 값을 설정한다.
-func handleAPISetPlateOut(w http.ResponseWriter, r *http.Request) {
+// handleAPISetCameraPubTask 함수는 아이템의 Camera PubTask를 설정한다.
+func handleAPISetCameraPubTask(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
 		Project string `json:"project"`
-		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
+		ID      string `json:"id"`
+		Task    string `json:"task"`
 		UserID  string `json:"userid"`
 		Error   string `json:"error"`
 	}
@@ -1418,81 +1469,63 @@ func handleAPISetPlateOut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
-		}
+	project := r.FormValue("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Project = project
+	id := r.FormValue("id")
+	if id == "" {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = id
+	task := r.FormValue("task")
+	if task == "" {
+		http.Error(w, "task를 설정해주세요", http.StatusBadRequest)
+		return
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "plateout", rcp.Frame)
+	rcp.Task = task
+	err = SetCameraPubTask(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Plate Out: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Camera Pub Task: %s", rcp.Task), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Plate Out: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Camera Pub Task: %s\nProject: %s, ID: %s, Author: %s", rcp.Task, rcp.Project, rcp.ID, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetScanIn 함수는 아이템에 ScanIn 값을 설정한다.
-func handleAPISetScanIn(w http.ResponseWriter, r *http.Request) {
+// handleAPISetCameraLensmm 함수는 아이템의 Camera Lensmm를 설정한다.
+func handleAPISetCameraLensmm(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
 		Project string `json:"project"`
-		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
+		ID      string `json:"id"`
+		Lensmm  string `json:"lensmm"`
 		UserID  string `json:"userid"`
-		Error   string `json:"error"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -1512,81 +1545,63 @@ func handleAPISetScanIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
-		}
-	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "scanin", rcp.Frame)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	project := r.FormValue("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
 		return
 	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Scan In: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	rcp.Project = project
+	id := r.FormValue("id")
+	if id == "" {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = id
+	lensmm := r.FormValue("lensmm")
+	if lensmm == "" {
+		http.Error(w, "lensmm를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Lensmm = lensmm
+	err = SetCameraLensmm(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Lensmm)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Camera Lens mm: %s", rcp.Lensmm), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Scan In: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Camera Lens mm: %s\nProject: %s, ID: %s, Author: %s", rcp.Lensmm, rcp.Project, rcp.ID, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetScanOut 함수는 아이템에 ScanOut 값을 설정한다.
-func handleAPISetScanOut(w http.ResponseWriter, r *http.Request) {
+// handleAPISetCameraProjection 함수는 아이템의 Camera Projection 여부를 설정한다.
+func handleAPISetCameraProjection(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
+		Project    string `json:"project"`
+		ID         string `json:"id"`
+		Projection bool   `json:"projection"`
+		UserID     string `json:"userid"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -1606,71 +1621,54 @@ func handleAPISetScanOut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
-		}
+	project := r.FormValue("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Project = project
+	id := r.FormValue("id")
+	if id == "" {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = id
+	projection := r.FormValue("projection")
+	if id == "" {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "scanout", rcp.Frame)
+	rcp.Projection = str2bool(projection)
+	err = SetCameraProjection(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Projection)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Scan Out: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Camera Projection: %t", rcp.Projection), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Scan Out: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Camera Projection: %t\nProject: %s, ID: %s, Author: %s", rcp.Projection, rcp.Project, rcp.ID, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetScanFrame 함수는 아이템에 ScanFrame 값을 설정한다.
-func handleAPISetScanFrame(w http.ResponseWriter, r *http.Request) {
+// handleAPISetObjectID 함수는 아이템의 ObjectID 값을 설정한다.
+func handleAPISetObjectID(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
@@ -1678,7 +1676,8 @@ func handleAPISetScanFrame(w http.ResponseWriter, r *http.Request) {
 	type Recipe struct {
 		Project string `json:"project"`
 		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
+		In      int    `json:"in"`
+		Out     int    `json:"out"`
 		UserID  string `json:"userid"`
 		Error   string `json:"error"`
 	}
@@ -1716,6 +1715,26 @@ func handleAPISetScanFrame(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			rcp.Name = v
+		case "in":
+			if len(values) == 1 {
+				rcp.In, err = strconv.Atoi(values[0])
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			} else {
+				rcp.In = 0
+			}
+		case "out":
+			if len(values) == 1 {
+				rcp.Out, err = strconv.Atoi(values[0])
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			} else {
+				rcp.Out = 0
+			}
 		case "userid":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
@@ -1725,33 +1744,21 @@ func handleAPISetScanFrame(w http.ResponseWriter, r *http.Request) {
 			if rcp.UserID == "unknown" && v != "" {
 				rcp.UserID = v
 			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
 		}
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "scanframe", rcp.Frame)
+	err = SetObjectID(session, rcp.Project, rcp.Name, rcp.UserID, rcp.In, rcp.Out)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Scan Frame: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("ObjectID: %d - %d", rcp.In, rcp.Out), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Scan Frame: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("ObjectID: %d - %d\nProject: %s, Name: %s, Author: %s", rcp.In, rcp.Out, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1763,8 +1770,18 @@ func handleAPISetScanFrame(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
-// handleAPISetHandleIn 함수는 아이템에 HandleIn 값을 설정한다.
-func handleAPISetHandleIn(w http.ResponseWriter, r *http.Request) {
+// handleAPISetSeq 함수는 아이템의 seq 값을 설정한다.
+func handleAPISetSeq(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "seq", "seq")
+}
+
+// handleAPISetPlatePath 함수는 아이템의 PlatePath 값을 설정한다.
+func handleAPISetPlatePath(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "path", "platepath")
+}
+
+// handleAPI2SetThummov 함수는 아이템의 Thummov 값을 설정한다.
+func handleAPI2SetThummov(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
@@ -1772,9 +1789,8 @@ func handleAPISetHandleIn(w http.ResponseWriter, r *http.Request) {
 	type Recipe struct {
 		Project string `json:"project"`
 		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
+		Path    string `json:"path"`
 		UserID  string `json:"userid"`
-		Error   string `json:"error"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -1794,79 +1810,80 @@ func handleAPISetHandleIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
-		}
+	project := r.FormValue("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Project = project
+	name := r.FormValue("name")
+	if name == "" {
+		http.Error(w, "name을 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Name = name
+	path := r.FormValue("path")
+	if path == "" {
+		http.Error(w, "path를 설정해주세요", http.StatusBadRequest)
+		return
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "handlein", rcp.Frame)
+	rcp.Path = path
+
+	err = SetThummov(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Path)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Handle In: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Thumbnail: %s", rcp.Path), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Handle In: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Thumbnail: %s\nProject: %s, Name: %s, Author: %s", rcp.Path, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetJustOut 함수는 아이템에 JustOut 값을 설정한다.
-func handleAPISetJustOut(w http.ResponseWriter, r *http.Request) {
+// handleAPISetBeforemov 함수는 아이템의 Before mov 값을 설정한다.
+func handleAPISetBeforemov(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "path", "beforemov")
+}
+
+// handleAPISetAftermov 함수는 아이템의 After mov 값을 설정한다.
+func handleAPISetAftermov(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "path", "aftermov")
+}
+
+// handleAPISetEditmov 함수는 아이템의 Edit mov 값을 설정한다.
+func handleAPISetEditmov(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "path", "editmov")
+}
+
+// handleAPISetTaskStatus 함수는 아이템의 task에 대한 상태를 설정한다.
+func handleAPISetTaskStatus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
 		Project string `json:"project"`
+		ID      string `json:"id"`
 		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
+		Task    string `json:"task"`
+		Status  string `json:"status"`
 		UserID  string `json:"userid"`
 		Error   string `json:"error"`
 	}
@@ -1888,81 +1905,91 @@ func handleAPISetJustOut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
+	rcp.Project = r.FormValue("project")
+	if rcp.Project == "" {
+		if err != nil {
+			http.Error(w, "프로젝트가 빈 문자열 입니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Name = r.FormValue("name")
+	if rcp.Name == "" {
+		if err != nil {
+			http.Error(w, "name이 빈 문자열 입니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Task = r.FormValue("task")
+	if rcp.Task == "" {
+		if err != nil {
+			http.Error(w, "task가 빈 문자열 입니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Status = r.FormValue("status")
+	if rcp.Status == "" {
+		if err != nil {
+			http.Error(w, "status가 빈 문자열 입니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.ID = r.FormValue("id")
+	if rcp.ID == "" {
+		typ, err := Type(session, rcp.Project, rcp.Name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
+		rcp.ID = rcp.Name + "_" + typ
+	}
+
+	// task가 존재하는지 체크한다.
+	err = HasTask(session, rcp.Project, rcp.ID, rcp.Task)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "justout", rcp.Frame)
+	err = SetTaskStatus(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, rcp.Status)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Just Out: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Task Status: %s %s", rcp.Task, rcp.Status), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Just Out: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Task Status: %s %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Status, rcp.Project, rcp.ID, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
+	rcp.Status = Status2string(rcp.Status) // "2"형태의 숫자라면 문자로 바꾼다.
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetHandleOut 함수는 아이템에 HandleOut 값을 설정한다.
-func handleAPISetHandleOut(w http.ResponseWriter, r *http.Request) {
+// handleAPI2SetTaskStatus 함수는 아이템의 task에 대한 상태를 설정한다.
+func handleAPI2SetTaskStatus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
 		Project string `json:"project"`
+		ID      string `json:"id"`
 		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
+		Task    string `json:"task"`
+		Status  string `json:"status"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -1971,7 +1998,7 @@ func handleAPISetHandleOut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
+	ssid, _, err := TokenHandler(r, session)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
@@ -1982,82 +2009,88 @@ func handleAPISetHandleOut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
+	rcp.Project = r.FormValue("project")
+	if rcp.Project == "" {
+		if err != nil {
+			http.Error(w, "프로젝트가 빈 문자열 입니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Name = r.FormValue("name")
+	if rcp.Name == "" {
+		if err != nil {
+			http.Error(w, "name이 빈 문자열 입니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Task = r.FormValue("task")
+	if rcp.Task == "" {
+		if err != nil {
+			http.Error(w, "task가 빈 문자열 입니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Status = r.FormValue("status")
+	if rcp.Status == "" {
+		if err != nil {
+			http.Error(w, "status가 빈 문자열 입니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.ID = r.FormValue("id")
+	if rcp.ID == "" {
+		typ, err := Type(session, rcp.Project, rcp.Name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
+		rcp.ID = rcp.Name + "_" + typ
+	}
+	// task가 존재하는지 체크한다.
+	err = HasTask(session, rcp.Project, rcp.ID, rcp.Task)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "handleout", rcp.Frame)
+	name, err := SetTaskStatusV2(session, rcp.Project, rcp.ID, ssid, rcp.Task, rcp.Status)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Handle Out: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Task Status: %s %s", rcp.Task, rcp.Status), rcp.Project, name, "csi3", ssid, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Handle Out: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Task Status: %s %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Status, rcp.Project, name, ssid))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPIPlateSize 함수는 아이템의 PlateSize를 설정한다.
-func handleAPISetPlateSize(w http.ResponseWriter, r *http.Request) {
+// handleAPIRmTask 함수는 아이템의 task를 제거한다.
+func handleAPIRmTask(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
 		Project string `json:"project"`
-		Name    string `json:"name"`
 		ID      string `json:"id"`
-		Size    string `json:"size"`
+		Task    string `json:"task"`
 		UserID  string `json:"userid"`
-		Error   string `json:"error"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -2086,85 +2119,52 @@ func handleAPISetPlateSize(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
+		case "id":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "size":
+			rcp.ID = v
+		case "task":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			if !regexpImageSize.MatchString(v) {
-				http.Error(w, "2048x1152 형태로 입력해주세요", http.StatusBadRequest)
-				return
-			}
-			rcp.Size = v
+			rcp.Task = v
 		}
 	}
-	id, err := SetImageSize(session, rcp.Project, rcp.Name, "platesize", rcp.Size)
+	err = RmTask(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	rcp.ID = id
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Platesize: %s", rcp.Size), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Rm Task: %s", rcp.Task), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Platesize: %s\nProject: %s, Name: %s, Author: %s", rcp.Size, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Rm Task: %s\nProject: %s, ID: %s, Author: %s", rcp.Task, rcp.Project, rcp.ID, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// PostFormValueInList 는 PostForm 쿼리시 Value값이 1개라면 값을 리턴한다.
-func PostFormValueInList(key string, values []string) (string, error) {
-	if len(values) != 1 {
-		return "", errors.New(key + "값이 여러개 입니다")
-	}
-	if key == "startdate" && values[0] == "" { // Task 시작일은 빈 문자를 허용한다.
-		return "", nil
-	}
-	if key == "predate" && values[0] == "" { // 1차마감일은 빈 문자를 허용한다.
-		return "", nil
-	}
-	if key == "date" && values[0] == "" { // 2차마감일은 빈 문자를 허용한다.
-		return "", nil
-	}
-	if key == "shottype" && values[0] == "" { // 샷타입은 빈 문자를 허용한다.
-		return "", nil
-	}
-	if values[0] == "" {
-		return "", errors.New(key + "값이 빈 문자입니다")
-	}
-	return values[0], nil
-}
-
-// handleAPISetCameraPubPath 함수는 아이템의 Camera PubPath를 설정한다.
-func handleAPISetCameraPubPath(w http.ResponseWriter, r *http.Request) {
+// handleAPIAddTask 함수는 아이템에 task를 추가한다.
+func handleAPIAddTask(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
@@ -2172,7 +2172,8 @@ func handleAPISetCameraPubPath(w http.ResponseWriter, r *http.Request) {
 	type Recipe struct {
 		Project string `json:"project"`
 		ID      string `json:"id"`
-		Path    string `json:"path"`
+		Task    string `json:"task"`
+		Status  string `json:"status"`
 		UserID  string `json:"userid"`
 	}
 	rcp := Recipe{}
@@ -2205,25 +2206,35 @@ func handleAPISetCameraPubPath(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	rcp.ID = id
-	path := r.FormValue("path")
-	if path == "" {
-		http.Error(w, "path를 설정해주세요", http.StatusBadRequest)
+	task := r.FormValue("task")
+	if task == "" {
+		http.Error(w, "task를 설정해주세요", http.StatusBadRequest)
 		return
 	}
-	rcp.Path = path
-	err = SetCameraPubPath(session, rcp.Project, rcp.ID, rcp.Path)
+	rcp.Task = task
+	status, err := AllStatus(session)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	for _, s := range status {
+		if s.InitStatus {
+			rcp.Status = s.ID
+		}
+	}
+	err = AddTask(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, rcp.Status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Camera Pub Path: %s", rcp.Path), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Add Task: %s(%s)", rcp.Task, rcp.Status), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Camera Pub Path: %s\nProject: %s, ID: %s, Author: %s", rcp.Path, rcp.Project, rcp.ID, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Add Task: %s %s\nProject: %s, ID: %s, Author: %s", rcp.Task, rcp.Status, rcp.Project, rcp.ID, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -2239,2372 +2250,8 @@ func handleAPISetCameraPubPath(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
-// handleAPISetCameraPubTask 함수는 아이템의 Camera PubTask를 설정한다.
-func handleAPISetCameraPubTask(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Task    string `json:"task"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.ID = id
-	task := r.FormValue("task")
-	if task == "" {
-		http.Error(w, "task를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Task = task
-	err = SetCameraPubTask(session, rcp.Project, rcp.ID, rcp.Task)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Camera Pub Task: %s", rcp.Task), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Camera Pub Task: %s\nProject: %s, ID: %s, Author: %s", rcp.Task, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetCameraLensmm 함수는 아이템의 Camera Lensmm를 설정한다.
-func handleAPISetCameraLensmm(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Lensmm  string `json:"lensmm"`
-		UserID  string `json:"userid"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.ID = id
-	lensmm := r.FormValue("lensmm")
-	if lensmm == "" {
-		http.Error(w, "lensmm를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Lensmm = lensmm
-	err = SetCameraLensmm(session, rcp.Project, rcp.ID, rcp.Lensmm)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Camera Lens mm: %s", rcp.Lensmm), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Camera Lens mm: %s\nProject: %s, ID: %s, Author: %s", rcp.Lensmm, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetCameraProjection 함수는 아이템의 Camera Projection 여부를 설정한다.
-func handleAPISetCameraProjection(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project    string `json:"project"`
-		ID         string `json:"id"`
-		Projection bool   `json:"projection"`
-		UserID     string `json:"userid"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.ID = id
-	projection := r.FormValue("projection")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Projection = str2bool(projection)
-	err = SetCameraProjection(session, rcp.Project, rcp.ID, rcp.Projection)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Camera Projection: %t", rcp.Projection), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Camera Projection: %t\nProject: %s, ID: %s, Author: %s", rcp.Projection, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetObjectID 함수는 아이템의 ObjectID 값을 설정한다.
-func handleAPISetObjectID(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		In      int    `json:"in"`
-		Out     int    `json:"out"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "in":
-			if len(values) == 1 {
-				rcp.In, err = strconv.Atoi(values[0])
-				if err != nil {
-					http.Error(w, err.Error(), http.StatusBadRequest)
-					return
-				}
-			} else {
-				rcp.In = 0
-			}
-		case "out":
-			if len(values) == 1 {
-				rcp.Out, err = strconv.Atoi(values[0])
-				if err != nil {
-					http.Error(w, err.Error(), http.StatusBadRequest)
-					return
-				}
-			} else {
-				rcp.Out = 0
-			}
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		}
-	}
-	err = SetObjectID(session, rcp.Project, rcp.Name, rcp.In, rcp.Out)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("ObjectID: %d - %d", rcp.In, rcp.Out), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("ObjectID: %d - %d\nProject: %s, Name: %s, Author: %s", rcp.In, rcp.Out, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetSeq 함수는 아이템의 seq 값을 설정한다.
-func handleAPISetSeq(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Seq     string `json:"seq"`
-		UserID  string `json:"userid"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.ID = id
-	rcp.Seq = r.FormValue("seq")
-
-	err = SetSeq(session, rcp.Project, rcp.ID, rcp.Seq)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Seq: %s", rcp.Seq), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Seq: %s\nProject: %s, ID: %s, Author: %s", rcp.Seq, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetPlatePath 함수는 아이템의 PlatePath 값을 설정한다.
-func handleAPISetPlatePath(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Path    string `json:"path"`
-		UserID  string `json:"userid"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.ID = id
-	rcp.Path = r.FormValue("path")
-
-	err = SetPlatePath(session, rcp.Project, rcp.ID, rcp.Path)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set PlatePath: %s", rcp.Path), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set PlatePath: %s\nProject: %s, ID: %s, Author: %s", rcp.Path, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPI2SetThummov 함수는 아이템의 Thummov 값을 설정한다.
-func handleAPI2SetThummov(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Path    string `json:"path"`
-		UserID  string `json:"userid"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-	name := r.FormValue("name")
-	if name == "" {
-		http.Error(w, "name을 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Name = name
-	path := r.FormValue("path")
-	if path == "" {
-		http.Error(w, "path를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Path = path
-
-	err = SetThummov(session, rcp.Project, rcp.Name, rcp.Path)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Thumbnail: %s", rcp.Path), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Thumbnail: %s\nProject: %s, Name: %s, Author: %s", rcp.Path, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetBeforemov 함수는 아이템의 Before mov 값을 설정한다.
-func handleAPISetBeforemov(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Path    string `json:"path"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "path":
-			if len(values) != 1 {
-				rcp.Path = ""
-			} else {
-				rcp.Path = values[0]
-			}
-		}
-	}
-	err = SetBeforemov(session, rcp.Project, rcp.Name, rcp.Path)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Beforemov: %s", rcp.Path), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Beforemov: %s\nProject: %s, Name: %s, Author: %s", rcp.Path, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetAftermov 함수는 아이템의 After mov 값을 설정한다.
-func handleAPISetAftermov(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Path    string `json:"path"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "path":
-			if len(values) != 1 {
-				rcp.Path = ""
-			} else {
-				rcp.Path = values[0]
-			}
-		}
-	}
-	err = SetAftermov(session, rcp.Project, rcp.Name, rcp.Path)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Aftermov: %s", rcp.Path), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Aftermov: %s\nProject: %s, Name: %s, Author: %s", rcp.Path, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetEditmov 함수는 아이템의 Edit mov 값을 설정한다.
-func handleAPISetEditmov(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Path    string `json:"path"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.ID = id
-	path := r.FormValue("path")
-	if path == "" {
-		http.Error(w, "path를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Path = path
-
-	err = SetEditmov(session, rcp.Project, rcp.ID, rcp.Path)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Editmov: %s", rcp.Path), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Editmov: %s\nProject: %s, ID: %s, Author: %s", rcp.Path, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetTaskStatus 함수는 아이템의 task에 대한 상태를 설정한다.
-func handleAPISetTaskStatus(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Name    string `json:"name"`
-		Task    string `json:"task"`
-		Status  string `json:"status"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	rcp.Project = r.FormValue("project")
-	if rcp.Project == "" {
-		if err != nil {
-			http.Error(w, "프로젝트가 빈 문자열 입니다", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.Name = r.FormValue("name")
-	if rcp.Name == "" {
-		if err != nil {
-			http.Error(w, "name이 빈 문자열 입니다", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.Task = r.FormValue("task")
-	if rcp.Task == "" {
-		if err != nil {
-			http.Error(w, "task가 빈 문자열 입니다", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.Status = r.FormValue("status")
-	if rcp.Status == "" {
-		if err != nil {
-			http.Error(w, "status가 빈 문자열 입니다", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.ID = r.FormValue("id")
-	if rcp.ID == "" {
-		typ, err := Type(session, rcp.Project, rcp.Name)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		rcp.ID = rcp.Name + "_" + typ
-	}
-
-	// task가 존재하는지 체크한다.
-	err = HasTask(session, rcp.Project, rcp.ID, rcp.Task)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	err = SetTaskStatus(session, rcp.Project, rcp.ID, rcp.Task, rcp.Status)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Task Status: %s %s", rcp.Task, rcp.Status), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Task Status: %s %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Status, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	rcp.Status = Status2string(rcp.Status) // "2"형태의 숫자라면 문자로 바꾼다.
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPI2SetTaskStatus 함수는 아이템의 task에 대한 상태를 설정한다.
-func handleAPI2SetTaskStatus(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Name    string `json:"name"`
-		Task    string `json:"task"`
-		Status  string `json:"status"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	ssid, _, err := TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	rcp.Project = r.FormValue("project")
-	if rcp.Project == "" {
-		if err != nil {
-			http.Error(w, "프로젝트가 빈 문자열 입니다", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.Name = r.FormValue("name")
-	if rcp.Name == "" {
-		if err != nil {
-			http.Error(w, "name이 빈 문자열 입니다", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.Task = r.FormValue("task")
-	if rcp.Task == "" {
-		if err != nil {
-			http.Error(w, "task가 빈 문자열 입니다", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.Status = r.FormValue("status")
-	if rcp.Status == "" {
-		if err != nil {
-			http.Error(w, "status가 빈 문자열 입니다", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.ID = r.FormValue("id")
-	if rcp.ID == "" {
-		typ, err := Type(session, rcp.Project, rcp.Name)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		rcp.ID = rcp.Name + "_" + typ
-	}
-	// task가 존재하는지 체크한다.
-	err = HasTask(session, rcp.Project, rcp.ID, rcp.Task)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	name, err := SetTaskStatusV2(session, rcp.Project, rcp.ID, rcp.Task, rcp.Status)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Task Status: %s %s", rcp.Task, rcp.Status), rcp.Project, name, "csi3", ssid, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Task Status: %s %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Status, rcp.Project, name, ssid))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPIRmTask 함수는 아이템의 task를 제거한다.
-func handleAPIRmTask(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Task    string `json:"task"`
-		UserID  string `json:"userid"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "id":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.ID = v
-		case "task":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Task = v
-		}
-	}
-	err = RmTask(session, rcp.Project, rcp.ID, rcp.Task)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Rm Task: %s", rcp.Task), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Rm Task: %s\nProject: %s, ID: %s, Author: %s", rcp.Task, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPIAddTask 함수는 아이템에 task를 추가한다.
-func handleAPIAddTask(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Task    string `json:"task"`
-		Status  string `json:"status"`
-		UserID  string `json:"userid"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.ID = id
-	task := r.FormValue("task")
-	if task == "" {
-		http.Error(w, "task를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Task = task
-	status, err := AllStatus(session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	for _, s := range status {
-		if s.InitStatus {
-			rcp.Status = s.ID
-		}
-	}
-	err = AddTask(session, rcp.Project, rcp.ID, rcp.Task, rcp.Status)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Add Task: %s(%s)", rcp.Task, rcp.Status), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Add Task: %s %s\nProject: %s, ID: %s, Author: %s", rcp.Task, rcp.Status, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetTaskUser 함수는 아이템의 task에 대한 유저를 설정한다.
-func handleAPISetTaskUser(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project  string `json:"project"`
-		ID       string `json:"id"`
-		Name     string `json:"name"`
-		Task     string `json:"task"`
-		Username string `json:"username"`
-		UserID   string `json:"userid"`
-		Error    string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "task":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Task = v
-		case "user":
-			if len(values) == 1 {
-				rcp.Username = values[0]
-			}
-		}
-	}
-	id, err := SetTaskUser(session, rcp.Project, rcp.Name, rcp.Task, rcp.Username)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	rcp.ID = id
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Task User: %s %s", rcp.Task, rcp.Username), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Task User: %s %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Username, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	rcp.Username = userInfo(rcp.Username) // id(name,team) 문자열을 name,team으로 바꾼다. 웹에서 보기좋게 하기 위함.
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetTaskStartdate 함수는 아이템의 task에 대한 시작일을 설정한다.
-func handleAPISetTaskStartdate(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Name    string `json:"name"`
-		Date    string `json:"date"`
-		Task    string `json:"task"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	rcp.Project = r.FormValue("project")
-	if rcp.Project == "" {
-		if err != nil {
-			http.Error(w, "프로젝트가 빈 문자열 입니다", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.Name = r.FormValue("name")
-	if rcp.Name == "" {
-		if err != nil {
-			http.Error(w, "name이 빈 문자열 입니다", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.Task = r.FormValue("task")
-	if rcp.Task == "" {
-		if err != nil {
-			http.Error(w, "task가 빈 문자열 입니다", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.Date = r.FormValue("date") // 마감일이 빈 문자열이 될 수 있다.
-	rcp.ID = r.FormValue("id")
-	if rcp.ID == "" {
-		typ, err := Type(session, rcp.Project, rcp.Name)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-		rcp.ID = rcp.Name + "_" + typ
-	}
-
-	err = HasTask(session, rcp.Project, rcp.ID, rcp.Task)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	err = SetTaskStartdate(session, rcp.Project, rcp.ID, rcp.Task, rcp.Date)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set %s Task StartDate: %s", rcp.Task, rcp.Date), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set %s Task StartDate: %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Date, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetTaskUserNote 함수는 아이템의 task에 대한 시작일을 설정한다.
-func handleAPISetTaskUserNote(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project  string `json:"project"`
-		Name     string `json:"name"`
-		Task     string `json:"task"`
-		UserNote string `json:"usernote"`
-		UserID   string `json:"userid"`
-		Error    string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "task":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Task = v
-		case "note", "usernote":
-			if len(values) != 1 {
-				rcp.UserNote = ""
-			} else {
-				rcp.UserNote = values[0]
-			}
-		}
-	}
-	err = SetTaskUserNote(session, rcp.Project, rcp.Name, rcp.Task, rcp.UserNote)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set %s Task UserNote: %s", rcp.Task, rcp.UserNote), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set %s Task UserNote: %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.UserNote, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetDeadline2D 함수는 아이템의 2D 마감일을 설정한다.
-func handleAPISetDeadline2D(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project   string `json:"project"`
-		Name      string `json:"name"`
-		ID        string `json:"id"`
-		Date      string `json:"date"`
-		ShortDate string `json:"shortdate"`
-		UserID    string `json:"userid"`
-		Error     string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "date":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Date = v
-		}
-	}
-	id, err := SetDeadline2D(session, rcp.Project, rcp.Name, rcp.Date)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	rcp.ID = id
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Deadline2D: %s", rcp.Date), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Deadline2D: %s\nProject: %s, Name: %s, Author: %s", rcp.Date, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	rcp.ShortDate = ToShortTime(rcp.Date) // 웹사이트에 렌더링시 사용한다.
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetDeadline3D 함수는 아이템의 3D 마감일을 설정한다.
-func handleAPISetDeadline3D(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project   string `json:"project"`
-		Name      string `json:"name"`
-		ID        string `json:"id"`
-		Date      string `json:"date"`
-		ShortDate string `json:"shortdate"`
-		UserID    string `json:"userid"`
-		Error     string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "date":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Date = v
-		}
-	}
-	id, err := SetDeadline3D(session, rcp.Project, rcp.Name, rcp.Date)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	rcp.ID = id
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Deadline3D: %s", rcp.Date), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Deadline3D: %s\nProject: %s, Name: %s, Author: %s", rcp.Date, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	rcp.ShortDate = ToShortTime(rcp.Date) // 웹사이트에 렌더링시 사용한다.
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetTaskPredate 함수는 아이템의 task에 대한 1차마감일을 설정한다.
-func handleAPISetTaskPredate(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project   string `json:"project"`
-		ID        string `json:"id"`
-		Date      string `json:"date"`
-		ShortDate string `json:"shortdate"`
-		Task      string `json:"task"`
-		UserID    string `json:"userid"`
-		Error     string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "id":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.ID = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "task":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Task = v
-		case "date":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Date = v
-		}
-	}
-	err = HasTask(session, rcp.Project, rcp.ID, rcp.Task)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	rcp.ID, err = SetTaskPredate(session, rcp.Project, rcp.ID, rcp.Task, rcp.Date)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set %s Task Pre Deadline: %s", rcp.Task, rcp.Date), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set %s Task Pre Deadline: %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Date, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	rcp.ShortDate = ToShortTime(rcp.Date)
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetTaskDate 함수는 아이템의 task에 대한 최종마감일을 설정한다.
-func handleAPISetTaskDate(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project   string `json:"project"`
-		ID        string `json:"id"`
-		Date      string `json:"date"`
-		ShortDate string `json:"shortdate"`
-		Task      string `json:"task"`
-		UserID    string `json:"userid"`
-		Error     string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "id":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.ID = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "task":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Task = v
-		case "date":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Date = v
-		}
-	}
-	err = HasTask(session, rcp.Project, rcp.ID, rcp.Task)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	err = SetTaskDate(session, rcp.Project, rcp.ID, rcp.Task, rcp.Date)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set %s Task Deadline: %s", rcp.Task, rcp.Date), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set %s Task Deadline: %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Date, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	rcp.ShortDate = ToShortTime(rcp.Date)
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetShotType 함수는 아이템의 shot type을 설정한다.
-func handleAPISetShotType(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		ID      string `json:"id"`
-		Type    string `json:"type"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, value := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, value)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, value)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, value)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "type", "shottype":
-			if len(value) == 0 {
-				rcp.Type = ""
-			} else {
-				rcp.Type = value[0]
-			}
-		}
-	}
-	id, err := SetShotType(session, rcp.Project, rcp.Name, rcp.Type)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	rcp.ID = id
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Shottype: %s", rcp.Type), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Shottype: %s\nProject: %s, Name: %s, Author: %s", rcp.Type, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	if rcp.Type == "" { // template에서 렌더링시에는 빈 문자열이면 눈에 보이지 않기 때문에 none으로 반환한다.
-		rcp.Type = "none"
-	}
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetUseType 함수는 아이템의 Usetype을 설정한다.
-func handleAPISetUseType(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Type    string `json:"type"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	ssid, _, err := TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.ID = id
-	typ := r.FormValue("type")
-	if typ == "" {
-		http.Error(w, "type을 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Type = typ
-	err = SetUseType(session, rcp.Project, rcp.ID, rcp.Type)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Usetype: %s", rcp.Type), rcp.Project, rcp.ID, "csi3", ssid, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Usetype: %s\nProject: %s, ID: %s, Author: %s", rcp.Type, rcp.Project, rcp.ID, ssid))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetOutputName 함수는 아이템의 shot의 아웃풋 이름을 설정합니다.
-func handleAPISetOutputName(w http.ResponseWriter, r *http.Request) {
-	defer r.Body.Close()
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
-		return
-	}
-	defer session.Close()
-	_, _, err = TokenHandler(r, session)
-	if err != nil {
-		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
-		return
-	}
-	r.ParseForm() // 받은 문자를 파싱합니다. 파싱되면 map이 됩니다.
-	var project string
-	var name string
-	var outputname string
-	args := r.PostForm
-	for key, value := range args {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, value)
-			if err != nil {
-				fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
-				return
-			}
-			project = v
-		case "name":
-			v, err := PostFormValueInList(key, value)
-			if err != nil {
-				fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
-				return
-			}
-			name = v
-		case "outputname":
-			v, err := PostFormValueInList(key, value)
-			if err != nil {
-				fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
-				return
-			}
-			outputname = v
-		}
-	}
-	err = SetOutputName(session, project, name, outputname)
-	if err != nil {
-		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
-		return
-	}
-	fmt.Fprintf(w, "{\"error\":\"\"}\n")
-}
-
-// handleAPISetRetimePlate 함수는 아이템의 retimeplate 값을 설정합니다.
-func handleAPISetRetimePlate(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Path    string `json:"path"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "path", "retimeplate", "plate":
-			if len(values) == 1 {
-				rcp.Path = values[0]
-			} else {
-				rcp.Path = ""
-			}
-		}
-	}
-	err = SetRetimePlate(session, rcp.Project, rcp.Name, rcp.Path)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Retime Plate: %s", rcp.Path), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Retime Plate: %s\nProject: %s, Name: %s, Author: %s", rcp.Path, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetOCIOcc 함수는 아이템의 OCIO .cc 파일을 설정합니다.
-func handleAPISetOCIOcc(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Path    string `json:"path"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "path", "cc", "ociocc", "ocio":
-			if len(values) == 1 {
-				rcp.Path = values[0]
-			} else {
-				rcp.Path = ""
-			}
-		}
-	}
-	err = SetOCIOcc(session, rcp.Project, rcp.Name, rcp.Path)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set OCIO .cc: %s", rcp.Path), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set OCIO .cc: %s\nProject: %s, Name: %s, Author: %s", rcp.Path, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetRollmedia 함수는 아이템의 Setellite Rollmedia를 설정합니다.
-func handleAPISetRollmedia(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project   string `json:"project"`
-		Name      string `json:"name"`
-		Rollmedia string `json:"rollmedia"`
-		UserID    string `json:"userid"`
-		Error     string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "rollmedia":
-			if len(values) == 1 {
-				rcp.Rollmedia = values[0]
-			} else {
-				rcp.Rollmedia = ""
-			}
-		}
-	}
-	err = SetRollmedia(session, rcp.Project, rcp.Name, rcp.Rollmedia)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Rollmedia: %s", rcp.Rollmedia), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Rollmedia: %s\nProject: %s, Name: %s, Author: %s", rcp.Rollmedia, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetScanname 함수는 아이템의 Scanname 값을 설정합니다.
-func handleAPISetScanname(w http.ResponseWriter, r *http.Request) {
+// handleAPISetTaskUser 함수는 아이템의 task에 대한 유저를 설정한다.
+func handleAPISetTaskUser(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
@@ -4612,8 +2259,12 @@ func handleAPISetScanname(w http.ResponseWriter, r *http.Request) {
 	type Recipe struct {
 		Project  string `json:"project"`
 		ID       string `json:"id"`
-		Scanname string `json:"scanname"`
+		Name     string `json:"name"`
+		Task     string `json:"task"`
+		Username string `json:"username"`
 		UserID   string `json:"userid"`
+		Warning  string `json:"warning"` // 오버부킹 경고
+		Error    string `json:"error"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -4633,59 +2284,83 @@ func handleAPISetScanname(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
+	for key, values := range r.PostForm {
+		switch key {
+		case "userid":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if rcp.UserID == "unknown" && v != "" {
+				rcp.UserID = v
+			}
+		case "project":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Project = v
+		case "name":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Name = v
+		case "task":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Task = v
+		case "user":
+			if len(values) == 1 {
+				rcp.Username = values[0]
+			}
+		}
 	}
-	rcp.ID = id
-	rcp.Scanname = r.FormValue("scanname")
-	err = SetScanname(session, rcp.Project, rcp.ID, rcp.Scanname)
+	id, warning, err := SetTaskUser(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Task, rcp.Username)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	rcp.ID = id
+	rcp.Warning = warning
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Scanname: %s", rcp.Scanname), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Task User: %s %s", rcp.Task, rcp.Username), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Scanname: %s\nProject: %s, Name: %s, Author: %s", rcp.Scanname, rcp.Project, rcp.ID, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Task User: %s %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Username, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	rcp.Username = userInfo(rcp.Username) // id(name,team) 문자열을 name,team으로 바꾼다. 웹에서 보기좋게 하기 위함.
+	data, _ := json.Marshal(rcp)
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetAssetType 함수는 아이템의 shot type을 설정한다.
-func handleAPISetAssetType(w http.ResponseWriter, r *http.Request) {
+// handleAPISetTaskStartdate 함수는 아이템의 task에 대한 시작일을 설정한다.
+func handleAPISetTaskStartdate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
 		Project string `json:"project"`
-		Name    string `json:"name"`
 		ID      string `json:"id"`
-		Type    string `json:"type"`
-		OldType string `json:"oldtype"`
+		Name    string `json:"name"`
+		Date    string `json:"date"`
+		Task    string `json:"task"`
 		UserID  string `json:"userid"`
 		Error   string `json:"error"`
 	}
@@ -4707,79 +2382,84 @@ func handleAPISetAssetType(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	r.ParseForm()
-	for key, value := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, value)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, value)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, value)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "type", "assettype":
-			v, err := PostFormValueInList(key, value)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Type = v
+	rcp.Project = r.FormValue("project")
+	if rcp.Project == "" {
+		if err != nil {
+			http.Error(w, "프로젝트가 빈 문자열 입니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Name = r.FormValue("name")
+	if rcp.Name == "" {
+		if err != nil {
+			http.Error(w, "name이 빈 문자열 입니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Task = r.FormValue("task")
+	if rcp.Task == "" {
+		if err != nil {
+			http.Error(w, "task가 빈 문자열 입니다", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Date = r.FormValue("date") // 마감일이 빈 문자열이 될 수 있다.
+	rcp.ID = r.FormValue("id")
+	if rcp.ID == "" {
+		typ, err := Type(session, rcp.Project, rcp.Name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
+		rcp.ID = rcp.Name + "_" + typ
+	}
+
+	err = HasTask(session, rcp.Project, rcp.ID, rcp.Task)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-	id, beforeType, _, err := SetAssetType(session, rcp.Project, rcp.Name, rcp.Type)
+	err = SetTaskStartdate(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, rcp.Date)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	rcp.ID = id
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Assettype: %s", rcp.Type), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set %s Task StartDate: %s", rcp.Task, rcp.Date), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Assettype: %s\nProject: %s, Name: %s, Author: %s", rcp.Type, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set %s Task StartDate: %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Date, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	rcp.OldType = beforeType // 브라우저에 기존에 드로잉된 에셋태그를 제거하기 위해서 사용한다.
-	data, _ := json.Marshal(rcp)
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetRnum 함수는 아이템에 롤넘버를 설정합니다.
-func handleAPISetRnum(w http.ResponseWriter, r *http.Request) {
+// handleAPISetTaskUserNote 함수는 아이템의 task에 대한 시작일을 설정한다.
+func handleAPISetTaskUserNote(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		ID      string `json:"id"`
-		Rnum    string `json:"rnum"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
+		Project  string `json:"project"`
+		Name     string `json:"name"`
+		Task     string `json:"task"`
+		UserNote string `json:"usernote"`
+		UserID   string `json:"userid"`
+		Error    string `json:"error"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -4798,7 +2478,7 @@ func handleAPISetRnum(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
-	r.ParseForm() // 받은 문자를 파싱합니다. 파싱되면 map이 됩니다.
+	r.ParseForm()
 	for key, values := range r.PostForm {
 		switch key {
 		case "project":
@@ -4824,32 +2504,34 @@ func handleAPISetRnum(w http.ResponseWriter, r *http.Request) {
 			if rcp.UserID == "unknown" && v != "" {
 				rcp.UserID = v
 			}
-		case "rnum":
-			if len(values) == 0 {
-				rcp.Rnum = ""
+		case "task":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Task = v
+		case "note", "usernote":
+			if len(values) != 1 {
+				rcp.UserNote = ""
 			} else {
-				rcp.Rnum = values[0]
+				rcp.UserNote = values[0]
 			}
 		}
 	}
-	if rcp.Rnum != "" && !regexpRnum.MatchString(rcp.Rnum) {
-		http.Error(w, rcp.Rnum+"값은 A0001 형식이 아닙니다.", http.StatusBadRequest)
-		return
-	}
-	id, err := SetRnum(session, rcp.Project, rcp.Name, rcp.Rnum)
+	err = SetTaskUserNote(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Task, rcp.UserNote)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	rcp.ID = id
 	// log
-	err = dilog.Add(*flagDBIP, host, "Set Rnum: "+rcp.Rnum, rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set %s Task UserNote: %s", rcp.Task, rcp.UserNote), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Rnum: %s\nProject: %s, Name: %s, Author: %s", rcp.Rnum, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set %s Task UserNote: %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.UserNote, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -4861,18 +2543,20 @@ func handleAPISetRnum(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
-// handleAPISetScanTimecodeIn 함수는 아이템에 Scan TimecodeIn 값을 설정한다.
-func handleAPISetScanTimecodeIn(w http.ResponseWriter, r *http.Request) {
+// handleAPISetDeadline2D 함수는 아이템의 2D 마감일을 설정한다.
+func handleAPISetDeadline2D(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
-		Project  string `json:"project"`
-		Name     string `json:"name"`
-		Timecode string `json:"timecode"`
-		UserID   string `json:"userid"`
-		Error    string `json:"error"`
+		Project   string `json:"project"`
+		Name      string `json:"name"`
+		ID        string `json:"id"`
+		Date      string `json:"date"`
+		ShortDate string `json:"shortdate"`
+		UserID    string `json:"userid"`
+		Error     string `json:"error"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -4917,50 +2601,55 @@ func handleAPISetScanTimecodeIn(w http.ResponseWriter, r *http.Request) {
 			if rcp.UserID == "unknown" && v != "" {
 				rcp.UserID = v
 			}
-		case "timecode":
-			if len(values) == 0 {
-				rcp.Timecode = ""
-			} else {
-				rcp.Timecode = values[0]
+		case "date":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
 			}
+			rcp.Date = v
 		}
 	}
-	err = SetScanTimecodeIn(session, rcp.Project, rcp.Name, rcp.Timecode)
+	id, err := SetDeadline2D(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Date)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	rcp.ID = id
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("ScanTimecodeIn: %s", rcp.Timecode), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Deadline2D: %s", rcp.Date), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("ScanTimecodeIn: %s\nProject: %s, Name: %s, Author: %s", rcp.Timecode, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Deadline2D: %s\nProject: %s, Name: %s, Author: %s", rcp.Date, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
+	rcp.ShortDate = ToShortTime(rcp.Date) // 웹사이트에 렌더링시 사용한다.
 	data, _ := json.Marshal(rcp)
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetScanTimecodeOut 함수는 아이템에 Scan TimecodeOut 값을 설정한다.
-func handleAPISetScanTimecodeOut(w http.ResponseWriter, r *http.Request) {
+// handleAPISetDeadline3D 함수는 아이템의 3D 마감일을 설정한다.
+func handleAPISetDeadline3D(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
-		Project  string `json:"project"`
-		Name     string `json:"name"`
-		Timecode string `json:"timecode"`
-		UserID   string `json:"userid"`
-		Error    string `json:"error"`
+		Project   string `json:"project"`
+		Name      string `json:"name"`
+		ID        string `json:"id"`
+		Date      string `json:"date"`
+		ShortDate string `json:"shortdate"`
+		UserID    string `json:"userid"`
+		Error     string `json:"error"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -5005,50 +2694,55 @@ func handleAPISetScanTimecodeOut(w http.ResponseWriter, r *http.Request) {
 			if rcp.UserID == "unknown" && v != "" {
 				rcp.UserID = v
 			}
-		case "timecode":
-			if len(values) == 0 {
-				rcp.Timecode = ""
-			} else {
-				rcp.Timecode = values[0]
+		case "date":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
 			}
+			rcp.Date = v
 		}
 	}
-	err = SetScanTimecodeOut(session, rcp.Project, rcp.Name, rcp.Timecode)
+	id, err := SetDeadline3D(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Date)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	rcp.ID = id
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("ScanTimecodeOut: %s", rcp.Timecode), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Deadline3D: %s", rcp.Date), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("ScanTimecodeOut: %s\nProject: %s, Name: %s, Author: %s", rcp.Timecode, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Deadline3D: %s\nProject: %s, Name: %s, Author: %s", rcp.Date, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
+	rcp.ShortDate = ToShortTime(rcp.Date) // 웹사이트에 렌더링시 사용한다.
 	data, _ := json.Marshal(rcp)
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetJustTimecodeIn 함수는 아이템에 Just TimecodeIn 값을 설정한다.
-func handleAPISetJustTimecodeIn(w http.ResponseWriter, r *http.Request) {
+// handleAPISetTaskPredate 함수는 아이템의 task에 대한 1차마감일을 설정한다.
+func handleAPISetTaskPredate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project  string `json:"project"`
-		Name     string `json:"name"`
-		Timecode string `json:"timecode"`
-		UserID   string `json:"userid"`
-		Error    string `json:"error"`
+		return
+	}
+	type Recipe struct {
+		Project   string `json:"project"`
+		ID        string `json:"id"`
+		Date      string `json:"date"`
+		ShortDate string `json:"shortdate"`
+		Task      string `json:"task"`
+		UserID    string `json:"userid"`
+		Error     string `json:"error"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -5077,13 +2771,13 @@ func handleAPISetJustTimecodeIn(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			rcp.Project = v
-		case "name":
+		case "id":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			rcp.Name = v
+			rcp.ID = v
 		case "userid":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
@@ -5093,50 +2787,66 @@ func handleAPISetJustTimecodeIn(w http.ResponseWriter, r *http.Request) {
 			if rcp.UserID == "unknown" && v != "" {
 				rcp.UserID = v
 			}
-		case "timecode":
-			if len(values) == 0 {
-				rcp.Timecode = ""
-			} else {
-				rcp.Timecode = values[0]
+		case "task":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Task = v
+		case "date":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
 			}
+			rcp.Date = v
 		}
 	}
-	err = SetJustTimecodeIn(session, rcp.Project, rcp.Name, rcp.Timecode)
+	err = HasTask(session, rcp.Project, rcp.ID, rcp.Task)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rcp.ID, err = SetTaskPredate(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, rcp.Date)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("JustTimecodeIn: %s", rcp.Timecode), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set %s Task Pre Deadline: %s", rcp.Task, rcp.Date), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("JustTimecodeIn: %s\nProject: %s, Name: %s, Author: %s", rcp.Timecode, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set %s Task Pre Deadline: %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Date, rcp.Project, rcp.ID, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
+	rcp.ShortDate = ToShortTime(rcp.Date)
 	data, _ := json.Marshal(rcp)
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetJustTimecodeOut 함수는 아이템에 Just TimecodeOut 값을 설정한다.
-func handleAPISetJustTimecodeOut(w http.ResponseWriter, r *http.Request) {
+// handleAPISetTaskDate 함수는 아이템의 task에 대한 최종마감일을 설정한다.
+func handleAPISetTaskDate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
-		Project  string `json:"project"`
-		Name     string `json:"name"`
-		Timecode string `json:"timecode"`
-		UserID   string `json:"userid"`
-		Error    string `json:"error"`
+		Project   string `json:"project"`
+		ID        string `json:"id"`
+		Date      string `json:"date"`
+		ShortDate string `json:"shortdate"`
+		Task      string `json:"task"`
+		UserID    string `json:"userid"`
+		Error     string `json:"error"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -5165,13 +2875,13 @@ func handleAPISetJustTimecodeOut(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			rcp.Project = v
-		case "name":
+		case "id":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			rcp.Name = v
+			rcp.ID = v
 		case "userid":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
@@ -5181,50 +2891,71 @@ func handleAPISetJustTimecodeOut(w http.ResponseWriter, r *http.Request) {
 			if rcp.UserID == "unknown" && v != "" {
 				rcp.UserID = v
 			}
-		case "timecode":
-			if len(values) == 0 {
-				rcp.Timecode = ""
-			} else {
-				rcp.Timecode = values[0]
+		case "task":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Task = v
+		case "date":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
 			}
+			rcp.Date = v
 		}
 	}
-	err = SetJustTimecodeOut(session, rcp.Project, rcp.Name, rcp.Timecode)
+	err = HasTask(session, rcp.Project, rcp.ID, rcp.Task)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = SetTaskDate(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, rcp.Date)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("JustTimecodeOut: %s", rcp.Timecode), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set %s Task Deadline: %s", rcp.Task, rcp.Date), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("JustTimecodeOut: %s\nProject: %s, Name: %s, Author: %s", rcp.Timecode, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set %s Task Deadline: %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Date, rcp.Project, rcp.ID, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
+	rcp.ShortDate = ToShortTime(rcp.Date)
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
-// handleAPISetFinver 함수는 아이템에 파이널 버전값을 설정한다.
-func handleAPISetFinver(w http.ResponseWriter, r *http.Request) {
+// handleAPISetShotType 함수는 아이템의 shot type을 설정한다.
+func handleAPISetShotType(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "type", "shottype")
+}
+
+// handleAPISetUseType 함수는 아이템의 Usetype을 설정한다.
+func handleAPISetUseType(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
 	}
 	type Recipe struct {
 		Project string `json:"project"`
-		Name    string `json:"name"`
-		Version string `json:"version"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
+		ID      string `json:"id"`
+		Type    string `json:"type"`
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -5233,7 +2964,7 @@ func handleAPISetFinver(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
+	ssid, _, err := TokenHandler(r, session)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
@@ -5244,59 +2975,41 @@ func handleAPISetFinver(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "version":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Version = v
-
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		}
+	project := r.FormValue("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Project = project
+	id := r.FormValue("id")
+	if id == "" {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = id
+	typ := r.FormValue("type")
+	if typ == "" {
+		http.Error(w, "type을 설정해주세요", http.StatusBadRequest)
+		return
 	}
-	err = SetFinver(session, rcp.Project, rcp.Name, rcp.Version)
+	rcp.Type = typ
+	err = SetUseType(session, rcp.Project, rcp.ID, ssid, rcp.Type)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Finversion: %s", rcp.Version), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Usetype: %s", rcp.Type), rcp.Project, rcp.ID, "csi3", ssid, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Finversion: %s\nProject: %s, Name: %s, Author: %s", rcp.Version, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Usetype: %s\nProject: %s, ID: %s, Author: %s", rcp.Type, rcp.Project, rcp.ID, ssid))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	// json 으로 결과 전송
 	data, err := json.Marshal(rcp)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -5307,8 +3020,85 @@ func handleAPISetFinver(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
-// handleAPISetFindate 함수는 데이터가 최종으로 나간 날짜를 설정한다.
-func handleAPISetFindate(w http.ResponseWriter, r *http.Request) {
+// handleAPISetOutputName 함수는 아이템의 shot의 아웃풋 이름을 설정합니다.
+func handleAPISetOutputName(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	r.ParseForm() // 받은 문자를 파싱합니다. 파싱되면 map이 됩니다.
+	var project string
+	var name string
+	var outputname string
+	args := r.PostForm
+	for key, value := range args {
+		switch key {
+		case "project":
+			v, err := PostFormValueInList(key, value)
+			if err != nil {
+				fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+				return
+			}
+			project = v
+		case "name":
+			v, err := PostFormValueInList(key, value)
+			if err != nil {
+				fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+				return
+			}
+			name = v
+		case "outputname":
+			v, err := PostFormValueInList(key, value)
+			if err != nil {
+				fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+				return
+			}
+			outputname = v
+		}
+	}
+	err = SetOutputName(session, project, name, userID, outputname)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	fmt.Fprintf(w, "{\"error\":\"\"}\n")
+}
+
+// handleAPISetRetimePlate 함수는 아이템의 retimeplate 값을 설정합니다.
+func handleAPISetRetimePlate(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "path", "retimeplate")
+}
+
+// handleAPISetOCIOcc 함수는 아이템의 OCIO .cc 파일을 설정합니다.
+func handleAPISetOCIOcc(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "path", "ociocc")
+}
+
+// handleAPISetRollmedia 함수는 아이템의 Setellite Rollmedia를 설정합니다.
+func handleAPISetRollmedia(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "rollmedia", "rollmedia")
+}
+
+// handleAPISetScanname 함수는 아이템의 Scanname 값을 설정합니다.
+func handleAPISetScanname(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "scanname", "scanname")
+}
+
+// handleAPISetAssetType 함수는 아이템의 shot type을 설정한다.
+func handleAPISetAssetType(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
@@ -5316,7 +3106,9 @@ func handleAPISetFindate(w http.ResponseWriter, r *http.Request) {
 	type Recipe struct {
 		Project string `json:"project"`
 		Name    string `json:"name"`
-		Date    string `json:"date"`
+		ID      string `json:"id"`
+		Type    string `json:"type"`
+		OldType string `json:"oldtype"`
 		UserID  string `json:"userid"`
 		Error   string `json:"error"`
 	}
@@ -5337,60 +3129,102 @@ func handleAPISetFindate(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
-	r.ParseForm() // 받은 문자를 파싱합니다. 파싱되면 map이 됩니다.
-	for key, values := range r.PostForm {
+	r.ParseForm()
+	for key, value := range r.PostForm {
 		switch key {
 		case "project":
-			v, err := PostFormValueInList(key, values)
+			v, err := PostFormValueInList(key, value)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
 			rcp.Project = v
 		case "name":
-			v, err := PostFormValueInList(key, values)
+			v, err := PostFormValueInList(key, value)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
 			rcp.Name = v
-		case "date":
-			v, err := PostFormValueInList(key, values)
+		case "userid":
+			v, err := PostFormValueInList(key, value)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			rcp.Date = v
+			if rcp.UserID == "unknown" && v != "" {
+				rcp.UserID = v
+			}
+		case "type", "assettype":
+			v, err := PostFormValueInList(key, value)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Type = v
 		}
 	}
-	err = SetFindate(session, rcp.Project, rcp.Name, rcp.Date)
+	id, beforeType, _, err := SetAssetType(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Type)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	rcp.ID = id
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set FinDate: %s", rcp.Date), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Assettype: %s", rcp.Type), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set FinDate: %s\nProject: %s, Name: %s, Author: %s", rcp.Date, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Assettype: %s\nProject: %s, Name: %s, Author: %s", rcp.Type, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	rcp.OldType = beforeType // 브라우저에 기존에 드로잉된 에셋태그를 제거하기 위해서 사용한다.
+	data, _ := json.Marshal(rcp)
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
 
+// handleAPISetRnum 함수는 아이템에 롤넘버를 설정합니다.
+func handleAPISetRnum(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "rnum", "rnum")
+}
+
+// handleAPISetScanTimecodeIn 함수는 아이템에 Scan TimecodeIn 값을 설정한다.
+func handleAPISetScanTimecodeIn(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "timecode", "scantimecodein")
+}
+
+// handleAPISetScanTimecodeOut 함수는 아이템에 Scan TimecodeOut 값을 설정한다.
+func handleAPISetScanTimecodeOut(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "timecode", "scantimecodeout")
+}
+
+// handleAPISetJustTimecodeIn 함수는 아이템에 Just TimecodeIn 값을 설정한다.
+func handleAPISetJustTimecodeIn(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "timecode", "justtimecodein")
+}
+
+// handleAPISetJustTimecodeOut 함수는 아이템에 Just TimecodeOut 값을 설정한다.
+func handleAPISetJustTimecodeOut(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "timecode", "justtimecodeout")
+}
+
+// handleAPISetFinver 함수는 아이템에 파이널 버전값을 설정한다.
+func handleAPISetFinver(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "version", "finver")
+}
+
+// handleAPISetFindate 함수는 데이터가 최종으로 나간 날짜를 설정한다.
+func handleAPISetFindate(w http.ResponseWriter, r *http.Request) {
+	handleAPIItemFieldForm(w, r, "date", "findate")
+}
+
 // handleAPISetCrowdAsset 함수는 CrowdAsset을 설정한다.
 func handleAPISetCrowdAsset(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -5440,7 +3274,7 @@ func handleAPISetCrowdAsset(w http.ResponseWriter, r *http.Request) {
 			rcp.Name = v
 		}
 	}
-	id, crowdType, err := SetCrowdAsset(session, rcp.Project, rcp.Name)
+	id, crowdType, err := SetCrowdAsset(session, rcp.Project, rcp.Name, rcp.UserID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -5524,7 +3358,7 @@ func handleAPIAddTag(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	rcp.Tag = tag
-	rcp.Name, err = AddTag(session, rcp.Project, rcp.ID, rcp.Tag)
+	rcp.Name, err = AddTag(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Tag)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -5600,7 +3434,7 @@ func handleAPIRenameTag(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	rcp.After = strings.Replace(after, " ", "", -1) // 빈 공백을 제거한다.
-	err = RenameTag(session, rcp.Project, rcp.Before, rcp.After)
+	err = RenameTag(session, rcp.Project, rcp.UserID, rcp.Before, rcp.After)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -5684,7 +3518,7 @@ func handleAPIRmTag(w http.ResponseWriter, r *http.Request) {
 	}
 	rcp.Tag = tag
 	rcp.IsContain = str2bool(r.FormValue("iscontain"))
-	rcp.Name, err = RmTag(session, rcp.Project, rcp.ID, rcp.Tag, rcp.IsContain)
+	rcp.Name, err = RmTag(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Tag, rcp.IsContain)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -5971,7 +3805,7 @@ func handleAPIEditComment(w http.ResponseWriter, r *http.Request) {
 	rcp.Text = text
 	rcp.Media = r.FormValue("media")
 	rcp.MediaTitle = r.FormValue("mediatitle")
-	rcp.Name, err = EditComment(session, rcp.Project, rcp.ID, rcp.Time, rcp.AuthorName, rcp.Text, rcp.MediaTitle, rcp.Media)
+	rcp.Name, err = EditComment(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Time, rcp.AuthorName, rcp.Text, rcp.MediaTitle, rcp.Media)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -6242,7 +4076,7 @@ func handleAPIRmSource(w http.ResponseWriter, r *http.Request) {
 			rcp.Title = v
 		}
 	}
-	id, err := RmSource(session, rcp.Project, rcp.Name, rcp.Title)
+	id, err := RmSource(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Title)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -6432,7 +4266,7 @@ func handleAPIRmReference(w http.ResponseWriter, r *http.Request) {
 			rcp.Title = v
 		}
 	}
-	id, err := RmReference(session, rcp.Project, rcp.Name, rcp.Title)
+	id, err := RmReference(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Title)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -6719,7 +4553,7 @@ func handleAPISetTaskLevel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = setTaskLevel(session, rcp.Project, rcp.Name, rcp.Task, rcp.Level)
+	err = setTaskLevel(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Task, rcp.Level)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -6940,50 +4774,52 @@ func handleAPIMailInfo(w http.ResponseWriter, r *http.Request) {
 	}
 	rcp.Title = i.Name
 	for key := range i.Tasks {
-		if regexpUserInfo.MatchString(i.Tasks[key].User) { // "khw7096(김한웅,2D1)" 패턴이면... 앞 문자열이 ID이다.
-			id := strings.Split(i.Tasks[key].User, "(")[0]
-			u, err := getUser(session, id)
-			if err != nil {
-				continue
-			}
-			if !regexpEmail.MatchString(u.Email) {
-				continue
-			}
-			// Task 아티스트의 메일을 메일리스트에 넣는다.
-			rcp.Mails = append(rcp.Mails, fmt.Sprintf("%s%s<%s>", u.LastNameKor, u.FirstNameKor, u.Email))
-			// Task 아티스트의 팀명을 찾는다.
-			var teamName string
-			for _, o := range u.Organizations {
-				if o.Primary {
-					teamName = o.Team.Name
-					break
+		for _, user := range i.Tasks[key].Users() { // 여러 아티스트가 작업하는 Task라면 모든 아티스트에게 메일을 보낸다.
+			if regexpUserInfo.MatchString(user) { // "khw7096(김한웅,2D1)" 패턴이면... 앞 문자열이 ID이다.
+				id := strings.Split(user, "(")[0]
+				u, err := getUser(session, id)
+				if err != nil {
+					continue
 				}
-				teamName = o.Team.Name
-
-			}
-			// 만약 팀명이 선언되어있지 않다면, 팀장리스트를 구하지 않는다.
-			if teamName == "" {
-				continue
-			}
-			// Task 아티스트의 팀장을 구한다.
-			leaderlist1, err := searchUsers(session, []string{teamName, "팀장"})
-			if err != nil {
-				continue
-			}
-			leaderlist2, err := searchUsers(session, []string{teamName, "Lead"})
-			if err != nil {
-				continue
-			}
-			// 팀장의 이메일을 참조에 추가한다. 만약 기존 메일리스트에 메일값이 중복되어 있다면, 제거한다.
-			for _, leader := range append(leaderlist1, leaderlist2...) {
-				has := false
-				for _, email := range append(rcp.Mails, rcp.Cc...) {
-					if email == leader.Email {
-						has = true
+				if !regexpEmail.MatchString(u.Email) {
+					continue
+				}
+				// Task 아티스트의 메일을 메일리스트에 넣는다.
+				rcp.Mails = append(rcp.Mails, fmt.Sprintf("%s%s<%s>", u.LastNameKor, u.FirstNameKor, u.Email))
+				// Task 아티스트의 팀명을 찾는다.
+				var teamName string
+				for _, o := range u.Organizations {
+					if o.Primary {
+						teamName = o.Team.Name
+						break
 					}
+					teamName = o.Team.Name
+
 				}
-				if !has {
-					rcp.Cc = append(rcp.Cc, fmt.Sprintf("%s%s<%s>", leader.LastNameKor, leader.FirstNameKor, leader.Email))
+				// 만약 팀명이 선언되어있지 않다면, 팀장리스트를 구하지 않는다.
+				if teamName == "" {
+					continue
+				}
+				// Task 아티스트의 팀장을 구한다.
+				leaderlist1, err := searchUsers(session, []string{teamName, "팀장"})
+				if err != nil {
+					continue
+				}
+				leaderlist2, err := searchUsers(session, []string{teamName, "Lead"})
+				if err != nil {
+					continue
+				}
+				// 팀장의 이메일을 참조에 추가한다. 만약 기존 메일리스트에 메일값이 중복되어 있다면, 제거한다.
+				for _, leader := range append(leaderlist1, leaderlist2...) {
+					has := false
+					for _, email := range append(rcp.Mails, rcp.Cc...) {
+						if email == leader.Email {
+							has = true
+						}
+					}
+					if !has {
+						rcp.Cc = append(rcp.Cc, fmt.Sprintf("%s%s<%s>", leader.LastNameKor, leader.FirstNameKor, leader.Email))
+					}
 				}
 			}
 		}
@@ -7330,7 +5166,7 @@ func handleAPIAddTaskPublish(w http.ResponseWriter, r *http.Request) {
 		IsOutput:      rcp.IsOutput,
 		AuthorNameKor: rcp.AuthorNameKor,
 	}
-	err = addTaskPublish(session, project, name, task, key, p)
+	err = addTaskPublish(session, project, name, rcp.UserID, task, key, p)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -7415,7 +5251,7 @@ func handleAPIRmTaskPublishKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	rcp.Key = key
-	err = rmTaskPublishKey(session, project, id, task, key)
+	err = rmTaskPublishKey(session, project, id, rcp.UserID, task, key)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -7576,7 +5412,7 @@ func handleAPIRmTaskPublish(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// 에러처리가 끝나면 해당 publish를 지운다.
-	err = rmTaskPublish(session, project, id, task, key, createtime, path)
+	err = rmTaskPublish(session, project, id, rcp.UserID, task, key, createtime, path)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -7690,7 +5526,7 @@ func handleAPISetTaskPublishStatus(w http.ResponseWriter, r *http.Request) {
 			i.Tasks[task].Publishes[key][n].Status = rcp.Status
 		}
 	}
-	err = setItem(session, project, i)
+	err = setItem(session, project, rcp.UserID, i)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -7720,226 +5556,17 @@ func handleAPISetTaskPublishStatus(w http.ResponseWriter, r *http.Request) {
 
 // handleAPISetSeason 함수는 아이템의 season 값을 설정한다.
 func handleAPISetSeason(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Season  string `json:"season"`
-		UserID  string `json:"userid"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.ID = id
-	rcp.Season = r.FormValue("season")
-
-	err = SetSeason(session, rcp.Project, rcp.ID, rcp.Season)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Season: %s", rcp.Season), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Season: %s\nProject: %s, ID: %s, Author: %s", rcp.Season, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	handleAPIItemFieldForm(w, r, "season", "season")
 }
 
 // handleAPISetEpisode 함수는 아이템의 episode 값을 설정한다.
 func handleAPISetEpisode(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		ID      string `json:"id"`
-		Episode string `json:"episode"`
-		UserID  string `json:"userid"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.ID = id
-	rcp.Episode = r.FormValue("episode")
-
-	err = SetEpisode(session, rcp.Project, rcp.ID, rcp.Episode)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Episode: %s", rcp.Episode), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Episode: %s\nProject: %s, ID: %s, Author: %s", rcp.Episode, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	handleAPIItemFieldForm(w, r, "episode", "episode")
 }
 
 // handleAPISetOverscanRatio 함수는 아이템의 OverscanRatio 값을 설정한다.
 func handleAPISetOverscanRatio(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project       string  `json:"project"`
-		ID            string  `json:"id"`
-		OverscanRatio float64 `json:"overscanratio"`
-		UserID        string  `json:"userid"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	project := r.FormValue("project")
-	if project == "" {
-		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.Project = project
-
-	id := r.FormValue("id")
-	if id == "" {
-		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
-		return
-	}
-	rcp.ID = id
-	ratio, err := strconv.ParseFloat(r.FormValue("ratio"), 64)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	rcp.OverscanRatio = ratio
-
-	err = SetOverscanRatio(session, rcp.Project, rcp.ID, rcp.OverscanRatio)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set OverscanRatio: %f", rcp.OverscanRatio), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set OverscanRatio: %f\nProject: %s, ID: %s, Author: %s", rcp.OverscanRatio, rcp.Project, rcp.ID, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, err := json.Marshal(rcp)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	handleAPIItemFieldForm(w, r, "ratio", "overscanratio")
 }
 
 // handleAPI2RenderSize 함수는 아이템에 RenderSize를 설정한다.
@@ -7995,7 +5622,7 @@ func handleAPI2SetRenderSize(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	rcp.Size = size
-	err = SetImageSizeVer2(session, rcp.Project, rcp.ID, "rendersize", rcp.Size)
+	err = SetImageSizeVer2(session, rcp.Project, rcp.ID, rcp.UserID, "rendersize", rcp.Size)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
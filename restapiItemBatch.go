@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// handleAPIItemsBatch 함수는 여러 아이템에 수정 작업 리스트를 한번에 적용하는 핸들러이다.
+// 모든 작업을 먼저 검사하고 하나라도 에러가 있으면 아무것도 쓰지 않는다. dryrun이면 바뀔 내용만 반환한다.
+// 아이템마다 바뀐 필드만 $set 하므로 아이템을 읽은 뒤 다른 곳에서 바뀐 필드를 덮어쓰지 않는다.
+func handleAPIItemsBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		Project    string               `json:"project"`
+		DryRun     bool                 `json:"dryrun"`
+		Operations []ItemBatchOperation `json:"operations"`
+		Results    []ItemBatchResult    `json:"results"`
+		UserID     string               `json:"userid"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	err = json.NewDecoder(r.Body).Decode(&rcp)
+	if err != nil {
+		http.Error(w, "JSON 형식이 잘못되었습니다: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if rcp.UserID == "" || userID != "unknown" {
+		rcp.UserID = userID
+	}
+	if rcp.Project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	if len(rcp.Operations) == 0 {
+		http.Error(w, "operations를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	err = HasProject(session, rcp.Project)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	globalStatus, err := AllStatus(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	items := make(map[string]*Item)
+	for _, id := range itemBatchIDs(rcp.Operations) {
+		item, err := getItem(session, rcp.Project, id)
+		if err != nil {
+			continue // 없는 아이템은 applyItemBatch에서 아이템별 에러로 보고된다.
+		}
+		items[id] = &item
+	}
+	results, ok := applyItemBatch(items, rcp.Operations, globalStatus)
+	for i := range results {
+		for j := range results[i].Changes {
+			results[i].Changes[j].Project = rcp.Project
+			results[i].Changes[j].Author = rcp.UserID
+			results[i].Changes[j].Source = "ItemsBatch"
+		}
+	}
+	rcp.Results = results
+	status := http.StatusOK
+	if !ok {
+		// 하나라도 검사에 실패하면 아무것도 쓰지 않고 아이템별 결과를 반환한다.
+		status = http.StatusBadRequest
+	} else if !rcp.DryRun {
+		// 모든 아이템의 업데이트 문서를 먼저 만들고 바뀐 필드만 저장한다.
+		updates := make(map[string]bson.M)
+		for _, result := range rcp.Results {
+			if len(result.Changes) == 0 {
+				continue
+			}
+			updates[result.ID] = itemBatchUpdate(result.Changes, items[result.ID].Updatetime)
+		}
+		for i, result := range rcp.Results {
+			update, ok := updates[result.ID]
+			if !ok {
+				continue
+			}
+			if status != http.StatusOK {
+				// 앞의 아이템 저장에 실패하면 나머지 아이템은 저장하지 않는다.
+				rcp.Results[i].Error = "앞의 아이템 저장에 실패하여 저장하지 않았습니다"
+				continue
+			}
+			err = updateItem(session, rcp.Project, result.ID, rcp.UserID, "ItemsBatch", update)
+			if err != nil {
+				rcp.Results[i].Error = err.Error()
+				status = http.StatusInternalServerError
+			}
+		}
+		// settaskuser는 /api/settaskuser와 같이 저장 후 오버부킹 경고를 계산한다.
+		if status == http.StatusOK {
+			for i, result := range rcp.Results {
+				for _, op := range rcp.Operations {
+					if op.Op != "settaskuser" || !inStrings(op.IDs, result.ID) {
+						continue
+					}
+					warning, err := OverbookWarning(session, rcp.Project, result.ID, strings.ToLower(op.Task), op.Value)
+					if err != nil {
+						log.Println(err) // 배정은 이미 저장되었으므로 로그만 출력한다.
+						continue
+					}
+					if warning != "" {
+						rcp.Results[i].Warnings = append(rcp.Results[i].Warnings, warning)
+					}
+				}
+			}
+		}
+		// log
+		err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Items Batch: %d operations, %d items", len(rcp.Operations), len(rcp.Results)), rcp.Project, "", "csi3", rcp.UserID, 180)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// slack log
+		err = slacklog(session, rcp.Project, fmt.Sprintf("Items Batch: %d operations, %d items\nProject: %s, Author: %s", len(rcp.Operations), len(rcp.Results), rcp.Project, rcp.UserID))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/digital-idea/dilog"
+	"github.com/digital-idea/ditime"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ItemGroupDetail 자료구조는 시퀀스, 에피소드와 진행상황을 같이 반환할 때 사용한다.
+type ItemGroupDetail struct {
+	ItemGroup
+	Progress ItemGroupProgress `json:"progress"`
+}
+
+// setItemGroupForm 함수는 폼으로 들어온 설명, 담당자, 상태, 마감일을 ItemGroup과 update에 설정한다. 폼에 없는 값은 바꾸지 않는다.
+func setItemGroupForm(session *mgo.Session, r *http.Request, g *ItemGroup, update bson.M) error {
+	if _, has := r.PostForm["description"]; has {
+		g.Description = r.FormValue("description")
+		update["description"] = g.Description
+	}
+	if _, has := r.PostForm["supervisor"]; has {
+		g.Supervisor = r.FormValue("supervisor")
+		update["supervisor"] = g.Supervisor
+	}
+	if _, has := r.PostForm["status"]; has {
+		status := r.FormValue("status")
+		if status != "" {
+			allStatus, err := AllStatus(session)
+			if err != nil {
+				return err
+			}
+			found := false
+			for _, s := range allStatus {
+				if s.ID == status {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("%s 상태는 존재하지 않습니다", status)
+			}
+		}
+		g.StatusV2 = status
+		update["statusv2"] = g.StatusV2
+	}
+	for _, key := range []string{"ddline2d", "ddline3d"} {
+		if _, has := r.PostForm[key]; !has {
+			continue
+		}
+		date := ""
+		if r.FormValue(key) != "" {
+			fullTime, err := ditime.ToFullTime(19, r.FormValue(key))
+			if err != nil {
+				return err
+			}
+			date = fullTime
+		}
+		if key == "ddline2d" {
+			g.Ddline2d = date
+		} else {
+			g.Ddline3d = date
+		}
+		update[key] = date
+	}
+	return nil
+}
+
+// itemGroupFromRequest 함수는 폼의 project, name 으로 시퀀스, 에피소드를 가지고 온다. 실패하면 에러를 응답하고 false를 반환한다.
+func itemGroupFromRequest(w http.ResponseWriter, session *mgo.Session, kind, project, name string) (ItemGroup, bool) {
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return ItemGroup{}, false
+	}
+	if name == "" {
+		http.Error(w, "name을 설정해주세요", http.StatusBadRequest)
+		return ItemGroup{}, false
+	}
+	g, err := getItemGroup(session, project, kind, name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return g, false
+	}
+	return g, true
+}
+
+// logItemGroup 함수는 시퀀스, 에피소드 변경 내용을 로그와 슬랙에 남긴다.
+func logItemGroup(session *mgo.Session, r *http.Request, g ItemGroup, userID, msg string) error {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return err
+	}
+	err = dilog.Add(*flagDBIP, host, msg, g.Project, g.Name, "csi3", userID, 180)
+	if err != nil {
+		return err
+	}
+	return slacklog(session, g.Project, fmt.Sprintf("%s\nProject: %s, Author: %s", msg, g.Project, userID))
+}
+
+// writeItemGroupJSON 함수는 v를 json으로 응답한다.
+func writeItemGroupJSON(w http.ResponseWriter, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIAddItemGroup 함수는 시퀀스(/api/addsequence), 에피소드(/api/addepisode)를 추가하는 핸들러이다.
+func handleAPIAddItemGroup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if accessLevel < LeadAccessLevel {
+		http.Error(w, "시퀀스, 에피소드를 추가할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	now := time.Now().Format(time.RFC3339)
+	g := ItemGroup{
+		ID:         bson.NewObjectId(),
+		Project:    r.FormValue("project"),
+		Kind:       itemGroupKind(r.URL.Path),
+		Name:       r.FormValue("name"),
+		Comments:   []Comment{},
+		Createtime: now,
+		Updatetime: now,
+	}
+	err = g.CheckError()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = setItemGroupForm(session, r, &g, bson.M{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = addItemGroup(session, g)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = logItemGroup(session, r, g, userID, fmt.Sprintf("Add %s: %s", g.Kind, g.Name))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeItemGroupJSON(w, g)
+}
+
+// handleAPIEditItemGroup 함수는 시퀀스(/api/editsequence), 에피소드(/api/editepisode)의 설명, 담당자, 상태, 마감일을 수정하는 핸들러이다. 입력하지 않은 값은 바꾸지 않는다.
+func handleAPIEditItemGroup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	g, ok := itemGroupFromRequest(w, session, itemGroupKind(r.URL.Path), r.FormValue("project"), r.FormValue("name"))
+	if !ok {
+		return
+	}
+	if !g.CanEdit(userID, accessLevel) {
+		http.Error(w, "시퀀스, 에피소드를 수정할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	update := bson.M{}
+	err = setItemGroupForm(session, r, &g, update)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	g.Updatetime = time.Now().Format(time.RFC3339)
+	update["updatetime"] = g.Updatetime
+	err = setItemGroupFields(session, g.ID, update)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = logItemGroup(session, r, g, userID, fmt.Sprintf("Edit %s: %s", g.Kind, g.Name))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeItemGroupJSON(w, g)
+}
+
+// handleAPIRmItemGroup 함수는 시퀀스(/api/rmsequence), 에피소드(/api/rmepisode)를 삭제하는 핸들러이다. 속한 아이템은 삭제되지 않는다.
+func handleAPIRmItemGroup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if accessLevel < LeadAccessLevel {
+		http.Error(w, "시퀀스, 에피소드를 삭제할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	g, ok := itemGroupFromRequest(w, session, itemGroupKind(r.URL.Path), r.FormValue("project"), r.FormValue("name"))
+	if !ok {
+		return
+	}
+	err = rmItemGroup(session, g.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = logItemGroup(session, r, g, userID, fmt.Sprintf("Rm %s: %s", g.Kind, g.Name))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeItemGroupJSON(w, g)
+}
+
+// handleAPIAddItemGroupComment 함수는 시퀀스(/api/addsequencecomment), 에피소드(/api/addepisodecomment)에 코멘트를 추가하는 핸들러이다.
+func handleAPIAddItemGroupComment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	// 사용자의 이름을 구한다.
+	u, err := getUser(session, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	g, ok := itemGroupFromRequest(w, session, itemGroupKind(r.URL.Path), r.FormValue("project"), r.FormValue("name"))
+	if !ok {
+		return
+	}
+	cmt := Comment{
+		ID:         bson.NewObjectId().Hex(),
+		Date:       time.Now().Format(time.RFC3339),
+		Author:     userID,
+		AuthorName: u.LastNameKor + u.FirstNameKor,
+		Text:       r.FormValue("text"),
+		Media:      r.FormValue("media"),
+		MediaTitle: r.FormValue("mediatitle"),
+	}
+	if cmt.Text == "" && cmt.Media == "" {
+		http.Error(w, "comment(text) 또는 첨부파일(media) 값 둘중 하나는 반드시 입력되어야 합니다", http.StatusBadRequest)
+		return
+	}
+	err = addItemGroupComment(session, g.ID, cmt, cmt.Date)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = logItemGroup(session, r, g, userID, fmt.Sprintf("Add %s Comment: %s\n%s", g.Kind, g.Name, cmt.Text))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeItemGroupJSON(w, cmt)
+}
+
+// handleAPIItemGroup 함수는 시퀀스(/api/sequence), 에피소드(/api/episode)와 속한 아이템의 진행상황을 반환하는 핸들러이다.
+func handleAPIItemGroup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	g, ok := itemGroupFromRequest(w, session, itemGroupKind(r.URL.Path), q.Get("project"), q.Get("name"))
+	if !ok {
+		return
+	}
+	progress, err := ItemGroupProgressOf(session, g)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeItemGroupJSON(w, ItemGroupDetail{ItemGroup: g, Progress: progress})
+}
+
+// handleAPIItemGroups 함수는 프로젝트의 시퀀스(/api/sequences), 에피소드(/api/episodes) 리스트와 진행상황을 반환하는 핸들러이다.
+func handleAPIItemGroups(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	groups, err := searchItemGroups(session, project, itemGroupKind(r.URL.Path), q.Get("status"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	results := []ItemGroupDetail{}
+	for _, g := range groups {
+		progress, err := ItemGroupProgressOf(session, g)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		results = append(results, ItemGroupDetail{ItemGroup: g, Progress: progress})
+	}
+	writeItemGroupJSON(w, results)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIItemHistory 함수는 아이템의 필드 변경 기록을 시간순으로 반환하는 핸들러이다.
+func handleAPIItemHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	id := q.Get("id")
+	if id == "" {
+		http.Error(w, "id(SS_0010_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	histories, err := getItemHistory(session, project, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// field가 설정되면 해당 필드와 하위 필드의 기록만 반환한다. 예) tasks.comp
+	field := q.Get("field")
+	if field != "" {
+		results := []ItemHistory{}
+		for _, h := range histories {
+			if h.Field == field || strings.HasPrefix(h.Field, field+".") {
+				results = append(results, h)
+			}
+		}
+		histories = results
+	}
+	data, err := json.Marshal(histories)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIRestoreItemField 함수는 변경 기록 하나의 필드를 변경 전 값으로 되돌리는 핸들러이다.
+func handleAPIRestoreItemField(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	id := r.FormValue("id")
+	if id == "" {
+		http.Error(w, "id(변경 기록 ID)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	h, err := restoreItemField(session, id, userID, accessLevel)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Restore Field: %s, %v", h.Field, h.Old), h.Project, h.ItemID, "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, h.Project, fmt.Sprintf("Restore Field: %s, %v\nProject: %s, ID: %s, Author: %s", h.Field, h.Old, h.Project, h.ItemID, userID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIRestoreItem 함수는 아이템 전체를 지정한 시간의 상태로 되돌리는 핸들러이다. 삭제된 아이템도 복원할 수 있다.
+func handleAPIRestoreItem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		Project string `json:"project"`
+		ID      string `json:"id"`
+		Time    string `json:"time"`
+		UserID  string `json:"userid"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	var level AccessLevel
+	rcp.UserID, level, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	// 아이템 전체를 바꾸므로 아이템 삭제와 같은 권한이 필요하다.
+	if PmAccessLevel > level {
+		http.Error(w, "권한이 낮아서 아이템을 복원할 수 없습니다", http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	rcp.Project = r.FormValue("project")
+	if rcp.Project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = r.FormValue("id")
+	if rcp.ID == "" {
+		http.Error(w, "id(SS_0010_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Time = r.FormValue("time")
+	t, err := time.Parse(time.RFC3339, rcp.Time)
+	if err != nil {
+		http.Error(w, "time은 2006-01-02T15:04:05+09:00 형식이어야 합니다", http.StatusBadRequest)
+		return
+	}
+	err = restoreItem(session, rcp.Project, rcp.ID, rcp.UserID, t)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Restore Item: %s", rcp.Time), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Restore Item: %s\nProject: %s, ID: %s, Author: %s", rcp.Time, rcp.Project, rcp.ID, rcp.UserID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
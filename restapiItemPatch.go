@@ -0,0 +1,189 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIV4Item 함수는 PATCH /api/v4/item/{project}/{id} 로 json merge 문서를 받아 아이템의 여러 필드를 수정하는 핸들러이다.
+func handleAPIV4Item(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		http.Error(w, "Patch Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		Project string   `json:"project"`
+		ID      string   `json:"id"`
+		Fields  []string `json:"fields"`
+		UserID  string   `json:"userid"`
+	}
+	rcp := Recipe{}
+	paths := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v4/item/"), "/")
+	if len(paths) != 2 || paths[0] == "" || paths[1] == "" {
+		http.Error(w, "/api/v4/item/{project}/{id} 형태로 요청해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Project = paths[0]
+	rcp.ID = paths[1]
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	var level AccessLevel
+	rcp.UserID, level, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	patch := make(map[string]json.RawMessage)
+	err = json.NewDecoder(r.Body).Decode(&patch)
+	if err != nil {
+		http.Error(w, "JSON 형식이 잘못되었습니다: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(patch) == 0 {
+		http.Error(w, "수정할 필드를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Fields, err = PatchItem(session, rcp.Project, rcp.ID, rcp.UserID, level, patch)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var changes []string
+	for _, f := range rcp.Fields {
+		changes = append(changes, fmt.Sprintf("%s: %s", f, patch[f]))
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, "Patch Item: "+strings.Join(changes, ", "), rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Patch Item: %s\nProject: %s, ID: %s, Author: %s", strings.Join(changes, ", "), rcp.Project, rcp.ID, rcp.UserID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIItemFieldForm 함수는 필드 하나를 수정하는 기존 form API를 PatchItem으로 처리한다.
+// formKey는 값을 받는 form 키, field는 아이템의 json 필드명이다. 아이템은 id 또는 name으로 찾는다.
+// 응답에는 기존 API와 같이 project, name, id, userid, error 와 formKey 값이 들어간다.
+func handleAPIItemFieldForm(w http.ResponseWriter, r *http.Request, formKey, field string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	project := r.FormValue("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	name := r.FormValue("name")
+	id := r.FormValue("id")
+	if id == "" {
+		if name == "" {
+			http.Error(w, "name 또는 id를 설정해주세요", http.StatusBadRequest)
+			return
+		}
+		typ, err := Type(session, project, name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		id = name + "_" + typ
+	}
+	value, err := itemPatchFormValue(field, r.FormValue(formKey))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rcp := map[string]interface{}{
+		"project": project,
+		"name":    name,
+		"id":      id,
+		"userid":  userID,
+		"error":   "",
+		formKey:   value,
+	}
+	if field != formKey {
+		rcp[field] = value
+	}
+	// 기존 SetFrame 함수와 같이 프레임 -1은 값을 바꾸지 않는다.
+	if !(formKey == "frame" && string(value) == "-1") {
+		// 기존 API는 토큰만 확인하고 권한 레벨은 검사하지 않았다. 권한 레벨은 /api/v4/item 에서만 검사한다.
+		_, err = PatchItem(session, project, id, userID, AdminAccessLevel, map[string]json.RawMessage{field: value})
+		if _, ok := err.(ItemPatchError); ok {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// log
+		err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set %s: %s", field, value), project, id, "csi3", userID, 180)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// slack log
+		err = slacklog(session, project, fmt.Sprintf("Set %s: %s\nProject: %s, ID: %s, Author: %s", field, value, project, id, userID))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	// json 으로 결과 전송
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+)
+
+// plateFromForm 함수는 폼으로 들어온 값으로 Plate를 만든다. 입력하지 않은 프레임은 0 이다.
+func plateFromForm(r *http.Request) (Plate, error) {
+	p := Plate{
+		Type:            r.FormValue("type"),
+		Scanname:        r.FormValue("scanname"),
+		Dataname:        r.FormValue("dataname"),
+		Platesize:       r.FormValue("platesize"),
+		Platepath:       r.FormValue("platepath"),
+		Scantime:        time.Now().Format(time.RFC3339),
+		ScanTimecodeIn:  r.FormValue("scantimecodein"),
+		ScanTimecodeOut: r.FormValue("scantimecodeout"),
+		JustTimecodeIn:  r.FormValue("justtimecodein"),
+		JustTimecodeOut: r.FormValue("justtimecodeout"),
+	}
+	if p.Dataname == "" {
+		p.Dataname = p.Scanname // 보통 스캔네임과 데이터네임은 같다.
+	}
+	for _, tc := range []string{p.ScanTimecodeIn, p.ScanTimecodeOut, p.JustTimecodeIn, p.JustTimecodeOut} {
+		if tc != "" && !regexpTimecode.MatchString(tc) {
+			return p, fmt.Errorf("%s 는 00:00:00:00 형식의 타임코드가 아닙니다", tc)
+		}
+	}
+	frames := []struct {
+		key   string
+		value *int
+	}{
+		{"scanframe", &p.ScanFrame},
+		{"scanin", &p.ScanIn},
+		{"scanout", &p.ScanOut},
+		{"platein", &p.PlateIn},
+		{"plateout", &p.PlateOut},
+		{"justin", &p.JustIn},
+		{"justout", &p.JustOut},
+	}
+	for _, f := range frames {
+		if r.FormValue(f.key) == "" {
+			continue
+		}
+		n, err := strconv.Atoi(r.FormValue(f.key))
+		if err != nil {
+			return p, fmt.Errorf("%s 는 숫자로 입력되어야 합니다", f.key)
+		}
+		*f.value = n
+	}
+	return p, p.CheckError()
+}
+
+// handleAPIPlates 함수는 아이템의 플레이트 버전 리스트와 사용중인 플레이트를 반환하는 핸들러이다.
+func handleAPIPlates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		Project     string  `json:"project"`
+		ID          string  `json:"id"`
+		ActivePlate string  `json:"activeplate"`
+		Plates      []Plate `json:"plates"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	rcp.Project = q.Get("project")
+	if rcp.Project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = q.Get("id")
+	if rcp.ID == "" {
+		http.Error(w, "id(SS_0010_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Plates, rcp.ActivePlate, err = Plates(session, rcp.Project, rcp.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIPlate 함수는 플레이트 버전을 추가(/api/addplate), 삭제(/api/rmplate)하거나 사용할 플레이트를 설정(/api/setactiveplate)하는 핸들러이다.
+func handleAPIPlate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		Project     string  `json:"project"`
+		ID          string  `json:"id"`
+		Type        string  `json:"type"`
+		ActivePlate string  `json:"activeplate"`
+		Plates      []Plate `json:"plates"`
+		UserID      string  `json:"userid"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	rcp.UserID, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	rcp.Project = r.FormValue("project")
+	if rcp.Project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = r.FormValue("id")
+	if rcp.ID == "" {
+		http.Error(w, "id(SS_0010_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Type = r.FormValue("type")
+	if rcp.Type == "" {
+		http.Error(w, "type(org1)을 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	if rcp.UserID == "unknown" && r.FormValue("userid") != "" {
+		rcp.UserID = r.FormValue("userid")
+	}
+	var item Item
+	var msg string
+	switch r.URL.Path {
+	case "/api/addplate":
+		p, err := plateFromForm(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		item, err = AddPlate(session, rcp.Project, rcp.ID, rcp.UserID, p, str2bool(r.FormValue("active")))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		msg = fmt.Sprintf("Add Plate: %s, Scanname: %s, ScanTimecode: %s / %s, Plate Range: %d - %d, Platesize: %s", p.Type, p.Scanname, p.ScanTimecodeIn, p.ScanTimecodeOut, p.PlateIn, p.PlateOut, p.Platesize)
+	case "/api/setactiveplate":
+		item, err = SetActivePlate(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Type)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		msg = "Set Active Plate: " + rcp.Type
+	case "/api/rmplate":
+		item, err = RmPlate(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Type)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		msg = "Rm Plate: " + rcp.Type
+	default:
+		http.Error(w, "지원하지 않는 URL입니다", http.StatusNotFound)
+		return
+	}
+	rcp.ActivePlate = item.ActivePlate
+	rcp.Plates = item.Plates
+	// log
+	err = dilog.Add(*flagDBIP, host, msg, rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, rcp.Project, fmt.Sprintf("%s\nProject: %s, ID: %s, Author: %s", msg, rcp.Project, rcp.ID, rcp.UserID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,599 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// checkPlaylistReviews 함수는 리뷰 ID 리스트가 모두 존재하고 플레이리스트와 같은 프로젝트인지 체크한다.
+func checkPlaylistReviews(session *mgo.Session, project string, ids []string) error {
+	for _, id := range ids {
+		if !bson.IsObjectIdHex(id) {
+			return fmt.Errorf("%s 는 리뷰 ID 형식이 아닙니다", id)
+		}
+		review, err := getReview(session, id)
+		if err != nil {
+			return fmt.Errorf("%s 리뷰를 가지고 올 수 없습니다: %v", id, err)
+		}
+		if review.Project != project {
+			return fmt.Errorf("%s 리뷰는 %s 프로젝트 리뷰가 아닙니다", id, project)
+		}
+	}
+	return nil
+}
+
+// playlistFromRequest 함수는 요청의 id 값으로 플레이리스트를 가지고 온다. 에러가 발생하면 응답을 작성하고 false를 반환한다.
+func playlistFromRequest(w http.ResponseWriter, session *mgo.Session, id string) (Playlist, bool) {
+	if !bson.IsObjectIdHex(id) {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return Playlist{}, false
+	}
+	p, err := getPlaylist(session, id)
+	if err == mgo.ErrNotFound {
+		http.Error(w, id+" 플레이리스트가 존재하지 않습니다", http.StatusNotFound)
+		return p, false
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return p, false
+	}
+	return p, true
+}
+
+// handleAPIAddPlaylist 함수는 플레이리스트를 추가하는 핸들러이다.
+func handleAPIAddPlaylist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	now := time.Now()
+	p := Playlist{
+		ID:         bson.NewObjectId(),
+		Project:    r.FormValue("project"),
+		Title:      r.FormValue("title"),
+		Owner:      userID,
+		Date:       r.FormValue("date"),
+		Attendees:  commaList(r.FormValue("attendees")),
+		Reviews:    commaList(r.FormValue("reviews")),
+		Createtime: now.Format(time.RFC3339),
+		Updatetime: now.Format(time.RFC3339),
+	}
+	if p.Date == "" {
+		p.Date = now.Format("2006-01-02")
+	}
+	if p.Reviews == nil {
+		p.Reviews = []string{}
+	}
+	err = p.CheckError()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = checkPlaylistReviews(session, p.Project, p.Reviews)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = addPlaylist(session, p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("AddPlaylist: %s, %s", p.Title, p.Date), p.Project, p.ID.Hex(), "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIEditPlaylist 함수는 플레이리스트의 제목, 날짜, 참석자를 수정하는 핸들러이다. 입력하지 않은 값은 바꾸지 않는다.
+func handleAPIEditPlaylist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	p, ok := playlistFromRequest(w, session, r.FormValue("id"))
+	if !ok {
+		return
+	}
+	if !p.CanEdit(userID, accessLevel) {
+		http.Error(w, "플레이리스트를 수정할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	update := bson.M{}
+	if _, has := r.PostForm["title"]; has {
+		p.Title = r.FormValue("title")
+		update["title"] = p.Title
+	}
+	if _, has := r.PostForm["date"]; has {
+		p.Date = r.FormValue("date")
+		update["date"] = p.Date
+	}
+	if _, has := r.PostForm["attendees"]; has {
+		p.Attendees = commaList(r.FormValue("attendees"))
+		update["attendees"] = p.Attendees
+	}
+	err = p.CheckError()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	p.Updatetime = time.Now().Format(time.RFC3339)
+	update["updatetime"] = p.Updatetime
+	err = setPlaylistFields(session, p.ID.Hex(), update)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIPlaylist 함수는 id를 받아서 플레이리스트를 반환하는 핸들러이다.
+func handleAPIPlaylist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	p, ok := playlistFromRequest(w, session, r.URL.Query().Get("id"))
+	if !ok {
+		return
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIPlaylists 함수는 프로젝트, 날짜로 플레이리스트를 검색하는 핸들러이다.
+func handleAPIPlaylists(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	playlists, err := searchPlaylists(session, q.Get("project"), q.Get("date"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(playlists)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIRmPlaylist 함수는 플레이리스트를 삭제하는 핸들러이다. 세션에서 작성된 코멘트는 리뷰에 남는다.
+func handleAPIRmPlaylist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	p, ok := playlistFromRequest(w, session, r.FormValue("id"))
+	if !ok {
+		return
+	}
+	if !p.CanEdit(userID, accessLevel) {
+		http.Error(w, "플레이리스트를 삭제할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	err = rmPlaylist(session, p.ID.Hex())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("RmPlaylist: %s, %s", p.Title, p.Date), p.Project, p.ID.Hex(), "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIPlaylistReviews 함수는 플레이리스트의 리뷰 순서를 바꾸거나 리뷰를 추가, 삭제하는 핸들러이다.
+// /api/reorderplaylist, /api/addplaylistreview, /api/rmplaylistreview 에서 사용한다.
+func handleAPIPlaylistReviews(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	p, ok := playlistFromRequest(w, session, r.FormValue("id"))
+	if !ok {
+		return
+	}
+	if !p.CanEdit(userID, accessLevel) {
+		http.Error(w, "플레이리스트를 수정할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	var reviews []string
+	switch r.URL.Path {
+	case "/api/reorderplaylist":
+		reviews, err = reorderList(p.Reviews, commaList(r.FormValue("reviews")))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case "/api/addplaylistreview":
+		review := r.FormValue("review")
+		if p.HasReview(review) {
+			http.Error(w, review+" 리뷰는 이미 플레이리스트에 존재합니다", http.StatusBadRequest)
+			return
+		}
+		err = checkPlaylistReviews(session, p.Project, []string{review})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		index := -1 // index를 입력하지 않으면 마지막에 추가한다.
+		if r.FormValue("index") != "" {
+			index, err = strconv.Atoi(r.FormValue("index"))
+			if err != nil {
+				http.Error(w, "index는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+				return
+			}
+		}
+		reviews = insertList(append([]string{}, p.Reviews...), review, index)
+	case "/api/rmplaylistreview":
+		review := r.FormValue("review")
+		if !p.HasReview(review) {
+			http.Error(w, review+" 리뷰는 플레이리스트에 존재하지 않습니다", http.StatusBadRequest)
+			return
+		}
+		reviews = removeList(p.Reviews, review)
+	default:
+		http.Error(w, "지원하지 않는 경로입니다", http.StatusNotFound)
+		return
+	}
+	err = setPlaylistReviews(session, p.ID.Hex(), p.Reviews, reviews)
+	if err == mgo.ErrNotFound {
+		http.Error(w, "다른 사용자가 플레이리스트를 수정했습니다. 다시 시도해주세요", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	p.Reviews = reviews
+	data, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIPlaylistSession 함수는 데일리 세션의 동기화 상태를 반환하는 핸들러이다.
+// revision 값을 입력하면 상태가 바뀌었는지 changed 값으로 알려준다. 세션 참석자는 이 API를 주기적으로 호출한다.
+func handleAPIPlaylistSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		ID      string          `json:"id"`
+		Changed bool            `json:"changed"`
+		Session PlaylistSession `json:"session"`
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	p, ok := playlistFromRequest(w, session, q.Get("id"))
+	if !ok {
+		return
+	}
+	rcp := Recipe{ID: p.ID.Hex(), Changed: true, Session: p.Session}
+	if q.Get("revision") != "" {
+		revision, err := strconv.Atoi(q.Get("revision"))
+		if err != nil {
+			http.Error(w, "revision은 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+		rcp.Changed = revision != p.Session.Revision
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPISetPlaylistSession 함수는 데일리 세션의 현재 리뷰, 프레임, 재생 상태를 설정하는 핸들러이다.
+func handleAPISetPlaylistSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	p, ok := playlistFromRequest(w, session, r.FormValue("id"))
+	if !ok {
+		return
+	}
+	if !p.CanControl(userID, accessLevel) {
+		http.Error(w, "데일리 세션을 진행할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	// 입력하지 않은 값은 현재 세션 상태를 유지한다.
+	s := p.Session
+	s.Host = userID
+	if _, has := r.PostForm["active"]; has {
+		s.Active = str2bool(r.FormValue("active"))
+	}
+	if _, has := r.PostForm["playing"]; has {
+		s.Playing = str2bool(r.FormValue("playing"))
+	}
+	if _, has := r.PostForm["current"]; has {
+		current := r.FormValue("current")
+		if current != "" && !p.HasReview(current) {
+			http.Error(w, current+" 리뷰는 플레이리스트에 존재하지 않습니다", http.StatusBadRequest)
+			return
+		}
+		if current != s.Current {
+			s.Frame = 1 // 리뷰가 바뀌면 첫 프레임부터 본다.
+		}
+		s.Current = current
+	}
+	if r.FormValue("frame") != "" {
+		s.Frame, err = strconv.Atoi(r.FormValue("frame"))
+		if err != nil || s.Frame < 1 {
+			http.Error(w, "frame은 1 이상의 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+	}
+	if s.Active && s.Current == "" && len(p.Reviews) > 0 {
+		// 세션을 시작할 때 현재 리뷰가 없다면 첫번째 리뷰부터 시작한다.
+		s.Current = p.Reviews[0]
+		s.Frame = 1
+	}
+	p, err = setPlaylistSession(session, p.ID.Hex(), s)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(p.Session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIAddPlaylistNote 함수는 데일리 세션에서 작성한 노트를 리뷰의 Comment로 추가하는 핸들러이다.
+// 코멘트에는 플레이리스트 ID가 기록되어 어떤 세션에서 작성된 노트인지 알 수 있다.
+func handleAPIAddPlaylistNote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	u, err := getUser(session, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	p, ok := playlistFromRequest(w, session, r.FormValue("id"))
+	if !ok {
+		return
+	}
+	reviewID := r.FormValue("review")
+	if reviewID == "" {
+		reviewID = p.Session.Current // 리뷰를 입력하지 않으면 세션에서 현재 보고 있는 리뷰에 노트를 남긴다.
+	}
+	if !p.HasReview(reviewID) {
+		http.Error(w, reviewID+" 리뷰는 플레이리스트에 존재하지 않습니다", http.StatusBadRequest)
+		return
+	}
+	review, err := getReview(session, reviewID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cmt := Comment{
+		ID:         bson.NewObjectId().Hex(),
+		Date:       time.Now().Format(time.RFC3339),
+		Author:     userID,
+		AuthorName: u.LastNameKor + u.FirstNameKor,
+		Text:       r.FormValue("text"),
+		Media:      r.FormValue("media"),
+		MediaTitle: r.FormValue("mediatitle"),
+		Stage:      review.Stage,
+		Playlist:   p.ID.Hex(),
+	}
+	if cmt.Text == "" && cmt.Media == "" {
+		http.Error(w, "comment(text) 또는 첨부파일(media) 값 둘중 하나는 반드시 입력되어야 합니다", http.StatusBadRequest)
+		return
+	}
+	if r.FormValue("frame") != "" {
+		cmt.Frame, err = strconv.Atoi(r.FormValue("frame"))
+		if err != nil {
+			http.Error(w, "frame은 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+	}
+	err = addReviewComment(session, reviewID, cmt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Add Playlist Note: %s, %s, %s", p.Title, reviewID, cmt.Text), review.Project, review.Name, "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, review.Project, fmt.Sprintf("Add Review Comment(%s): %s, \nProject: %s, Name: %s, Author: %s", p.Title, cmt.Text, review.Project, review.Name, userID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(cmt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
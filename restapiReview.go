@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"time"
@@ -71,13 +72,16 @@ func handleAPIAddReview(w http.ResponseWriter, r *http.Request) {
 	rcp.Review.Task = task
 	typ := r.FormValue("type")
 	if typ == "" {
-		rcp.Review.Type = "clip"
-
+		typ = "clip"
+	}
+	if !(typ == "clip" || typ == "image" || typ == "sequence") {
+		http.Error(w, "type은 clip, image, sequence 값만 사용가능합니다", http.StatusBadRequest)
+		return
 	}
 	rcp.Review.Type = typ
 	ext := r.FormValue("ext")
 	if ext == "" {
-		rcp.Review.Ext = ".mp4"
+		ext = ".mp4"
 	}
 	rcp.Review.Ext = ext
 	stage := r.FormValue("stage")
@@ -132,7 +136,37 @@ func handleAPIAddReview(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "path를 설정해주세요", http.StatusBadRequest)
 		return
 	}
-	if _, err := os.Stat(path); os.IsNotExist(err) {
+	if typ == "sequence" {
+		// sequence 타입은 shot.####.exr 형태의 프레임 패턴과 프레임 구간을 사용한다.
+		pattern, err := seqPattern(path)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if _, err := os.Stat(filepath.Dir(pattern)); os.IsNotExist(err) {
+			http.Error(w, filepath.Dir(pattern)+"경로가 서버에 존재하지 않습니다", http.StatusBadRequest)
+			return
+		}
+		rcp.Review.FrameIn, err = strconv.Atoi(r.FormValue("framein"))
+		if err != nil {
+			http.Error(w, "framein은 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+		rcp.Review.FrameOut, err = strconv.Atoi(r.FormValue("frameout"))
+		if err != nil {
+			http.Error(w, "frameout은 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+		if rcp.Review.FrameIn > rcp.Review.FrameOut {
+			http.Error(w, "framein은 frameout보다 클 수 없습니다", http.StatusBadRequest)
+			return
+		}
+		if len(missingFrames(pattern, rcp.Review.FrameIn, rcp.Review.FrameOut)) == rcp.Review.FrameOut-rcp.Review.FrameIn+1 {
+			http.Error(w, fmt.Sprintf("%s 경로에 %d-%d 구간의 프레임이 하나도 존재하지 않습니다", path, rcp.Review.FrameIn, rcp.Review.FrameOut), http.StatusBadRequest)
+			return
+		}
+		path = pattern
+	} else if _, err := os.Stat(path); os.IsNotExist(err) {
 		http.Error(w, path+"파일이 서버에 존재하지 않습니다", http.StatusBadRequest)
 		return
 	}
@@ -147,6 +181,7 @@ func handleAPIAddReview(w http.ResponseWriter, r *http.Request) {
 	rcp.Review.Status = "wait"
 	rcp.Review.Description = r.FormValue("description")
 	rcp.Review.CameraInfo = r.FormValue("camerainfo")
+	rcp.Review.InColorspace = r.FormValue("incolorspace")
 	progress := r.FormValue("progress")
 	if progress != "" {
 		n, err := strconv.Atoi(progress)
@@ -189,6 +224,14 @@ func handleAPIAddReview(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// 연산 큐에 등록하고 대기중인 worker를 바로 깨운다.
+	err = addReviewJob(session, rcp.Review)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Review.ProcessStatus = "queued"
+	reviewQueue.Notify()
 
 	// log
 	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("AddReview: %s, %s, %s", rcp.Review.Name, rcp.Review.Task, rcp.Review.Path), rcp.Review.Project, rcp.Review.Name, "csi3", rcp.UserID, 180)
@@ -299,10 +342,12 @@ func handleAPISetReviewStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	type Recipe struct {
-		UserID string `json:"userid"`
-		ID     string `json:"id"`
-		Status string `json:"status"`
-		Stage  string `json:"stage"`
+		UserID   string        `json:"userid"`
+		ID       string        `json:"id"`
+		Status   string        `json:"status"`
+		Stage    string        `json:"stage"`
+		Override bool          `json:"override"` // 해결되지 않은 수정사항이 있어도 approve 한다. 슈퍼바이저 이상 권한이 필요하다.
+		Changes  []ItemHistory `json:"changes"`  // 최종 Stage에서 approve 되어 아이템에 반영된 변경사항
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -311,7 +356,8 @@ func handleAPISetReviewStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
+	var accessLevel AccessLevel
+	rcp.UserID, accessLevel, err = TokenHandler(r, session)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
@@ -335,15 +381,35 @@ func handleAPISetReviewStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	rcp.Status = status
+	rcp.Override = str2bool(r.FormValue("override"))
 	review, err := getReview(session, rcp.ID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	rcp.Stage = review.Stage
-	// 만약 approve라면 task mov를 설정한다. 샷 또는 에셋이 없을 때 에러가 나더라도 에러처리하지 않는다.
+	// 해결되지 않은 수정사항이 있다면 approve 할 수 없다.
+	if rcp.Status == "approve" {
+		err = review.CheckApprove(rcp.Override, accessLevel)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+	}
 	if rcp.Status == "approve" {
-		_, _ = setTaskMov(session, review.Project, review.Name, review.Task, review.Path)
+		// Stage가 삭제되었다면 최종 Stage가 아닌것으로 처리한다.
+		stage, _ := GetStage(session, review.Stage)
+		if stage.Final {
+			// 최종 Stage라면 아이템 Task 상태, Task mov, Publish 상태에 반영하고 기록을 남긴다.
+			rcp.Changes, err = propagateReviewApproval(session, review, stage, rcp.UserID)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		} else {
+			// 만약 approve라면 task mov를 설정한다. 샷 또는 에셋이 없을 때 에러가 나더라도 에러처리하지 않는다.
+			_, _ = setTaskMov(session, review.Project, review.Name, rcp.UserID, review.Task, review.Path)
+		}
 	}
 
 	// 리뷰 상태를 설정한다.
@@ -353,7 +419,7 @@ func handleAPISetReviewStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Review Status: %s, %s", rcp.ID, rcp.Status), review.Project, review.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Review Status: %s, %s, override: %t", rcp.ID, rcp.Status, rcp.Override), review.Project, review.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -381,9 +447,11 @@ func handleAPISetReviewNextStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	type Recipe struct {
-		UserID string `json:"userid"`
-		ID     string `json:"id"`
-		Status string `json:"status"`
+		UserID   string        `json:"userid"`
+		ID       string        `json:"id"`
+		Status   string        `json:"status"`
+		Override bool          `json:"override"` // 해결되지 않은 수정사항이 있어도 approve 한다. 슈퍼바이저 이상 권한이 필요하다.
+		Changes  []ItemHistory `json:"changes"`  // 최종 Stage에서 approve 되어 아이템에 반영된 변경사항
 	}
 	rcp := Recipe{}
 	session, err := mgo.Dial(*flagDBIP)
@@ -392,7 +460,8 @@ func handleAPISetReviewNextStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
+	var accessLevel AccessLevel
+	rcp.UserID, accessLevel, err = TokenHandler(r, session)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
@@ -420,13 +489,28 @@ func handleAPISetReviewNextStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	rcp.Status = stage.NextStatus
+	rcp.Override = str2bool(r.FormValue("override"))
+	// 해결되지 않은 수정사항이 있다면 approve 할 수 없다.
+	if rcp.Status == "approve" {
+		err = review.CheckApprove(rcp.Override, accessLevel)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		// 최종 Stage라면 아이템 Task 상태, Task mov, Publish 상태에 반영하고 기록을 남긴다.
+		rcp.Changes, err = propagateReviewApproval(session, review, stage, rcp.UserID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 	err = setReviewStatus(session, rcp.ID, rcp.Status)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Review Status: %s, %s", rcp.ID, rcp.Status), review.Project, review.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Review Status: %s, %s, override: %t", rcp.ID, rcp.Status, rcp.Override), review.Project, review.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -623,6 +707,7 @@ func handleAPIAddReviewComment(w http.ResponseWriter, r *http.Request) {
 	type Recipe struct {
 		UserID               string `json:"userid"`
 		ID                   string `json:"id"`
+		CommentID            string `json:"commentid"`
 		Text                 string `json:"text"`
 		Media                string `json:"media"`
 		MediaTitle           string `json:"mediatitle"`
@@ -707,6 +792,8 @@ func handleAPIAddReviewComment(w http.ResponseWriter, r *http.Request) {
 		rcp.Frame = frame
 	}
 	cmt := Comment{}
+	cmt.ID = bson.NewObjectId().Hex()
+	rcp.CommentID = cmt.ID
 	cmt.Date = time.Now().Format(time.RFC3339)
 	rcp.Date = cmt.Date
 	cmt.Author = rcp.UserID
@@ -717,6 +804,12 @@ func handleAPIAddReviewComment(w http.ResponseWriter, r *http.Request) {
 	cmt.MediaTitle = rcp.MediaTitle
 	cmt.Stage = rcp.Stage
 	cmt.Frame = rcp.Frame
+	// 상태를 기록하는 코멘트처럼 수정사항이 아닌 코멘트는 해결된 상태로 등록한다.
+	if str2bool(r.FormValue("resolved")) {
+		cmt.Resolved = true
+		cmt.ResolvedBy = rcp.UserID
+		cmt.ResolvedTime = cmt.Date
+	}
 
 	err = addReviewComment(session, rcp.ID, cmt)
 	if err != nil {
@@ -747,6 +840,244 @@ func handleAPIAddReviewComment(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
+// handleAPIAddReviewAnnotation 함수는 프레임의 영역을 가리키는 코멘트 또는 코멘트의 답글을 추가하는 RestAPI 이다.
+func handleAPIAddReviewAnnotation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	u, err := getUser(session, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	id := r.FormValue("id")
+	if id == "" {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	review, err := getReview(session, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cmt := Comment{
+		ID:         bson.NewObjectId().Hex(),
+		Date:       time.Now().Format(time.RFC3339),
+		Author:     userID,
+		AuthorName: u.LastNameKor + u.FirstNameKor,
+		Text:       r.FormValue("text"),
+		Media:      r.FormValue("media"),
+		MediaTitle: r.FormValue("mediatitle"),
+		Stage:      review.Stage,
+		Parent:     r.FormValue("parent"),
+	}
+	if cmt.Text == "" {
+		http.Error(w, "text를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	if r.FormValue("frame") != "" {
+		cmt.Frame, err = strconv.Atoi(r.FormValue("frame"))
+		if err != nil {
+			http.Error(w, "frame은 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+	}
+	cmt.Region.Type = r.FormValue("shape")
+	for key, value := range map[string]*float64{"x": &cmt.Region.X, "y": &cmt.Region.Y, "width": &cmt.Region.Width, "height": &cmt.Region.Height} {
+		if r.FormValue(key) == "" {
+			continue
+		}
+		*value, err = strconv.ParseFloat(r.FormValue(key), 64)
+		if err != nil {
+			http.Error(w, key+"는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+	}
+	err = cmt.Region.CheckError()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// 답글이라면 부모 코멘트가 존재해야 하고, 프레임을 입력하지 않으면 부모 코멘트의 프레임을 사용한다.
+	if cmt.Parent != "" {
+		hasParent := false
+		for _, c := range review.Comments {
+			if c.Key() != cmt.Parent {
+				continue
+			}
+			if c.Parent != "" {
+				http.Error(w, "답글에는 답글을 달 수 없습니다", http.StatusBadRequest)
+				return
+			}
+			hasParent = true
+			if r.FormValue("frame") == "" {
+				cmt.Frame = c.Frame
+			}
+		}
+		if !hasParent {
+			http.Error(w, cmt.Parent+" 코멘트가 존재하지 않습니다", http.StatusBadRequest)
+			return
+		}
+	}
+	err = addReviewComment(session, id, cmt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Add Review Annotation: %s, %s", id, cmt.Text), review.Project, review.Name, "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, review.Project, fmt.Sprintf("Add Review Comment: %s, \nProject: %s, Name: %s, Author: %s", cmt.Text, review.Project, review.Name, userID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(cmt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIResolveReviewComment 함수는 리뷰 코멘트를 해결 또는 미해결 상태로 바꾸는 RestAPI 이다.
+func handleAPIResolveReviewComment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	id := r.FormValue("id")
+	if id == "" {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	key := r.FormValue("comment")
+	if key == "" {
+		http.Error(w, "comment를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	resolved := true
+	if r.FormValue("resolved") != "" {
+		resolved = str2bool(r.FormValue("resolved"))
+	}
+	review, err := getReview(session, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cmt, err := resolveReviewComment(session, id, key, resolved, userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Resolve Review Comment: %s, %s, %t", id, key, resolved), review.Project, review.Name, "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(cmt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIReviewThreads 함수는 리뷰 코멘트를 스레드로 묶어서 반환하는 RestAPI 이다.
+// frame을 입력하면 해당 프레임의 스레드만 반환한다.
+func handleAPIReviewThreads(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		ID        string          `json:"id"`
+		OpenNotes int             `json:"opennotes"` // 해결되지 않은 수정사항 개수
+		Threads   []CommentThread `json:"threads"`
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	id := q.Get("id")
+	if !bson.IsObjectIdHex(id) {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	review, err := getReview(session, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp := Recipe{ID: id, OpenNotes: len(review.OpenNotes()), Threads: []CommentThread{}}
+	for _, t := range commentThreads(review.Comments) {
+		if q.Get("frame") != "" && strconv.Itoa(t.Frame) != q.Get("frame") {
+			continue
+		}
+		rcp.Threads = append(rcp.Threads, t)
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
 // handleAPIEditReviewComment 함수는 리뷰에서 코멘트를 수정합니다.
 func handleAPIEditReviewComment(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -946,13 +1277,17 @@ func handleAPIRmReview(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	// 데이터 삭제
-	reviewfile := fmt.Sprintf("%s/%s%s", CachedAdminSetting.ReviewDataPath, review.Ext, rcp.ID)
-	if _, err := os.Stat(reviewfile); err == nil {
-		err = os.Remove(reviewfile)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+	// 데이터 삭제. 슬레이트 버전이 있다면 같이 삭제한다.
+	for _, reviewfile := range []string{
+		reviewDataFile(CachedAdminSetting, rcp.ID, review.Ext, false),
+		reviewDataFile(CachedAdminSetting, rcp.ID, review.Ext, true),
+	} {
+		if _, err := os.Stat(reviewfile); err == nil {
+			err = os.Remove(reviewfile)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
 		}
 	}
 	// log
@@ -974,6 +1309,11 @@ func handleAPIRmReview(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	err = rmReviewJob(session, rcp.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	// json 으로 결과 전송
 	data, err := json.Marshal(rcp)
 	if err != nil {
@@ -1560,6 +1900,10 @@ func handleAPISetReviewProcessStatus(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// wait 상태가 되면 scheduler가 다시 큐에 등록할 수 있도록 깨운다.
+	if rcp.Status == "wait" {
+		reviewQueue.Notify()
+	}
 	// json 으로 결과 전송
 	data, err := json.Marshal(rcp)
 	if err != nil {
@@ -1720,6 +2064,7 @@ func handleAPIUploadReviewDrawing(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+		publishEvent(session, reviewEvent(session, EventReviewUpdate, id, EventChange{Field: "sketches", New: review.Sketches}))
 		rcp.Data = review
 	}
 	data, err := json.Marshal(rcp)
@@ -1790,6 +2135,7 @@ func handleAPIRmReviewDrawing(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	publishEvent(session, reviewEvent(session, EventReviewUpdate, rcp.ID, EventChange{Field: "sketches", New: review.Sketches}))
 
 	// 썸네일 이미지가 존재한다면 이미지 파일을 지운다.
 	imgPath := fmt.Sprintf("%s/%s.%06d.png", CachedAdminSetting.ReviewDataPath, rcp.ID, rcp.Frame)
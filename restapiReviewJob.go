@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIReviewJobs 함수는 리뷰 연산 작업 리스트를 반환하는 restAPI 이다.
+// status 값을 입력하면 해당 상태의 작업만 반환한다. queued, running, failed, done
+func handleAPIReviewJobs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type JobInfo struct {
+		ReviewJob
+		WaitSeconds float64 `json:"waitseconds"` // 등록후 연산이 시작될때까지 기다린 시간
+		RunSeconds  float64 `json:"runseconds"`  // 마지막 연산에 걸린 시간
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", "queued", "running", "failed", "done":
+	default:
+		http.Error(w, "status는 queued, running, failed, done 값만 사용가능합니다", http.StatusBadRequest)
+		return
+	}
+	jobs, err := allReviewJobs(session, status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	results := []JobInfo{}
+	for _, job := range jobs {
+		info := JobInfo{ReviewJob: job}
+		createtime, err := time.Parse(time.RFC3339, job.Createtime)
+		if err == nil {
+			starttime, err := time.Parse(time.RFC3339, job.Starttime)
+			if err != nil {
+				// 아직 시작되지 않은 작업은 현재까지 기다린 시간을 표기한다.
+				info.WaitSeconds = now.Sub(createtime).Seconds()
+			} else {
+				info.WaitSeconds = starttime.Sub(createtime).Seconds()
+				endtime, err := time.Parse(time.RFC3339, job.Endtime)
+				if err != nil || job.Status == "running" {
+					endtime = now
+				}
+				info.RunSeconds = endtime.Sub(starttime).Seconds()
+			}
+		}
+		results = append(results, info)
+	}
+	// json 으로 결과 전송
+	data, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// handleAPIExportReviewNotes 함수는 리뷰 검색결과 또는 플레이리스트의 리뷰노트를 HTML, PDF 파일로 내려받는 핸들러이다.
+func handleAPIExportReviewNotes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	serveReviewNotes(w, session, q.Get("searchword"), q.Get("playlist"), q.Get("format"))
+}
+
+// serveReviewNotes 함수는 리뷰노트를 만들어서 첨부파일로 전송한다. format은 html, pdf를 사용할 수 있고 기본값은 html이다.
+func serveReviewNotes(w http.ResponseWriter, session *mgo.Session, searchword, playlist, format string) {
+	if format == "" {
+		format = "html"
+	}
+	if format != "html" && format != "pdf" {
+		http.Error(w, "format은 html, pdf 값만 사용가능합니다", http.StatusBadRequest)
+		return
+	}
+	if playlist != "" && !bson.IsObjectIdHex(playlist) {
+		http.Error(w, playlist+" 는 플레이리스트 ID 형식이 아닙니다", http.StatusBadRequest)
+		return
+	}
+	title, reviews, err := reviewsForNotes(session, searchword, playlist)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	admin, err := GetAdminSetting(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	notes := collectReviewNotes(session, admin, title, reviews)
+	var data []byte
+	switch format {
+	case "pdf":
+		data, err = renderReviewNotesPDF(notes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+	default:
+		var buf bytes.Buffer
+		err = TEMPLATES.ExecuteTemplate(&buf, "reviewnotes", notes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data = buf.Bytes()
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	}
+	w.Header().Add("Content-Disposition", fmt.Sprintf("Attachment; filename=%s", reviewNotesFilename(title, format)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPISchedule 함수는 프로젝트, 아티스트, 팀별 간트차트 데이터를 반환하는 핸들러이다.
+func handleAPISchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	f := ScheduleFilter{
+		User: q.Get("user"),
+		Team: q.Get("team"),
+		Task: q.Get("task"),
+	}
+	if project == "" && f.User == "" && f.Team == "" {
+		http.Error(w, "project, user, team 중 하나를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	s, err := GetSchedule(session, project, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIReschedule 함수는 간트차트에서 바를 옮길 때 Task의 날짜를 바꾸는 핸들러이다.
+func handleAPIReschedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		Project   string             `json:"project"`
+		ID        string             `json:"id"`
+		Task      string             `json:"task"`
+		Bar       ScheduleBar        `json:"bar"`
+		Conflicts []ScheduleConflict `json:"conflicts"`
+		UserID    string             `json:"userid"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	rcp.UserID, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if rcp.UserID == "unknown" && r.FormValue("userid") != "" {
+		rcp.UserID = r.FormValue("userid")
+	}
+	rcp.Project = r.FormValue("project")
+	if rcp.Project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.ID = r.FormValue("id")
+	if rcp.ID == "" {
+		http.Error(w, "id(SS_0010_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Task = r.FormValue("task")
+	if rcp.Task == "" {
+		http.Error(w, "task를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	shift := 0
+	if r.FormValue("shift") != "" {
+		shift, err = strconv.Atoi(r.FormValue("shift"))
+		if err != nil {
+			http.Error(w, "shift는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+	}
+	startdate := r.FormValue("startdate")
+	predate := r.FormValue("predate")
+	date := r.FormValue("date")
+	if shift == 0 && startdate == "" && predate == "" && date == "" {
+		http.Error(w, "shift, startdate, predate, date 중 하나를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Bar, rcp.Conflicts, err = Reschedule(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Task, startdate, predate, date, shift)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	msg := fmt.Sprintf("Reschedule: %s, Start: %s, Predate: %s, Date: %s", rcp.Task, rcp.Bar.Startdate, rcp.Bar.Predate, rcp.Bar.Date)
+	// log
+	err = dilog.Add(*flagDBIP, host, msg, rcp.Project, rcp.ID, "csi3", rcp.UserID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, rcp.Project, fmt.Sprintf("%s\nProject: %s, ID: %s, Author: %s", msg, rcp.Project, rcp.ID, rcp.UserID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
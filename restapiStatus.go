@@ -78,6 +78,7 @@ func handleAPIAddStatus(w http.ResponseWriter, r *http.Request) {
 	rcp.BorderColor = r.FormValue("bordercolor")
 	rcp.DefaultOn = str2bool(r.FormValue("defaulton"))
 	rcp.InitStatus = str2bool(r.FormValue("initstatus"))
+	rcp.Complete = str2bool(r.FormValue("complete"))
 	err = rcp.CheckError()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPITaskTemplates 함수는 전체 Task 템플릿을 반환하는 핸들러이다.
+func handleAPITaskTemplates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	templates, err := AllTaskTemplates(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(templates)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPITaskTemplate 함수는 id에 해당하는 Task 템플릿을 반환하는 핸들러이다.
+func handleAPITaskTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	t, err := getTaskTemplate(session, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPISetTaskTemplate 함수는 Task 템플릿을 추가(/api/addtasktemplate), 수정(/api/edittasktemplate), 삭제(/api/rmtasktemplate)하는 핸들러이다.
+func handleAPISetTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if accessLevel < PmAccessLevel {
+		http.Error(w, "Task 템플릿을 수정할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	t := TaskTemplate{
+		ID:          r.FormValue("id"),
+		Name:        r.FormValue("name"),
+		Description: r.FormValue("description"),
+	}
+	if t.ID == "" {
+		http.Error(w, "id를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	var msg string
+	switch r.URL.Path {
+	case "/api/addtasktemplate", "/api/edittasktemplate":
+		t.Tasks, err = parseTaskTemplateTasks(r.FormValue("tasks"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path == "/api/addtasktemplate" {
+			err = AddTaskTemplate(session, t)
+			msg = "Add TaskTemplate: " + t.ID
+		} else {
+			err = SetTaskTemplate(session, t)
+			msg = "Edit TaskTemplate: " + t.ID
+		}
+	case "/api/rmtasktemplate":
+		err = RmTaskTemplate(session, t.ID)
+		msg = "Rm TaskTemplate: " + t.ID
+	default:
+		http.Error(w, "지원하지 않는 URL입니다", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, msg, "", t.ID, "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPISetProjectTaskTemplate 함수는 프로젝트의 아이템 타입, shottype 별 Task 템플릿을 설정하는 핸들러이다.
+func handleAPISetProjectTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if accessLevel < PmAccessLevel {
+		http.Error(w, "프로젝트의 Task 템플릿을 설정할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	project := r.FormValue("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rule := TaskTemplateRule{
+		ItemType: r.FormValue("itemtype"),
+		Shottype: r.FormValue("shottype"),
+		Template: r.FormValue("template"),
+	}
+	err = SetProjectTaskTemplate(session, project, rule)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	msg := fmt.Sprintf("Set TaskTemplate: %s, ItemType: %s, Shottype: %s", rule.Template, rule.ItemType, rule.Shottype)
+	// log
+	err = dilog.Add(*flagDBIP, host, msg, project, "", "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// slack log
+	err = slacklog(session, project, fmt.Sprintf("%s\nProject: %s, Author: %s", msg, project, userID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(rule)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIApplyTaskTemplate 함수는 기존 아이템에 Task 템플릿을 다시 적용하는 핸들러이다.
+// dryrun이 true이면 아이템을 수정하지 않고 추가, 삭제될 Task 리스트만 반환한다.
+func handleAPIApplyTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	project := r.FormValue("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	ids := Str2List(r.FormValue("ids"))
+	if len(ids) == 0 {
+		http.Error(w, "ids(SS_0010_org,SS_0020_org)를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	template := r.FormValue("template")
+	remove, _ := strconv.ParseBool(r.FormValue("remove"))
+	dryrun, _ := strconv.ParseBool(r.FormValue("dryrun"))
+	if !dryrun && accessLevel < LeadAccessLevel {
+		http.Error(w, "Task 템플릿을 적용할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	results := []TaskTemplateDiff{}
+	for _, id := range ids {
+		diff, err := ApplyTaskTemplate(session, project, id, userID, template, remove, dryrun)
+		if err != nil {
+			diff.Error = err.Error()
+			results = append(results, diff)
+			continue
+		}
+		results = append(results, diff)
+		if dryrun || (len(diff.Add) == 0 && (!remove || len(diff.Remove) == 0)) {
+			continue
+		}
+		msg := fmt.Sprintf("Apply TaskTemplate: %s, Add: %v", diff.Template, diff.Add)
+		if remove {
+			msg += fmt.Sprintf(", Remove: %v", diff.Remove)
+		}
+		// log
+		err = dilog.Add(*flagDBIP, host, msg, project, id, "csi3", userID, 180)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// slack log
+		err = slacklog(session, project, fmt.Sprintf("%s\nProject: %s, ID: %s, Author: %s", msg, project, id, userID))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	data, err := json.Marshal(results)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIAddTimeLog 함수는 토큰 사용자의 작업시간을 Task에 기록하는 핸들러이다. 같은 날 같은 Task의 기록은 교체된다.
+func handleAPIAddTimeLog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	l := TimeLog{
+		Project: r.FormValue("project"),
+		Item:    r.FormValue("id"),
+		Task:    r.FormValue("task"),
+		UserID:  userID,
+		Date:    r.FormValue("date"),
+		Note:    r.FormValue("note"),
+	}
+	l.Hours, err = strconv.ParseFloat(r.FormValue("hours"), 64)
+	if err != nil {
+		http.Error(w, "hours는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+		return
+	}
+	l, err = AddTimeLog(session, l)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Add TimeLog: %s %s %gh", l.Task, l.Date, l.Hours), l.Project, l.Item, "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(l)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIRmTimeLog 함수는 토큰 사용자의 타임시트를 삭제하는 핸들러이다.
+func handleAPIRmTimeLog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, _, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	l, err := RmTimeLog(session, r.FormValue("id"), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Rm TimeLog: %s %s %gh", l.Task, l.Date, l.Hours), l.Project, l.Item, "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(l)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPITimeLogs 함수는 타임시트를 검색하는 핸들러이다. Lead 미만의 사용자는 본인의 타임시트만 검색할 수 있다.
+func handleAPITimeLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	f := TimeLogFilter{
+		Project: q.Get("project"),
+		Item:    q.Get("id"),
+		Task:    q.Get("task"),
+		UserID:  q.Get("user"),
+		Start:   q.Get("start"),
+		End:     q.Get("end"),
+		Status:  q.Get("status"),
+	}
+	if q.Get("week") != "" {
+		f.Start, f.End, err = weekRange(q.Get("week"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if accessLevel < LeadAccessLevel && f.UserID != userID {
+		f.UserID = userID
+	}
+	logs, err := searchTimeLogs(session, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(logs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIApproveTimesheet 함수는 아티스트의 주간 타임시트를 승인하거나 반려하는 핸들러이다. Lead 이상의 권한이 필요하다.
+func handleAPIApproveTimesheet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		User     string `json:"user"`
+		Week     string `json:"week"`
+		Status   string `json:"status"`
+		Num      int    `json:"num"`
+		Approver string `json:"approver"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if accessLevel < LeadAccessLevel {
+		http.Error(w, "타임시트를 승인할 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	rcp.Approver = userID
+	rcp.User = r.FormValue("user")
+	if rcp.User == "" {
+		http.Error(w, "user를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	rcp.Week = r.FormValue("week")
+	rcp.Status = r.FormValue("status")
+	if rcp.Status == "" {
+		rcp.Status = "approved"
+	}
+	rcp.Num, err = ApproveTimesheet(session, userID, rcp.User, rcp.Week, rcp.Status)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Timesheet %s: %s, Week: %s", rcp.Status, rcp.User, rcp.Week), "", rcp.User, "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPITimesheetReport 함수는 프로젝트의 예측 맨데이와 실제 맨데이를 task, user, team 별로 비교하는 핸들러이다.
+func handleAPITimesheetReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if accessLevel < LeadAccessLevel {
+		http.Error(w, "타임시트 리포트를 볼 권한이 없습니다", http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	if project == "" {
+		http.Error(w, "project를 설정해주세요", http.StatusBadRequest)
+		return
+	}
+	groupby := q.Get("groupby")
+	if groupby == "" {
+		groupby = "task"
+	}
+	rows, err := TimesheetReport(session, project, groupby)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := json.Marshal(rows)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digital-idea/dilog"
+	"github.com/digital-idea/ditime"
+	"gopkg.in/mgo.v2"
+)
+
+// splitFormList 함수는 쉼표로 구분된 폼 값을 중복 없는 리스트로 바꾼다.
+func splitFormList(value string) []string {
+	list := []string{}
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" && !inStrings(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// maskWebhookSecret 함수는 웹훅 구독 리스트에서 서명 키를 가린다.
+func maskWebhookSecret(subs []WebhookSubscription) []WebhookSubscription {
+	for i := range subs {
+		if subs[i].Secret != "" {
+			subs[i].Secret = "********"
+		}
+	}
+	return subs
+}
+
+// handleAPIEvents 함수는 since 이후에 발생한 이벤트를 반환하는 핸들러이다. 웹훅 대신 주기적으로 가지고 갈 때 사용한다.
+func handleAPIEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	since := q.Get("since")
+	if _, err := time.Parse(time.RFC3339, since); since != "" && err != nil {
+		since, err = ditime.ToFullTime(0, since)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	typ := q.Get("type")
+	if typ != "" && !validEventPattern(typ) {
+		http.Error(w, typ+" 는 사용할 수 없는 이벤트 필터입니다", http.StatusBadRequest)
+		return
+	}
+	limit := 100
+	if q.Get("limit") != "" {
+		limit, err = strconv.Atoi(q.Get("limit"))
+		if err != nil || limit < 1 || limit > 1000 {
+			http.Error(w, "limit는 1~1000 사이의 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+	}
+	events, err := getEvents(session, since, typ, q.Get("project"), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(events)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIWebhooks 함수는 전체 웹훅 구독을 반환하는 핸들러이다. 서명 키는 가려서 반환한다.
+func handleAPIWebhooks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if accessLevel != AdminAccessLevel {
+		http.Error(w, "웹훅을 조회하기 위해서 관리자 권한이 필요합니다", http.StatusUnauthorized)
+		return
+	}
+	subs, err := allWebhooks(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(maskWebhookSecret(subs))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIEditWebhook 함수는 웹훅 구독을 추가, 수정, 삭제하는 핸들러이다.
+// /api/addwebhook 은 서명 키를 생략하면 만들어서 반환한다. 서명 키는 추가, 수정할 때만 응답에 포함된다.
+func handleAPIEditWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if accessLevel != AdminAccessLevel {
+		http.Error(w, "웹훅을 설정하기 위해서 관리자 권한이 필요합니다", http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	s := WebhookSubscription{Active: true, MaxAttempts: WebhookDefaultAttempts, Events: []string{}, Projects: []string{}, Author: userID}
+	if r.URL.Path != "/api/addwebhook" {
+		s, err = getWebhook(session, r.FormValue("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	var msg string
+	switch r.URL.Path {
+	case "/api/rmwebhook":
+		err = rmWebhook(session, r.FormValue("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		msg = "Rm Webhook: " + s.Name
+		s.Secret = ""
+	default:
+		for _, v := range []struct {
+			key   string
+			field *string
+		}{{"name", &s.Name}, {"url", &s.URL}, {"secret", &s.Secret}} {
+			if _, ok := r.Form[v.key]; ok {
+				*v.field = strings.TrimSpace(r.FormValue(v.key))
+			}
+		}
+		if _, ok := r.Form["events"]; ok {
+			s.Events = splitFormList(r.FormValue("events"))
+		}
+		if _, ok := r.Form["projects"]; ok {
+			s.Projects = splitFormList(r.FormValue("projects"))
+		}
+		if r.FormValue("active") != "" {
+			s.Active = str2bool(r.FormValue("active"))
+		}
+		if r.FormValue("maxattempts") != "" {
+			s.MaxAttempts, err = strconv.Atoi(r.FormValue("maxattempts"))
+			if err != nil {
+				http.Error(w, "maxattempts는 숫자로 입력되어야 합니다", http.StatusBadRequest)
+				return
+			}
+		}
+		if r.URL.Path == "/api/addwebhook" {
+			if s.Secret == "" {
+				s.Secret, err = newWebhookSecret()
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+			}
+			s, err = addWebhook(session, s)
+			msg = "Add Webhook: " + s.Name + " " + s.URL
+		} else {
+			s, err = setWebhook(session, s)
+			msg = "Set Webhook: " + s.Name + " " + s.URL
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, msg, "", s.ID.Hex(), "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIWebhookDeliveries 함수는 웹훅 전송 기록을 최신순으로 반환하는 핸들러이다.
+func handleAPIWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if accessLevel != AdminAccessLevel {
+		http.Error(w, "웹훅 전송 기록을 조회하기 위해서 관리자 권한이 필요합니다", http.StatusUnauthorized)
+		return
+	}
+	q := r.URL.Query()
+	status := q.Get("status")
+	if status != "" && status != DeliveryPending && status != DeliverySuccess && status != DeliveryFailed {
+		http.Error(w, "status는 pending, success, failed 중 하나여야 합니다", http.StatusBadRequest)
+		return
+	}
+	limit := 100
+	if q.Get("limit") != "" {
+		limit, err = strconv.Atoi(q.Get("limit"))
+		if err != nil || limit < 1 || limit > 1000 {
+			http.Error(w, "limit는 1~1000 사이의 숫자로 입력되어야 합니다", http.StatusBadRequest)
+			return
+		}
+	}
+	deliveries, err := getWebhookDeliveries(session, q.Get("id"), status, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := json.Marshal(deliveries)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIRedeliverWebhook 함수는 웹훅 전송을 다시 보내도록 대기 상태로 되돌리는 핸들러이다.
+func handleAPIRedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, accessLevel, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if accessLevel != AdminAccessLevel {
+		http.Error(w, "웹훅을 다시 보내기 위해서 관리자 권한이 필요합니다", http.StatusUnauthorized)
+		return
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.ParseForm()
+	if userID == "unknown" && r.FormValue("userid") != "" {
+		userID = r.FormValue("userid")
+	}
+	id := r.FormValue("id")
+	err = redeliverWebhook(session, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// log
+	err = dilog.Add(*flagDBIP, host, "Redeliver Webhook: "+id, "", id, "csi3", userID, 180)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	type Recipe struct {
+		ID     string `json:"id"`
+		UserID string `json:"userid"`
+	}
+	data, err := json.Marshal(Recipe{ID: id, UserID: userID})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
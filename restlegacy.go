@@ -60,7 +60,7 @@ func handleAPISetTags(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetTags(session, project, name, Str2List(tags))
+	err = SetTags(session, project, name, tokenID, Str2List(tags))
 	if err != nil {
 		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
 		return
@@ -147,7 +147,7 @@ func handleAPISetTaskMov(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	rcp.Mov = dipath.Win2lin(rcp.Mov) // 내부적으로 모든 경로는 unix 경로를 사용한다.
-	_, err = setTaskMov(session, rcp.Project, rcp.Name, rcp.Task, rcp.Mov)
+	_, err = setTaskMov(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Task, rcp.Mov)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -378,7 +378,7 @@ func handleAPISetThummov(w http.ResponseWriter, r *http.Request) {
 			rcp.Path = v
 		}
 	}
-	err = SetThummov(session, rcp.Project, rcp.Name, rcp.Path)
+	err = SetThummov(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Path)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -472,7 +472,7 @@ func handleAPISetRenderSize(w http.ResponseWriter, r *http.Request) {
 			rcp.Size = v
 		}
 	}
-	id, err := SetImageSize(session, rcp.Project, rcp.Name, "rendersize", rcp.Size)
+	id, err := SetImageSize(session, rcp.Project, rcp.Name, rcp.UserID, "rendersize", rcp.Size)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1,35 +1,74 @@
 package main
 
-import "gopkg.in/mgo.v2/bson"
+import (
+	"fmt"
+
+	"gopkg.in/mgo.v2/bson"
+)
 
 // Review 는 리뷰데이터 자료구조 이다.
 type Review struct {
-	ID                 bson.ObjectId `json:"id" bson:"_id,omitempty"` // ID
-	Project            string        `json:"project"`                 // 프로젝트
-	Name               string        `json:"name"`                    // 샷네임, 에셋네임
-	Task               string        `json:"task"`                    // 태스크
-	Createtime         string        `json:"createtime"`              // 생성시간
-	Updatetime         string        `json:"updatetime"`              // 업데이트 시간
-	Author             string        `json:"author"`                  // 작성자
-	AuthorNameKor      string        `json:"authornamekor"`           // 작성자 한글 이름
-	Path               string        `json:"path"`                    // 리뷰경로
-	Status             string        `json:"status"`                  // 상태 approve, comment, waiting
-	ProcessStatus      string        `json:"processstatus"`           // 연산상태. wait, error, done
-	Sketches           []Sketch      `json:"sketches"`                // 스케치 프레임
-	Playlist           []string      `json:"playlist"`                // 플레이리스트 목록
-	Comments           []Comment     `json:"comments"`                // 댓글
-	Description        string        `json:"description"`             // 설명
-	Progress           int           `json:"progress"`                // 진행률
-	CameraInfo         string        `json:"camerainfo"`              // 카메라정보
-	CreatedMp4         bool          `json:"createmp4"`               // Mp4 생성여부
-	Fps                float64       `json:"fps"`                     // fps
-	Log                string        `json:"log"`                     // Log 예로 Errlog가 있다.
-	MainVersion        int           `json:"mainversion"`             // Main Version
-	SubVersion         int           `json:"subversion"`              // Sub Version
-	Stage              string        `json:"stage"`                   // 현재 리뷰 Stage 단계
-	RemoveAfterProcess bool          `json:"removeafterprocess"`      // 프로세스 처리후 제거하는 옵션
-	Type               string        `json:"type"`                    // review type: clip, image 가 존재한다. 추후 3D 데이터도 리뷰에 포함될 수 있다.
-	Ext                string        `json:"ext"`                     // 웹서버에서 보일 최종 reviewdata의 확장자
+	ID                 bson.ObjectId  `json:"id" bson:"_id,omitempty"` // ID
+	Project            string         `json:"project"`                 // 프로젝트
+	Name               string         `json:"name"`                    // 샷네임, 에셋네임
+	Task               string         `json:"task"`                    // 태스크
+	Createtime         string         `json:"createtime"`              // 생성시간
+	Updatetime         string         `json:"updatetime"`              // 업데이트 시간
+	Author             string         `json:"author"`                  // 작성자
+	AuthorNameKor      string         `json:"authornamekor"`           // 작성자 한글 이름
+	Path               string         `json:"path"`                    // 리뷰경로
+	Status             string         `json:"status"`                  // 상태 approve, comment, waiting
+	ProcessStatus      string         `json:"processstatus"`           // 연산상태. wait, error, done
+	Sketches           []Sketch       `json:"sketches"`                // 스케치 프레임
+	Playlist           []string       `json:"playlist"`                // 플레이리스트 목록
+	Comments           []Comment      `json:"comments"`                // 댓글
+	Description        string         `json:"description"`             // 설명
+	Progress           int            `json:"progress"`                // 진행률
+	CameraInfo         string         `json:"camerainfo"`              // 카메라정보
+	CreatedMp4         bool           `json:"createmp4"`               // Mp4 생성여부
+	Fps                float64        `json:"fps"`                     // fps
+	Log                string         `json:"log"`                     // Log 예로 Errlog가 있다.
+	MainVersion        int            `json:"mainversion"`             // Main Version
+	SubVersion         int            `json:"subversion"`              // Sub Version
+	Stage              string         `json:"stage"`                   // 현재 리뷰 Stage 단계
+	RemoveAfterProcess bool           `json:"removeafterprocess"`      // 프로세스 처리후 제거하는 옵션
+	Type               string         `json:"type"`                    // review type: clip, image, sequence, compare 가 존재한다. 추후 3D 데이터도 리뷰에 포함될 수 있다.
+	Ext                string         `json:"ext"`                     // 웹서버에서 보일 최종 reviewdata의 확장자
+	InColorspace       string         `json:"incolorspace"`            // 리뷰 소스의 컬러스페이스. 빈 문자열이면 프로젝트 컨펌용 MOV IN 컬러스페이스를 사용한다.
+	ColorTransform     ColorTransform `json:"colortransform"`          // 리뷰 동영상을 만들 때 적용된 컬러 변환
+	Slated             bool           `json:"slated"`                  // BurnIn이 새겨진 슬레이트 버전이 존재하는지 여부. 파일명은 ID_slate.Ext 이다.
+	FrameIn            int            `json:"framein"`                 // sequence 타입의 시작 프레임
+	FrameOut           int            `json:"frameout"`                // sequence 타입의 끝 프레임
+	CompareMode        string         `json:"comparemode"`             // compare 타입의 비교방식: sidebyside, wipe. 비교할 원본 리뷰 ID는 Playlist에 순서대로 저장한다.
+	Wipe               float64        `json:"wipe"`                    // wipe 비교방식에서 화면을 나누는 위치. 0~1 사이의 값이다.
+	Duration           float64        `json:"duration"`                // 생성된 리뷰 동영상의 길이(초)
+}
+
+// OpenNotes 메소드는 해결되지 않은 수정사항 리스트를 반환한다.
+func (r Review) OpenNotes() []Comment {
+	var notes []Comment
+	for _, c := range r.Comments {
+		if c.IsOpenNote() {
+			notes = append(notes, c)
+		}
+	}
+	return notes
+}
+
+// CheckApprove 메소드는 리뷰를 approve 할 수 있는지 체크한다.
+// 해결되지 않은 수정사항이 있다면 슈퍼바이저 이상의 권한을 가진 사용자가 override 했을 때만 approve 할 수 있다.
+func (r Review) CheckApprove(override bool, level AccessLevel) error {
+	notes := r.OpenNotes()
+	if len(notes) == 0 {
+		return nil
+	}
+	if override && level >= SupervisorAccessLevel {
+		return nil
+	}
+	if override {
+		return fmt.Errorf("해결되지 않은 수정사항 %d개가 있습니다. override는 슈퍼바이저 이상의 권한이 필요합니다", len(notes))
+	}
+	return fmt.Errorf("해결되지 않은 수정사항 %d개가 있어서 approve 할 수 없습니다", len(notes))
 }
 
 // Sketch 는 스케치 자료구조이다.
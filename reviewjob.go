@@ -0,0 +1,89 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// ReviewJob 자료구조는 리뷰 데이터를 연산하기 위한 작업 자료구조이다.
+type ReviewJob struct {
+	ID            string `json:"id"`            // 리뷰 ID와 같은 값을 사용한다.
+	Project       string `json:"project"`       // 프로젝트
+	Name          string `json:"name"`          // 샷네임, 에셋네임
+	Task          string `json:"task"`          // 태스크
+	Type          string `json:"type"`          // 리뷰 타입
+	Status        string `json:"status"`        // 작업상태: queued, running, failed, done
+	Attempts      int    `json:"attempts"`      // 시도한 횟수
+	MaxAttempts   int    `json:"maxattempts"`   // 최대 시도 횟수
+	Createtime    string `json:"createtime"`    // 작업 등록시간 RFC3339
+	Starttime     string `json:"starttime"`     // 마지막 연산 시작시간 RFC3339
+	Endtime       string `json:"endtime"`       // 마지막 연산 종료시간 RFC3339
+	NextRetrytime string `json:"nextretrytime"` // 다음 연산을 시도할 수 있는 시간 RFC3339
+	Log           string `json:"log"`           // 마지막 에러로그
+}
+
+// reviewJobBaseDelay 는 재시도 대기시간의 기본값이다.
+const reviewJobBaseDelay = 30 * time.Second
+
+// reviewJobMaxDelay 는 재시도 대기시간의 최대값이다.
+const reviewJobMaxDelay = 30 * time.Minute
+
+// reviewJobBackoff 함수는 시도 횟수를 받아서 다음 재시도까지 기다릴 시간을 반환한다.
+// 시도할 때마다 대기시간이 2배씩 늘어난다. 30초, 1분, 2분 ...
+func reviewJobBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	d := reviewJobBaseDelay
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= reviewJobMaxDelay {
+			return reviewJobMaxDelay
+		}
+	}
+	return d
+}
+
+// retried 메소드는 연산에 실패한 작업의 다음 상태를 반환한다.
+// 최대 시도 횟수에 도달하면 failed 상태가 되고, 아니면 queued 상태로 대기시간 이후에 다시 연산한다.
+func (job ReviewJob) retried(log string, now time.Time) ReviewJob {
+	job.Endtime = now.Format(time.RFC3339)
+	job.Log = log
+	if job.Attempts >= job.MaxAttempts {
+		job.Status = "failed"
+		return job
+	}
+	job.Status = "queued"
+	job.NextRetrytime = now.Add(reviewJobBackoff(job.Attempts)).Format(time.RFC3339)
+	return job
+}
+
+// ReviewQueue 자료구조는 대기중인 worker를 깨우기 위한 자료구조이다.
+type ReviewQueue struct {
+	mu   sync.Mutex
+	wake chan struct{}
+}
+
+// reviewQueue 는 서버에서 공유하는 리뷰 큐이다.
+var reviewQueue = NewReviewQueue()
+
+// NewReviewQueue 함수는 ReviewQueue를 생성한다.
+func NewReviewQueue() *ReviewQueue {
+	return &ReviewQueue{wake: make(chan struct{})}
+}
+
+// Notify 메소드는 대기중인 모든 worker를 깨운다.
+func (q *ReviewQueue) Notify() {
+	q.mu.Lock()
+	close(q.wake)
+	q.wake = make(chan struct{})
+	q.mu.Unlock()
+}
+
+// Wakeup 메소드는 다음 Notify 호출시 닫히는 채널을 반환한다.
+// DB를 조회하기 전에 채널을 먼저 받아두어야 그 사이에 발생한 Notify를 놓치지 않는다.
+func (q *ReviewQueue) Wakeup() <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.wake
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReviewJobBackoff(t *testing.T) {
+	cases := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 0, want: 0},
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 3, want: 2 * time.Minute},
+		{attempts: 6, want: 16 * time.Minute},
+		{attempts: 7, want: reviewJobMaxDelay}, // 32분은 최대값을 넘는다.
+		{attempts: 100, want: reviewJobMaxDelay},
+	}
+	for _, c := range cases {
+		got := reviewJobBackoff(c.attempts)
+		if got != c.want {
+			t.Fatalf("TestReviewJobBackoff(%v): 얻은 값 %v, 원하는 값 %v", c.attempts, got, c.want)
+		}
+	}
+}
+
+func TestReviewJobRetried(t *testing.T) {
+	now := time.Date(2020, 11, 2, 9, 0, 0, 0, time.UTC)
+	cases := []struct {
+		job           ReviewJob
+		status        string
+		nextRetrytime string
+	}{
+		{job: ReviewJob{Status: "running", Attempts: 1, MaxAttempts: 3}, status: "queued", nextRetrytime: "2020-11-02T09:00:30Z"},
+		{job: ReviewJob{Status: "running", Attempts: 2, MaxAttempts: 3}, status: "queued", nextRetrytime: "2020-11-02T09:01:00Z"},
+		{job: ReviewJob{Status: "running", Attempts: 3, MaxAttempts: 3}, status: "failed", nextRetrytime: ""},                      // 최대 시도 횟수에 도달
+		{job: ReviewJob{Status: "running", Attempts: 4, MaxAttempts: 3}, status: "failed", nextRetrytime: ""},                      // 최대 시도 횟수를 넘음
+		{job: ReviewJob{Status: "running", Attempts: 9, MaxAttempts: 10}, status: "queued", nextRetrytime: "2020-11-02T09:30:00Z"}, // 최대 대기시간
+	}
+	for _, c := range cases {
+		got := c.job.retried("ffmpeg error", now)
+		if got.Status != c.status || got.NextRetrytime != c.nextRetrytime {
+			t.Fatalf("TestReviewJobRetried(%v): 얻은 값 %v, 원하는 값 %v %v", c.job, got, c.status, c.nextRetrytime)
+		}
+		if got.Log != "ffmpeg error" || got.Endtime != "2020-11-02T09:00:00Z" || got.Attempts != c.job.Attempts {
+			t.Fatalf("TestReviewJobRetried(%v): 얻은 값 %v, 원하는 값 %v", c.job, got, "ffmpeg error 2020-11-02T09:00:00Z")
+		}
+	}
+}
+
+func TestReviewQueueWakeup(t *testing.T) {
+	q := NewReviewQueue()
+	wake := q.Wakeup()
+	select {
+	case <-wake:
+		t.Fatalf("TestReviewQueueWakeup(%v): 얻은 값 %v, 원하는 값 %v", "before Notify", "closed", "open")
+	default:
+	}
+	q.Notify()
+	select {
+	case <-wake:
+	default:
+		t.Fatalf("TestReviewQueueWakeup(%v): 얻은 값 %v, 원하는 값 %v", "after Notify", "open", "closed")
+	}
+	select {
+	case <-q.Wakeup():
+		t.Fatalf("TestReviewQueueWakeup(%v): 얻은 값 %v, 원하는 값 %v", "next Wakeup", "closed", "open")
+	default:
+	}
+}
@@ -0,0 +1,441 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html/template"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // ffmpeg가 출력하는 PNG 프레임을 읽기 위해 필요하다.
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/disintegration/imaging"
+	"gopkg.in/mgo.v2"
+)
+
+// reviewNoteImageWidth 값은 리뷰노트에 들어가는 프레임 이미지의 최대 너비이다.
+const reviewNoteImageWidth = 640
+
+// ReviewNotes 자료구조는 여러 리뷰의 코멘트와 스케치를 모은 리뷰노트 문서이다.
+type ReviewNotes struct {
+	Title      string       // 문서 제목. 검색어 또는 플레이리스트 제목
+	Createtime string       // 문서 생성시간
+	Reviews    []ReviewNote // 리뷰 리스트
+}
+
+// ReviewNote 자료구조는 리뷰노트 문서의 리뷰 하나이다.
+type ReviewNote struct {
+	Review    Review            // 리뷰
+	Thumbnail []byte            // 리뷰 썸네일 JPEG 데이터
+	General   []CommentThread   // 프레임이 지정되지 않은 코멘트
+	Frames    []ReviewNoteFrame // 코멘트나 스케치가 있는 프레임. 프레임 순서로 정렬되어 있다.
+}
+
+// ReviewNoteFrame 자료구조는 리뷰노트에서 코멘트나 스케치가 있는 프레임 하나이다.
+type ReviewNoteFrame struct {
+	Frame   int             // 리뷰 동영상 기준 프레임. 1부터 시작한다.
+	Sketch  string          // 스케치 이미지 경로. 스케치가 없다면 빈 문자열이다.
+	Threads []CommentThread // 프레임에 작성된 코멘트
+	Image   []byte          // 프레임에 스케치와 코멘트 영역을 합성한 JPEG 데이터
+}
+
+// ThumbnailURI 메소드는 썸네일을 HTML에 바로 넣을 수 있는 data URI로 반환한다.
+func (n ReviewNote) ThumbnailURI() template.URL {
+	return jpegDataURI(n.Thumbnail)
+}
+
+// ImageURI 메소드는 프레임 이미지를 HTML에 바로 넣을 수 있는 data URI로 반환한다.
+func (f ReviewNoteFrame) ImageURI() template.URL {
+	return jpegDataURI(f.Image)
+}
+
+// jpegDataURI 함수는 JPEG 데이터를 data URI로 바꾼다. 데이터가 없다면 빈 문자열을 반환한다.
+func jpegDataURI(data []byte) template.URL {
+	if len(data) == 0 {
+		return ""
+	}
+	return template.URL("data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(data))
+}
+
+// reviewNoteFrames 함수는 리뷰의 코멘트와 스케치를 프레임별로 묶는다.
+// 프레임이 지정되지 않은 코멘트는 general로 반환하고, 답글은 부모 코멘트의 프레임을 따른다.
+func reviewNoteFrames(review Review) ([]CommentThread, []ReviewNoteFrame) {
+	var general []CommentThread
+	frames := make(map[int]*ReviewNoteFrame)
+	frameOf := func(f int) *ReviewNoteFrame {
+		if _, ok := frames[f]; !ok {
+			frames[f] = &ReviewNoteFrame{Frame: f, Threads: []CommentThread{}}
+		}
+		return frames[f]
+	}
+	for _, t := range commentThreads(review.Comments) {
+		if t.Frame < 1 {
+			general = append(general, t)
+			continue
+		}
+		f := frameOf(t.Frame)
+		f.Threads = append(f.Threads, t)
+	}
+	for _, s := range review.Sketches {
+		if s.Frame < 1 || s.SketchPath == "" {
+			continue
+		}
+		frameOf(s.Frame).Sketch = s.SketchPath
+	}
+	var results []ReviewNoteFrame
+	for _, f := range frames {
+		results = append(results, *f)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Frame < results[j].Frame
+	})
+	return general, results
+}
+
+// reviewsForNotes 함수는 검색어 또는 플레이리스트로 리뷰노트에 들어갈 리뷰와 문서 제목을 가지고 온다.
+// 플레이리스트를 사용하면 플레이리스트 순서를 유지한다.
+func reviewsForNotes(session *mgo.Session, searchword, playlistID string) (string, []Review, error) {
+	if playlistID != "" {
+		p, err := getPlaylist(session, playlistID)
+		if err != nil {
+			return "", nil, err
+		}
+		var reviews []Review
+		for _, id := range p.Reviews {
+			r, err := getReview(session, id)
+			if err != nil {
+				return "", nil, fmt.Errorf("%s 리뷰를 가지고 올 수 없습니다: %v", id, err)
+			}
+			reviews = append(reviews, r)
+		}
+		return fmt.Sprintf("%s %s %s", p.Project, p.Date, p.Title), reviews, nil
+	}
+	if searchword == "" {
+		return "", nil, errors.New("searchword 또는 playlist를 설정해주세요")
+	}
+	reviews, err := searchReview(session, searchword)
+	if err != nil {
+		return "", nil, err
+	}
+	return searchword, reviews, nil
+}
+
+// collectReviewNotes 함수는 리뷰 리스트로 리뷰노트 문서를 만든다.
+// 프레임 이미지를 만들 수 없다면 샷, 에셋 썸네일을 대신 사용하고, 썸네일도 없다면 이미지 없이 코멘트만 기록한다.
+func collectReviewNotes(session *mgo.Session, admin Setting, title string, reviews []Review) ReviewNotes {
+	notes := ReviewNotes{
+		Title:      title,
+		Createtime: time.Now().Format(time.RFC3339),
+	}
+	for _, r := range reviews {
+		n := ReviewNote{Review: r}
+		n.General, n.Frames = reviewNoteFrames(r)
+		thumbnail := reviewItemThumbnail(session, admin, r)
+		n.Thumbnail = thumbnail
+		if img, err := reviewFrameImage(admin, r, 1); err == nil {
+			if data, err := encodeReviewNoteImage(img); err == nil {
+				n.Thumbnail = data
+			}
+		}
+		for i, f := range n.Frames {
+			img, err := reviewFrameImage(admin, r, f.Frame)
+			if err != nil {
+				n.Frames[i].Image = thumbnail
+				continue
+			}
+			data, err := encodeReviewNoteImage(composeReviewNoteFrame(img, f))
+			if err != nil {
+				n.Frames[i].Image = thumbnail
+				continue
+			}
+			n.Frames[i].Image = data
+		}
+		notes.Reviews = append(notes.Reviews, n)
+	}
+	return notes
+}
+
+// reviewItemThumbnail 함수는 리뷰의 샷, 에셋 썸네일을 JPEG 데이터로 반환한다. 썸네일이 없다면 nil을 반환한다.
+func reviewItemThumbnail(session *mgo.Session, admin Setting, review Review) []byte {
+	typ, err := Type(session, review.Project, review.Name)
+	if err != nil {
+		return nil
+	}
+	item, err := getItem(session, review.Project, review.Name+"_"+typ)
+	if err != nil {
+		return nil
+	}
+	// adminsetting에서 값을 가지고 와서 경로를 설정한다.
+	var thumbnailImagePath bytes.Buffer
+	thumbnailImagePathTmpl, err := template.New("thumbnailImagePath").Parse(admin.ThumbnailImagePath)
+	if err != nil {
+		return nil
+	}
+	err = thumbnailImagePathTmpl.Execute(&thumbnailImagePath, item)
+	if err != nil {
+		return nil
+	}
+	img, err := imaging.Open(thumbnailImagePath.String())
+	if err != nil {
+		return nil
+	}
+	data, err := encodeReviewNoteImage(img)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// reviewFrameImage 함수는 리뷰 데이터에서 frame 이미지를 가지고 온다.
+// image 타입은 리뷰 이미지를 그대로 사용하고, 동영상 리뷰는 FFmpeg로 해당 프레임을 추출한다.
+func reviewFrameImage(admin Setting, review Review, frame int) (image.Image, error) {
+	if review.ProcessStatus != "done" {
+		return nil, errors.New("연산이 끝나지 않은 리뷰입니다")
+	}
+	path := reviewDataFile(admin, review.ID.Hex(), review.Ext, false)
+	if review.Type == "image" {
+		return imaging.Open(path)
+	}
+	if admin.FFmpeg == "" {
+		return nil, errors.New("admin 셋팅에 FFmpeg 경로가 설정되어 있지 않습니다")
+	}
+	fps := review.Fps
+	if fps <= 0 {
+		fps = 24
+	}
+	if frame < 1 {
+		frame = 1
+	}
+	args := []string{
+		"-ss", fmt.Sprintf("%.3f", float64(frame-1)/fps),
+		"-i", path,
+		"-frames:v", "1",
+		"-f", "image2pipe",
+		"-vcodec", "png",
+		"-",
+	}
+	out, err := exec.Command(admin.FFmpeg, args...).Output()
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(out))
+	return img, err
+}
+
+// composeReviewNoteFrame 함수는 프레임 이미지 위에 스케치와 코멘트 영역을 합성한다.
+func composeReviewNoteFrame(img image.Image, f ReviewNoteFrame) image.Image {
+	bounds := img.Bounds()
+	dst := imaging.Clone(img)
+	if f.Sketch != "" {
+		sketch, err := imaging.Open(f.Sketch)
+		if err == nil {
+			// 스케치는 리뷰 화면 크기로 그려지므로 프레임 크기에 맞춘다.
+			sketch = imaging.Resize(sketch, bounds.Dx(), bounds.Dy(), imaging.Linear)
+			dst = imaging.Overlay(dst, sketch, image.Pt(0, 0), 1.0)
+		}
+	}
+	for _, t := range f.Threads {
+		c := color.NRGBA{255, 204, 0, 255}
+		if t.Resolved {
+			c = color.NRGBA{160, 160, 160, 255}
+		}
+		drawRegion(dst, t.Region, c)
+	}
+	return dst
+}
+
+// drawRegion 함수는 이미지에 코멘트 영역을 사각형 테두리로 그린다. point 영역은 작은 사각형으로 그린다.
+func drawRegion(img *image.NRGBA, region Region, c color.NRGBA) {
+	w := float64(img.Bounds().Dx())
+	h := float64(img.Bounds().Dy())
+	var x0, y0, x1, y1 int
+	switch region.Type {
+	case "rect":
+		x0, y0 = int(region.X*w), int(region.Y*h)
+		x1, y1 = int((region.X+region.Width)*w), int((region.Y+region.Height)*h)
+	case "point":
+		x0, y0 = int(region.X*w)-6, int(region.Y*h)-6
+		x1, y1 = x0+12, y0+12
+	default:
+		return
+	}
+	const thickness = 3
+	for t := 0; t < thickness; t++ {
+		for x := x0; x <= x1; x++ {
+			img.SetNRGBA(x, y0+t, c)
+			img.SetNRGBA(x, y1-t, c)
+		}
+		for y := y0; y <= y1; y++ {
+			img.SetNRGBA(x0+t, y, c)
+			img.SetNRGBA(x1-t, y, c)
+		}
+	}
+}
+
+// encodeReviewNoteImage 함수는 이미지를 리뷰노트 크기로 줄이고 JPEG 데이터로 바꾼다.
+func encodeReviewNoteImage(img image.Image) ([]byte, error) {
+	if img.Bounds().Dx() > reviewNoteImageWidth {
+		img = imaging.Resize(img, reviewNoteImageWidth, 0, imaging.Lanczos)
+	}
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// reviewNoteThreadLines 함수는 코멘트 스레드를 리뷰노트에 표기할 문자열 리스트로 바꾼다.
+func reviewNoteThreadLines(t CommentThread) []string {
+	line := fmt.Sprintf("%s %s: %s", t.AuthorName, t.Date, t.Text)
+	if t.Resolved {
+		line = "[해결] " + line
+	}
+	lines := []string{line}
+	for _, reply := range t.Replies {
+		lines = append(lines, fmt.Sprintf("  - %s %s: %s", reply.AuthorName, reply.Date, reply.Text))
+	}
+	return lines
+}
+
+// reviewNoteTitle 함수는 리뷰노트에 표기할 리뷰 제목을 반환한다.
+func reviewNoteTitle(r Review) string {
+	title := fmt.Sprintf("%s %s %s", r.Project, r.Name, r.Task)
+	if r.MainVersion > 0 {
+		title += " v" + ProductionVersionFormat(r.MainVersion)
+	}
+	if r.SubVersion > 0 {
+		title += " w" + ProductionVersionFormat(r.SubVersion)
+	}
+	return title
+}
+
+// renderReviewNotesPDF 함수는 리뷰노트를 PDF 데이터로 만든다.
+// 리뷰마다 썸네일과 리뷰 정보를 쓰고, 프레임마다 왼쪽에 이미지, 오른쪽에 코멘트를 쓴다.
+func renderReviewNotesPDF(notes ReviewNotes) ([]byte, error) {
+	const (
+		margin     = 36.0
+		imageWidth = 320.0
+		gap        = 12.0
+		fontSize   = 9.0
+		lineHeight = 13.0
+	)
+	textWidth := pdfPageWidth - margin*2 - imageWidth - gap
+	doc := newPDFDocument()
+	doc.AddPage()
+	y := margin
+	// ensure 함수는 남은 공간이 h보다 작다면 다음 페이지로 넘긴다.
+	ensure := func(h float64) {
+		if y+h > pdfPageHeight-margin {
+			doc.AddPage()
+			y = margin
+		}
+	}
+	// lines 함수는 x 위치부터 width 너비에 맞춰 글을 쓰고, 페이지를 넘어가면 다음 페이지에 이어서 쓴다.
+	lines := func(x, width float64, texts []string) {
+		for _, text := range texts {
+			for _, l := range pdfWrapText(text, fontSize, width) {
+				ensure(lineHeight)
+				doc.Text(x, y+fontSize, fontSize, l)
+				y += lineHeight
+			}
+		}
+	}
+	doc.Text(margin, y+16, 16, "Review Notes: "+notes.Title)
+	y += 24
+	doc.Color(0.4, 0.4, 0.4)
+	doc.Text(margin, y+fontSize, fontSize, notes.Createtime)
+	doc.Color(0, 0, 0)
+	y += lineHeight * 2
+	for _, n := range notes.Reviews {
+		r := n.Review
+		thumbHeight := 0.0
+		if len(n.Thumbnail) != 0 {
+			thumbHeight = pdfImageHeight(n.Thumbnail, 160)
+		}
+		ensure(thumbHeight + lineHeight*3)
+		doc.Line(margin, y, pdfPageWidth-margin, y)
+		y += gap
+		top, page := y, len(doc.pages)
+		if thumbHeight > 0 {
+			err := doc.Image(n.Thumbnail, margin, y, 160, thumbHeight)
+			if err != nil {
+				return nil, err
+			}
+		}
+		x := margin + 160 + gap
+		doc.Text(x, y+12, 12, reviewNoteTitle(r))
+		y += 18
+		info := []string{fmt.Sprintf("Stage: %s  Status: %s  Author: %s  Createtime: %s", r.Stage, r.Status, r.AuthorNameKor, r.Createtime)}
+		if r.Description != "" {
+			info = append(info, r.Description)
+		}
+		for _, t := range n.General {
+			info = append(info, reviewNoteThreadLines(t)...)
+		}
+		lines(x, pdfPageWidth-margin-x, info)
+		if len(doc.pages) == page && y < top+thumbHeight {
+			y = top + thumbHeight
+		}
+		y += gap
+		for _, f := range n.Frames {
+			width, imageHeight := imageWidth, 0.0
+			if len(f.Image) != 0 {
+				imageHeight = pdfImageHeight(f.Image, imageWidth)
+			}
+			// 세로가 긴 이미지는 한 페이지에 들어가도록 줄인다.
+			if maxHeight := pdfPageHeight - margin*2 - lineHeight; imageHeight > maxHeight {
+				width = width * maxHeight / imageHeight
+				imageHeight = maxHeight
+			}
+			ensure(imageHeight + lineHeight)
+			top, page := y, len(doc.pages)
+			if imageHeight > 0 {
+				err := doc.Image(f.Image, margin, y, width, imageHeight)
+				if err != nil {
+					return nil, err
+				}
+			}
+			texts := []string{fmt.Sprintf("Frame %d", f.Frame)}
+			if f.Sketch != "" {
+				texts[0] += " (sketch)"
+			}
+			for _, t := range f.Threads {
+				texts = append(texts, reviewNoteThreadLines(t)...)
+			}
+			lines(margin+imageWidth+gap, textWidth, texts)
+			// 이미지 옆의 글이 다음 페이지로 넘어가지 않았다면 이미지 아래로 이동한다.
+			if len(doc.pages) == page && y < top+imageHeight {
+				y = top + imageHeight
+			}
+			y += gap
+		}
+	}
+	return doc.Bytes(), nil
+}
+
+// pdfImageHeight 함수는 JPEG 이미지를 width 너비로 그릴 때의 높이를 반환한다.
+func pdfImageHeight(data []byte, width float64) float64 {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width == 0 {
+		return 0
+	}
+	return width * float64(config.Height) / float64(config.Width)
+}
+
+// reviewNotesFilename 함수는 리뷰노트를 내려받을 때 사용할 파일명을 반환한다.
+func reviewNotesFilename(title, format string) string {
+	name := strings.Map(func(r rune) rune {
+		if r > 0x7E || r == ' ' || r == ':' || r == '/' || r == '\\' || r == '"' || r == ';' {
+			return '_'
+		}
+		return r
+	}, title)
+	return fmt.Sprintf("reviewnotes-%s.%s", name, format)
+}
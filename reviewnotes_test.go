@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestReviewNoteFrames(t *testing.T) {
+	review := Review{
+		Comments: []Comment{
+			{ID: "a", Text: "전체 코멘트"},
+			{ID: "b", Frame: 12, Text: "12 프레임"},
+			{ID: "c", Frame: 3, Text: "3 프레임"},
+			{ID: "d", Parent: "b", Text: "답글"},
+		},
+		Sketches: []Sketch{
+			{Frame: 12, SketchPath: "/review/id.000012.png"},
+			{Frame: 20, SketchPath: "/review/id.000020.png"},
+		},
+	}
+	general, frames := reviewNoteFrames(review)
+	if len(general) != 1 || general[0].ID != "a" {
+		t.Fatalf("TestReviewNoteFrames(general): 얻은 값 %v, 원하는 값 %v", general, "a")
+	}
+	var got []int
+	for _, f := range frames {
+		got = append(got, f.Frame)
+	}
+	if !reflect.DeepEqual(got, []int{3, 12, 20}) {
+		t.Fatalf("TestReviewNoteFrames(frames): 얻은 값 %v, 원하는 값 %v", got, []int{3, 12, 20})
+	}
+	if frames[1].Sketch != "/review/id.000012.png" || len(frames[1].Threads[0].Replies) != 1 {
+		t.Fatalf("TestReviewNoteFrames(12): 얻은 값 %v, 원하는 값 %v", frames[1], "스케치와 답글 1개")
+	}
+}
+
+func TestPDFWrapText(t *testing.T) {
+	cases := []struct {
+		text  string
+		width float64
+		want  []string
+	}{{
+		text:  "hello world",
+		width: 100,
+		want:  []string{"hello world"},
+	}, {
+		text:  "hello world",
+		width: 30,
+		want:  []string{"hello", "world"},
+	}, {
+		text:  "가나다라",
+		width: 20,
+		want:  []string{"가나", "다라"},
+	}, {
+		text:  "a\nb",
+		width: 100,
+		want:  []string{"a", "b"},
+	}}
+	for _, c := range cases {
+		got := pdfWrapText(c.text, 10, c.width)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("TestPDFWrapText(%v): 얻은 값 %q, 원하는 값 %q", c.text, got, c.want)
+		}
+	}
+}
+
+func TestPDFDocumentXref(t *testing.T) {
+	var img bytes.Buffer
+	err := jpeg.Encode(&img, image.NewRGBA(image.Rect(0, 0, 4, 3)), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := newPDFDocument()
+	doc.Text(36, 36, 12, "리뷰노트")
+	err = doc.Image(img.Bytes(), 36, 60, 40, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.AddPage()
+	doc.Text(36, 36, 12, "page 2")
+	data := doc.Bytes()
+	// xref 테이블의 모든 위치에 순서대로 오브젝트가 있어야 한다.
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data, -1)
+	if len(offsets) == 0 {
+		t.Fatalf("TestPDFDocumentXref: 얻은 값 %v, 원하는 값 %v", 0, "1개 이상의 오브젝트")
+	}
+	for i, o := range offsets {
+		offset, _ := strconv.Atoi(string(o[1]))
+		want := fmt.Sprintf("%d 0 obj", i+1)
+		if !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Fatalf("TestPDFDocumentXref(%d): 얻은 값 %q, 원하는 값 %q", i+1, data[offset:offset+len(want)], want)
+		}
+	}
+	if !bytes.Contains(data, []byte("/Count 2")) {
+		t.Fatalf("TestPDFDocumentXref: 얻은 값 %v, 원하는 값 %v", "페이지 수 불일치", "/Count 2")
+	}
+}
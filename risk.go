@@ -0,0 +1,269 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Task 마감 위험도
+const (
+	RiskOnTrack = "on-track" // 마감일 안에 끝낼 수 있다.
+	RiskAtRisk  = "at-risk"  // 여유가 없거나 배정된 아티스트가 없다.
+	RiskLate    = "late"     // 마감일이 지났거나 마감일 안에 끝낼 수 없다.
+)
+
+// RiskBuffer 는 마감일까지 가능한 맨데이 중 이 비율 이상이 남은 작업이라면 at-risk로 판단하는 값이다.
+const RiskBuffer = 0.8
+
+// RiskUnassignedDays 는 아티스트가 배정되지 않은 Task를 at-risk로 판단하는 마감일까지 남은 일수이다.
+const RiskUnassignedDays = 14
+
+// TaskRisk 자료구조는 Task의 마감 위험도이다.
+type TaskRisk struct {
+	Project   string   `json:"project"`
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Seq       string   `json:"seq"`
+	Task      string   `json:"task"`
+	Users     []string `json:"users"`
+	StatusV2  string   `json:"statusv2"`
+	Deadline  string   `json:"deadline"`  // 2차 마감일, 1차 마감일, 아이템 마감일 순으로 사용한 마감일 2006-01-02
+	Progress  float64  `json:"progress"`  // Status 순서로 계산한 진행률 0~1
+	Remaining float64  `json:"remaining"` // 남은 맨데이
+	Available float64  `json:"available"` // 마감일까지 아티스트가 쓸 수 있는 맨데이. 여러 아티스트라면 가장 부족한 아티스트의 값이다.
+	Level     string   `json:"level"`     // on-track, at-risk, late
+	Reason    string   `json:"reason"`
+}
+
+// RiskCount 자료구조는 시퀀스, Task별 위험도 개수이다.
+type RiskCount struct {
+	Name    string `json:"name"`
+	OnTrack int    `json:"ontrack"`
+	AtRisk  int    `json:"atrisk"`
+	Late    int    `json:"late"`
+}
+
+// RiskReport 자료구조는 프로젝트의 마감 위험도 리포트이다.
+type RiskReport struct {
+	Project string      `json:"project"`
+	Date    string      `json:"date"`  // 기준일 2006-01-02
+	BySeq   []RiskCount `json:"byseq"` // 시퀀스별 개수. 에셋은 "-" 이다.
+	ByTask  []RiskCount `json:"bytask"`
+	Risks   []TaskRisk  `json:"risks"` // at-risk, late 인 Task 리스트
+}
+
+// statusProgress 함수는 Status 순서(Order)를 0~1 사이의 진행률로 바꾼다. 가장 낮은 순서가 0, 가장 높은 순서가 1이다.
+func statusProgress(status []Status) map[string]float64 {
+	progress := make(map[string]float64)
+	if len(status) == 0 {
+		return progress
+	}
+	min, max := status[0].Order, status[0].Order
+	for _, s := range status {
+		if s.Order < min {
+			min = s.Order
+		}
+		if s.Order > max {
+			max = s.Order
+		}
+	}
+	for _, s := range status {
+		if max == min {
+			progress[s.ID] = 0
+			continue
+		}
+		progress[s.ID] = (s.Order - min) / (max - min)
+	}
+	return progress
+}
+
+// userDayLoads 함수는 Task 리스트로 아티스트:날짜:맨데이 맵을 만든다.
+func userDayLoads(tasks []capacityTask, calendars map[string]WorkCalendar) map[string]map[string]float64 {
+	loads := make(map[string]map[string]float64)
+	company := calendars[CompanyCalendarID]
+	for _, t := range tasks {
+		for u, days := range t.Shares {
+			if _, ok := loads[u]; !ok {
+				loads[u] = make(map[string]float64)
+			}
+			for date, load := range taskDayLoads(t.Start, t.End, days, calendars[u], company) {
+				loads[u][date] += load
+			}
+		}
+	}
+	return loads
+}
+
+// riskContext 자료구조는 Task 위험도 계산에 필요한 값이다.
+type riskContext struct {
+	Today     string                        // 기준일 2006-01-02
+	Progress  map[string]float64            // Status:진행률
+	Complete  map[string]bool               // 끝난 Status
+	Calendars map[string]WorkCalendar       // 사용자 ID:근무 캘린더
+	Loads     map[string]map[string]float64 // 전체 프로젝트의 아티스트:날짜:맨데이
+}
+
+// taskDeadline 함수는 Task의 마감일을 반환한다. 2차 마감일, 1차 마감일, 아이템 마감일 순으로 사용한다.
+func taskDeadline(i Item, t Task) string {
+	if d := scheduleDate(t.Date); d != "" {
+		return d
+	}
+	if d := scheduleDate(t.Predate); d != "" {
+		return d
+	}
+	return itemDeadline(i)
+}
+
+// assess 메소드는 Task의 마감 위험도를 계산한다.
+// 남은 맨데이는 예측 맨데이와 Status 진행률로 계산하고, 아티스트가 쓸 수 있는 맨데이는 근무 캘린더에서 다른 Task의 작업량을 뺀 값이다.
+func (rc riskContext) assess(project string, i Item, name string, t Task) TaskRisk {
+	r := TaskRisk{
+		Project:  project,
+		ID:       i.ID,
+		Name:     i.Name,
+		Seq:      i.Seq,
+		Task:     name,
+		Users:    []string{},
+		StatusV2: t.StatusV2,
+		Level:    RiskOnTrack,
+	}
+	for _, u := range t.Users() {
+		r.Users = append(r.Users, assigneeID(u))
+	}
+	if rc.Complete[t.StatusV2] {
+		r.Progress = 1
+		return r
+	}
+	r.Deadline = taskDeadline(i, t)
+	if r.Deadline == "" {
+		r.Reason = "마감일이 없습니다"
+		return r
+	}
+	r.Progress = rc.Progress[t.StatusV2]
+	r.Remaining = float64(t.ExpectDay) * (1 - r.Progress)
+	if rc.Today > r.Deadline {
+		r.Level = RiskLate
+		r.Reason = fmt.Sprintf("마감일(%s)이 지났습니다", r.Deadline)
+		return r
+	}
+	company := rc.Calendars[CompanyCalendarID]
+	shares := expectDayShares(t)
+	if len(shares) == 0 {
+		for d := rc.Today; d <= r.Deadline; d = addScheduleDays(d, 1) {
+			r.Available += WorkCalendar{}.Capacity(d, company)
+		}
+		switch {
+		case r.Remaining > r.Available:
+			r.Level = RiskLate
+			r.Reason = fmt.Sprintf("남은 작업(%.1f)이 마감일까지 근무일(%.1f)보다 많습니다", r.Remaining, r.Available)
+		case r.Remaining > 0 && addScheduleDays(rc.Today, RiskUnassignedDays) >= r.Deadline:
+			r.Level = RiskAtRisk
+			r.Reason = "마감일이 가까운데 배정된 아티스트가 없습니다"
+		}
+		return r
+	}
+	// 이 Task가 작업량에 포함되어 있다면 아티스트의 작업량에서 뺀다.
+	bar, hasBar := newScheduleBar(project, i, name, t, nil)
+	hasBar = hasBar && t.ExpectDay > 0
+	worst := -1.0
+	var users []string
+	for u := range shares {
+		users = append(users, u)
+	}
+	sort.Strings(users)
+	for _, u := range users {
+		need := shares[u] * (1 - r.Progress)
+		var own map[string]float64
+		if hasBar {
+			own = taskDayLoads(bar.Start, bar.End, shares[u], rc.Calendars[u], company)
+		}
+		var free float64
+		for d := rc.Today; d <= r.Deadline; d = addScheduleDays(d, 1) {
+			free += rc.Calendars[u].Capacity(d, company) - (rc.Loads[u][d] - own[d])
+		}
+		if free < 0 {
+			free = 0
+		}
+		ratio := 0.0
+		switch {
+		case need == 0:
+		case free == 0:
+			ratio = need + 1
+		default:
+			ratio = need / free
+		}
+		if ratio <= worst {
+			continue
+		}
+		worst = ratio
+		r.Available = free
+		switch {
+		case need > free:
+			r.Level = RiskLate
+			r.Reason = fmt.Sprintf("%s 의 남은 작업(%.1f)이 마감일까지 가능한 맨데이(%.1f)보다 많습니다", u, need, free)
+		case need > free*RiskBuffer:
+			r.Level = RiskAtRisk
+			r.Reason = fmt.Sprintf("%s 의 남은 작업(%.1f)이 마감일까지 가능한 맨데이(%.1f)에 비해 여유가 없습니다", u, need, free)
+		default:
+			r.Level = RiskOnTrack
+			r.Reason = ""
+		}
+	}
+	return r
+}
+
+// buildRiskReport 함수는 Task 위험도 리스트로 프로젝트 리포트를 만든다.
+func buildRiskReport(project, today string, risks []TaskRisk) RiskReport {
+	report := RiskReport{Project: project, Date: today, BySeq: []RiskCount{}, ByTask: []RiskCount{}, Risks: []TaskRisk{}}
+	bySeq := make(map[string]*RiskCount)
+	byTask := make(map[string]*RiskCount)
+	for _, r := range risks {
+		seq := r.Seq
+		if seq == "" {
+			seq = "-"
+		}
+		for _, c := range []struct {
+			m    map[string]*RiskCount
+			name string
+		}{{bySeq, seq}, {byTask, r.Task}} {
+			if _, ok := c.m[c.name]; !ok {
+				c.m[c.name] = &RiskCount{Name: c.name}
+			}
+			switch r.Level {
+			case RiskLate:
+				c.m[c.name].Late++
+			case RiskAtRisk:
+				c.m[c.name].AtRisk++
+			default:
+				c.m[c.name].OnTrack++
+			}
+		}
+		if r.Level != RiskOnTrack {
+			report.Risks = append(report.Risks, r)
+		}
+	}
+	for _, c := range bySeq {
+		report.BySeq = append(report.BySeq, *c)
+	}
+	for _, c := range byTask {
+		report.ByTask = append(report.ByTask, *c)
+	}
+	sort.Slice(report.BySeq, func(i, j int) bool { return report.BySeq[i].Name < report.BySeq[j].Name })
+	sort.Slice(report.ByTask, func(i, j int) bool { return report.ByTask[i].Name < report.ByTask[j].Name })
+	sort.Slice(report.Risks, func(i, j int) bool {
+		if report.Risks[i].Level != report.Risks[j].Level {
+			return report.Risks[i].Level == RiskLate
+		}
+		if report.Risks[i].Deadline != report.Risks[j].Deadline {
+			return report.Risks[i].Deadline < report.Risks[j].Deadline
+		}
+		return report.Risks[i].ID+report.Risks[i].Task < report.Risks[j].ID+report.Risks[j].Task
+	})
+	return report
+}
+
+// riskToday 함수는 위험도 계산 기준일(오늘)을 반환한다.
+func riskToday() string {
+	return time.Now().Format("2006-01-02")
+}
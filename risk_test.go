@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestRiskAssess(t *testing.T) {
+	status := []Status{{ID: "assign", Order: 0}, {ID: "wip", Order: 1}, {ID: "confirm", Order: 2, Complete: true}}
+	complete := map[string]bool{"confirm": true}
+	items := map[string][]Item{"TEMP": {
+		{ID: "SS_0010_org", Tasks: map[string]Task{"comp": {User: "kim", StatusV2: "wip", ExpectDay: 5, Startdate: "2020-11-02T10:00:00+09:00", Date: "2020-11-06T19:00:00+09:00"}}},
+		{ID: "SS_0020_org", Tasks: map[string]Task{"comp": {User: "kim", StatusV2: "assign", ExpectDay: 2, Startdate: "2020-11-02T10:00:00+09:00", Date: "2020-11-06T19:00:00+09:00"}}},
+		{ID: "SS_0030_org", Tasks: map[string]Task{"comp": {StatusV2: "assign", ExpectDay: 2, Predate: "2020-11-06T19:00:00+09:00"}}},
+		{ID: "SS_0040_org", Tasks: map[string]Task{"comp": {StatusV2: "assign", ExpectDay: 2, Predate: "2020-12-31T19:00:00+09:00"}}},
+		{ID: "SS_0050_org", Tasks: map[string]Task{"comp": {User: "lee", StatusV2: "wip", Date: "2020-10-30T19:00:00+09:00"}}},
+		{ID: "SS_0060_org", Tasks: map[string]Task{"comp": {User: "lee", StatusV2: "confirm", Date: "2020-10-30T19:00:00+09:00"}}},
+		{ID: "SS_0070_org", Tasks: map[string]Task{"comp": {User: "lee", StatusV2: "wip"}}},
+	}}
+	rc := riskContext{
+		Today:     "2020-11-02",
+		Progress:  statusProgress(status),
+		Complete:  complete,
+		Calendars: map[string]WorkCalendar{},
+		Loads:     userDayLoads(capacityTasks(items, complete), map[string]WorkCalendar{}),
+	}
+	want := map[string]string{
+		"SS_0010_org": RiskAtRisk,  // 남은 2.5, 가능 3
+		"SS_0020_org": RiskLate,    // 남은 2, 가능 0
+		"SS_0030_org": RiskAtRisk,  // 배정된 아티스트 없음
+		"SS_0040_org": RiskOnTrack, // 마감일이 멀다
+		"SS_0050_org": RiskLate,    // 마감일이 지났다
+		"SS_0060_org": RiskOnTrack, // 끝난 Task
+		"SS_0070_org": RiskOnTrack, // 마감일 없음
+	}
+	for _, i := range items["TEMP"] {
+		r := rc.assess("TEMP", i, "comp", i.Tasks["comp"])
+		if r.Level != want[i.ID] {
+			t.Fatalf("TestRiskAssess(%v): 얻은 값 %v(%v), 원하는 값 %v", i.ID, r.Level, r.Reason, want[i.ID])
+		}
+	}
+}
+
+func TestBuildRiskReport(t *testing.T) {
+	risks := []TaskRisk{
+		{ID: "SS_0010_org", Seq: "SS", Task: "comp", Level: RiskLate},
+		{ID: "SS_0020_org", Seq: "SS", Task: "comp", Level: RiskOnTrack},
+		{ID: "SS_0020_org", Seq: "SS", Task: "fx", Level: RiskAtRisk},
+		{ID: "tree_asset", Task: "model", Level: RiskOnTrack},
+	}
+	report := buildRiskReport("TEMP", "2020-11-02", risks)
+	cases := []struct {
+		got  RiskCount
+		want RiskCount
+	}{
+		{got: report.BySeq[0], want: RiskCount{Name: "-", OnTrack: 1}},
+		{got: report.BySeq[1], want: RiskCount{Name: "SS", OnTrack: 1, AtRisk: 1, Late: 1}},
+		{got: report.ByTask[0], want: RiskCount{Name: "comp", OnTrack: 1, Late: 1}},
+	}
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Fatalf("TestBuildRiskReport: 얻은 값 %v, 원하는 값 %v", c.got, c.want)
+		}
+	}
+	if len(report.Risks) != 2 || report.Risks[0].Level != RiskLate {
+		t.Fatalf("TestBuildRiskReport(risks): 얻은 값 %v, 원하는 값 %v", report.Risks, "late, at-risk")
+	}
+}
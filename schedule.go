@@ -0,0 +1,302 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ScheduleBar 자료구조는 간트차트에서 하나의 Task 작업기간이다.
+type ScheduleBar struct {
+	Project   string   `json:"project"`   // 프로젝트
+	ID        string   `json:"id"`        // 아이템 ID
+	Name      string   `json:"name"`      // 아이템 이름
+	Task      string   `json:"task"`      // Task 이름
+	Users     []string `json:"users"`     // 작업 아티스트 ID 리스트
+	Teams     []string `json:"teams"`     // 작업 아티스트의 팀 리스트
+	StatusV2  string   `json:"statusv2"`  // Task 상태
+	Startdate string   `json:"startdate"` // 작업 시작일 RFC3339
+	Predate   string   `json:"predate"`   // 1차 마감일 RFC3339
+	Date      string   `json:"date"`      // 2차 마감일 RFC3339
+	ExpectDay int      `json:"expectday"` // 예측 맨데이
+	Start     string   `json:"start"`     // 간트차트 시작일 2006-01-02
+	End       string   `json:"end"`       // 간트차트 종료일 2006-01-02
+	Estimated bool     `json:"estimated"` // 시작일 또는 종료일을 ExpectDay로 계산했는지 여부
+	Deadline  string   `json:"deadline"`  // 아이템 마감일(Ddline2d, Ddline3d) 2006-01-02
+}
+
+// ScheduleMilestone 자료구조는 간트차트에 표시할 프로젝트 마일스톤이다.
+type ScheduleMilestone struct {
+	Project string `json:"project"`
+	Name    string `json:"name"`
+	Date    string `json:"date"` // 2006-01-02
+}
+
+// ScheduleConflict 자료구조는 일정 충돌 정보이다.
+// Type은 overlap(같은 아티스트의 작업기간이 겹침), deadline(아이템 마감일을 넘김), order(시작일, 1차, 2차 마감일 순서가 맞지 않음), dependency(상위 Task가 끝나기 전에 시작함) 중 하나이다.
+type ScheduleConflict struct {
+	Type    string   `json:"type"`
+	Bars    []string `json:"bars"`    // 충돌하는 바의 "id:task" 리스트
+	User    string   `json:"user"`    // overlap일 때 아티스트
+	Message string   `json:"message"` // 충돌 설명
+}
+
+// Schedule 자료구조는 간트차트 데이터이다.
+type Schedule struct {
+	Start      string              `json:"start"` // 전체 바와 마일스톤의 가장 빠른 날짜
+	End        string              `json:"end"`   // 전체 바와 마일스톤의 가장 늦은 날짜
+	Bars       []ScheduleBar       `json:"bars"`
+	Milestones []ScheduleMilestone `json:"milestones"`
+	Conflicts  []ScheduleConflict  `json:"conflicts"`
+}
+
+// key 메소드는 바를 구분하는 "id:task" 문자열을 반환한다.
+func (b ScheduleBar) key() string {
+	return b.ID + ":" + b.Task
+}
+
+// scheduleDate 함수는 RFC3339 시간을 2006-01-02 형태의 날짜로 바꾼다. 시간이 아니라면 빈 문자열을 반환한다.
+func scheduleDate(fulltime string) string {
+	t, err := time.Parse(time.RFC3339, fulltime)
+	if err != nil {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// addScheduleDays 함수는 2006-01-02 형태의 날짜에 days를 더한다.
+func addScheduleDays(date string, days int) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return ""
+	}
+	return t.AddDate(0, 0, days).Format("2006-01-02")
+}
+
+// itemDeadline 함수는 아이템의 마감일을 반환한다. 3D 샷은 Ddline3d를, 나머지는 Ddline2d를 먼저 사용한다.
+func itemDeadline(i Item) string {
+	first, second := i.Ddline2d, i.Ddline3d
+	if i.Shottype == "3d" {
+		first, second = i.Ddline3d, i.Ddline2d
+	}
+	if d := scheduleDate(first); d != "" {
+		return d
+	}
+	return scheduleDate(second)
+}
+
+// newScheduleBar 함수는 Task의 날짜로 간트차트 바를 만든다.
+// 종료일은 2차 마감일, 1차 마감일 순으로 사용하고 시작일 또는 종료일이 없다면 ExpectDay로 계산한다. 바를 만들 수 없다면 false를 반환한다.
+func newScheduleBar(project string, i Item, name string, t Task, teams map[string]string) (ScheduleBar, bool) {
+	b := ScheduleBar{
+		Project:   project,
+		ID:        i.ID,
+		Name:      i.Name,
+		Task:      name,
+		StatusV2:  t.StatusV2,
+		Startdate: t.Startdate,
+		Predate:   t.Predate,
+		Date:      t.Date,
+		ExpectDay: t.ExpectDay,
+		Deadline:  itemDeadline(i),
+	}
+	b.Start = scheduleDate(t.Startdate)
+	b.End = scheduleDate(t.Date)
+	if b.End == "" {
+		b.End = scheduleDate(t.Predate)
+	}
+	days := t.ExpectDay
+	if days < 1 {
+		days = 1
+	}
+	switch {
+	case b.Start == "" && b.End == "":
+		return b, false
+	case b.Start == "":
+		b.Start = addScheduleDays(b.End, 1-days)
+		b.Estimated = true
+	case b.End == "":
+		b.End = addScheduleDays(b.Start, days-1)
+		b.Estimated = true
+	}
+	hasTeam := make(map[string]bool)
+	for _, u := range t.Users() {
+		id := assigneeID(u)
+		b.Users = append(b.Users, id)
+		if team, ok := teams[id]; ok && team != "" && !hasTeam[team] {
+			hasTeam[team] = true
+			b.Teams = append(b.Teams, team)
+		}
+	}
+	return b, true
+}
+
+// ScheduleFilter 자료구조는 간트차트 바를 거르는 조건이다. 빈 값은 조건에서 제외한다.
+type ScheduleFilter struct {
+	User string // 아티스트 ID
+	Team string // 팀 이름
+	Task string // Task 이름
+}
+
+// match 메소드는 바가 조건에 맞는지 체크한다.
+func (f ScheduleFilter) match(b ScheduleBar) bool {
+	if f.Task != "" && b.Task != f.Task {
+		return false
+	}
+	if f.User != "" && !inStrings(b.Users, f.User) {
+		return false
+	}
+	if f.Team != "" && !inStrings(b.Teams, f.Team) {
+		return false
+	}
+	return true
+}
+
+// inStrings 함수는 리스트에 문자열이 있는지 체크한다.
+func inStrings(list []string, str string) bool {
+	for _, s := range list {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// buildSchedule 함수는 아이템 리스트로 간트차트 데이터를 만든다. items는 프로젝트 이름을 키로 사용한다.
+// 의존관계 충돌은 같은 프로젝트 안에서만 계산한다.
+func buildSchedule(items map[string][]Item, projects []Project, teams map[string]string, f ScheduleFilter) Schedule {
+	s := Schedule{Bars: []ScheduleBar{}, Milestones: []ScheduleMilestone{}, Conflicts: []ScheduleConflict{}}
+	all := make(map[string]ScheduleBar) // 필터와 상관없이 의존관계를 계산하기 위한 project/id:task 맵
+	var projectNames []string
+	for project := range items {
+		projectNames = append(projectNames, project)
+	}
+	sort.Strings(projectNames)
+	for _, project := range projectNames {
+		for _, i := range items[project] {
+			var tasks []string
+			for name := range i.Tasks {
+				tasks = append(tasks, name)
+			}
+			sort.Strings(tasks)
+			for _, name := range tasks {
+				b, ok := newScheduleBar(project, i, name, i.Tasks[name], teams)
+				if !ok {
+					continue
+				}
+				all[project+"/"+b.key()] = b
+				if f.match(b) {
+					s.Bars = append(s.Bars, b)
+				}
+			}
+		}
+	}
+	for _, p := range projects {
+		if _, ok := items[p.ID]; !ok {
+			continue
+		}
+		for _, m := range p.Milestones {
+			date := scheduleDate(m.Date)
+			if date == "" {
+				continue
+			}
+			s.Milestones = append(s.Milestones, ScheduleMilestone{Project: p.ID, Name: m.Name, Date: date})
+		}
+	}
+	s.Conflicts = scheduleConflicts(s.Bars, items, all)
+	for _, b := range s.Bars {
+		if s.Start == "" || b.Start < s.Start {
+			s.Start = b.Start
+		}
+		if b.End > s.End {
+			s.End = b.End
+		}
+	}
+	for _, m := range s.Milestones {
+		if s.Start == "" || m.Date < s.Start {
+			s.Start = m.Date
+		}
+		if m.Date > s.End {
+			s.End = m.Date
+		}
+	}
+	return s
+}
+
+// scheduleConflicts 함수는 바 리스트의 일정 충돌을 계산한다.
+func scheduleConflicts(bars []ScheduleBar, items map[string][]Item, all map[string]ScheduleBar) []ScheduleConflict {
+	conflicts := []ScheduleConflict{}
+	byUser := make(map[string][]ScheduleBar)
+	for _, b := range bars {
+		if b.Start > b.End || (b.Startdate != "" && b.Predate != "" && b.Predate < b.Startdate) || (b.Predate != "" && b.Date != "" && b.Date < b.Predate) {
+			conflicts = append(conflicts, ScheduleConflict{Type: "order", Bars: []string{b.key()}, Message: fmt.Sprintf("%s 의 시작일, 1차, 2차 마감일 순서가 맞지 않습니다", b.key())})
+		}
+		if b.Deadline != "" && b.End > b.Deadline {
+			conflicts = append(conflicts, ScheduleConflict{Type: "deadline", Bars: []string{b.key()}, Message: fmt.Sprintf("%s 가 아이템 마감일(%s)을 넘깁니다", b.key(), b.Deadline)})
+		}
+		for _, u := range b.Users {
+			byUser[u] = append(byUser[u], b)
+		}
+	}
+	// 같은 아티스트의 작업기간이 겹치는지 체크한다.
+	var users []string
+	for u := range byUser {
+		users = append(users, u)
+	}
+	sort.Strings(users)
+	for _, u := range users {
+		list := byUser[u]
+		sort.Slice(list, func(i, j int) bool { return list[i].Start < list[j].Start })
+		for n := 1; n < len(list); n++ {
+			for m := 0; m < n; m++ {
+				if list[m].End >= list[n].Start {
+					conflicts = append(conflicts, ScheduleConflict{Type: "overlap", User: u, Bars: []string{list[m].key(), list[n].key()}, Message: fmt.Sprintf("%s 의 %s, %s 작업기간이 겹칩니다", u, list[m].key(), list[n].key())})
+				}
+			}
+		}
+	}
+	// 상위 Task가 끝나기 전에 시작하는지 체크한다.
+	deps := make(map[string][]Dependency)
+	for project, list := range items {
+		for _, i := range list {
+			deps[project+"/"+i.ID] = i.Dependencies
+		}
+	}
+	for _, b := range bars {
+		for _, d := range deps[b.Project+"/"+b.ID] {
+			if d.Task != "" && d.Task != b.Task {
+				continue
+			}
+			for _, up := range all {
+				if up.Project != b.Project || up.ID != d.Upstream || (d.UpstreamTask != "" && up.Task != d.UpstreamTask) {
+					continue
+				}
+				if b.Start <= up.End {
+					conflicts = append(conflicts, ScheduleConflict{Type: "dependency", Bars: []string{up.key(), b.key()}, Message: fmt.Sprintf("%s 가 상위 %s 가 끝나기(%s) 전에 시작합니다", b.key(), up.key(), up.End)})
+				}
+			}
+		}
+	}
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		if conflicts[i].Type != conflicts[j].Type {
+			return conflicts[i].Type < conflicts[j].Type
+		}
+		return conflicts[i].Message < conflicts[j].Message
+	})
+	return conflicts
+}
+
+// checkTaskDates 함수는 Task의 시작일, 1차 마감일, 2차 마감일 순서를 체크한다. 빈 값은 체크하지 않는다.
+func checkTaskDates(startdate, predate, date string) error {
+	if startdate != "" && predate != "" && predate < startdate {
+		return errors.New("1차 마감일이 시작일보다 빠릅니다")
+	}
+	if startdate != "" && date != "" && date < startdate {
+		return errors.New("2차 마감일이 시작일보다 빠릅니다")
+	}
+	if predate != "" && date != "" && date < predate {
+		return errors.New("2차 마감일이 1차 마감일보다 빠릅니다")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestNewScheduleBar(t *testing.T) {
+	cases := []struct {
+		task      Task
+		start     string
+		end       string
+		estimated bool
+		ok        bool
+	}{{
+		task:  Task{Startdate: "2020-11-02T10:00:00+09:00", Predate: "2020-11-04T19:00:00+09:00", Date: "2020-11-06T19:00:00+09:00"},
+		start: "2020-11-02",
+		end:   "2020-11-06",
+		ok:    true,
+	}, {
+		task:      Task{Startdate: "2020-11-02T10:00:00+09:00", ExpectDay: 3},
+		start:     "2020-11-02",
+		end:       "2020-11-04",
+		estimated: true,
+		ok:        true,
+	}, {
+		task:      Task{Predate: "2020-11-06T19:00:00+09:00", ExpectDay: 2},
+		start:     "2020-11-05",
+		end:       "2020-11-06",
+		estimated: true,
+		ok:        true,
+	}, {
+		task: Task{ExpectDay: 2},
+		ok:   false,
+	}}
+	for _, c := range cases {
+		b, ok := newScheduleBar("TEMP", Item{ID: "SS_0010_org"}, "comp", c.task, nil)
+		if ok != c.ok || (ok && (b.Start != c.start || b.End != c.end || b.Estimated != c.estimated)) {
+			t.Fatalf("TestNewScheduleBar(%v): 얻은 값 %v~%v(%v), 원하는 값 %v~%v(%v)", c.task, b.Start, b.End, b.Estimated, c.start, c.end, c.estimated)
+		}
+	}
+}
+
+func TestBuildScheduleConflicts(t *testing.T) {
+	items := map[string][]Item{"TEMP": {
+		{ID: "SS_0010_org", Ddline2d: "2020-11-05T19:00:00+09:00", Tasks: map[string]Task{
+			"comp": {User: "kim", Startdate: "2020-11-02T10:00:00+09:00", Date: "2020-11-06T19:00:00+09:00"},
+		}},
+		{ID: "SS_0020_org", Dependencies: []Dependency{{Task: "comp", Upstream: "SS_0010_org", UpstreamTask: "comp"}}, Tasks: map[string]Task{
+			"comp": {User: "kim", Startdate: "2020-11-04T10:00:00+09:00", Date: "2020-11-10T19:00:00+09:00"},
+		}},
+	}}
+	projects := []Project{{ID: "TEMP", Milestones: []Milestone{{Name: "기술시사", Date: "2020-11-30T10:00:00+09:00"}}}}
+	s := buildSchedule(items, projects, map[string]string{"kim": "comp1"}, ScheduleFilter{Team: "comp1"})
+	types := make(map[string]int)
+	for _, c := range s.Conflicts {
+		types[c.Type]++
+	}
+	if len(s.Bars) != 2 || types["overlap"] != 1 || types["deadline"] != 1 || types["dependency"] != 1 || types["order"] != 0 {
+		t.Fatalf("TestBuildScheduleConflicts(): 얻은 값 %v", s.Conflicts)
+	}
+	if s.Start != "2020-11-02" || s.End != "2020-11-30" || len(s.Milestones) != 1 {
+		t.Fatalf("TestBuildScheduleConflicts(): 얻은 값 %v~%v, 원하는 값 2020-11-02~2020-11-30", s.Start, s.End)
+	}
+}
+
+func TestCheckTaskDates(t *testing.T) {
+	cases := []struct {
+		start, pre, date string
+		err              bool
+	}{
+		{"2020-11-02T10:00:00+09:00", "2020-11-04T10:00:00+09:00", "2020-11-06T10:00:00+09:00", false},
+		{"2020-11-02T10:00:00+09:00", "", "2020-11-01T10:00:00+09:00", true},
+		{"", "2020-11-04T10:00:00+09:00", "2020-11-03T10:00:00+09:00", true},
+		{"", "", "", false},
+	}
+	for _, c := range cases {
+		err := checkTaskDates(c.start, c.pre, c.date)
+		if (err != nil) != c.err {
+			t.Fatalf("TestCheckTaskDates(%v, %v, %v): 얻은 에러 %v", c.start, c.pre, c.date, err)
+		}
+	}
+}
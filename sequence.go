@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// regexpSeqHash 는 shot.####.exr 형태의 프레임 패턴에서 # 부분을 찾는다.
+var regexpSeqHash = regexp.MustCompile(`#+`)
+
+// regexpSeqPrintf 는 shot.%04d.exr 형태의 프레임 패턴에서 %04d 부분을 찾는다.
+var regexpSeqPrintf = regexp.MustCompile(`%0?[0-9]*d`)
+
+// seqExts 는 sequence 리뷰에 사용할 수 있는 이미지 확장자이다.
+var seqExts = []string{".exr", ".dpx", ".png", ".jpg", ".jpeg", ".tif", ".tiff"}
+
+// seqPattern 함수는 shot.####.exr 또는 shot.%04d.exr 형태의 경로를 shot.%04d.exr 형태의 printf 패턴으로 바꾼다.
+func seqPattern(path string) (string, error) {
+	base := filepath.Base(path)
+	hashes := regexpSeqHash.FindAllString(base, -1)
+	printfs := regexpSeqPrintf.FindAllString(base, -1)
+	if len(hashes)+len(printfs) != 1 {
+		return "", fmt.Errorf("%s 경로는 shot.####.exr 또는 shot.%%04d.exr 형태의 프레임 패턴이 1개 있어야 합니다", path)
+	}
+	if !hasExt(base, seqExts) {
+		return "", fmt.Errorf("%s 경로는 sequence 리뷰에 사용할 수 없는 확장자입니다. 사용가능한 확장자: %s", path, strings.Join(seqExts, ", "))
+	}
+	if len(printfs) == 1 {
+		return path, nil
+	}
+	return filepath.Join(filepath.Dir(path), strings.Replace(base, hashes[0], fmt.Sprintf("%%0%dd", len(hashes[0])), 1)), nil
+}
+
+// seqFramePath 함수는 printf 패턴과 프레임 번호를 이용해서 프레임 경로를 반환한다.
+func seqFramePath(pattern string, frame int) string {
+	return fmt.Sprintf(pattern, frame)
+}
+
+// missingFrames 함수는 프레임 구간에서 존재하지 않는 프레임 리스트를 반환한다.
+func missingFrames(pattern string, frameIn, frameOut int) []int {
+	var missing []int
+	for f := frameIn; f <= frameOut; f++ {
+		if _, err := os.Stat(seqFramePath(pattern, f)); err != nil {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// framesToString 함수는 프레임 리스트를 1001-1003,1010 형태의 문자열로 바꾼다. 프레임 리스트는 정렬되어 있어야 한다.
+func framesToString(frames []int) string {
+	var ranges []string
+	for i := 0; i < len(frames); {
+		j := i
+		for j+1 < len(frames) && frames[j+1] == frames[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(frames[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", frames[i], frames[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ",")
+}
+
+// linkSeqFrames 함수는 FFmpeg가 프레임이 빠진 구간에서 멈추지 않도록 dir 경로에 0부터 시작하는 연속된 심볼릭 링크를 만든다.
+// 빠진 프레임은 바로 앞의 존재하는 프레임으로 대체한다. 생성된 링크의 printf 패턴을 반환한다.
+func linkSeqFrames(pattern string, frameIn, frameOut int, dir string) (string, error) {
+	ext := filepath.Ext(pattern)
+	linkPattern := filepath.Join(dir, "%08d"+ext)
+	last := ""
+	// 첫 프레임이 빠져있다면 이후에 처음 존재하는 프레임으로 대체한다.
+	for f := frameIn; f <= frameOut; f++ {
+		if _, err := os.Stat(seqFramePath(pattern, f)); err == nil {
+			last = seqFramePath(pattern, f)
+			break
+		}
+	}
+	if last == "" {
+		return "", errors.New(pattern + " 프레임이 하나도 존재하지 않습니다")
+	}
+	for f := frameIn; f <= frameOut; f++ {
+		src := seqFramePath(pattern, f)
+		if _, err := os.Stat(src); err == nil {
+			last = src
+		}
+		err := os.Symlink(last, seqFramePath(linkPattern, f-frameIn))
+		if err != nil {
+			return "", err
+		}
+	}
+	return linkPattern, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSeqPattern(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+		err  bool
+	}{{
+		in:   "/show/circle/SS_0010/comp/SS_0010_comp_v001.####.exr",
+		want: "/show/circle/SS_0010/comp/SS_0010_comp_v001.%04d.exr",
+	}, {
+		in:   "/show/circle/SS_0010/comp/SS_0010_comp_v001.%06d.dpx",
+		want: "/show/circle/SS_0010/comp/SS_0010_comp_v001.%06d.dpx",
+	}, {
+		in:  "/show/circle/SS_0010/comp/SS_0010_comp_v001.1001.exr",
+		err: true,
+	}, {
+		in:  "/show/circle/SS_0010/comp/SS_0010_comp_v001.####.mov",
+		err: true,
+	}, {
+		in:  "/show/circle/SS_0010/comp/SS_####_v001.####.exr",
+		err: true,
+	}}
+	for _, c := range cases {
+		got, err := seqPattern(c.in)
+		if c.err {
+			if err == nil {
+				t.Fatalf("TestSeqPattern(%v): 에러가 발생해야 합니다", c.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("TestSeqPattern(%v): 얻은 값 %v, 원하는 값 %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestFramesToString(t *testing.T) {
+	cases := []struct {
+		in   []int
+		want string
+	}{{
+		in:   nil,
+		want: "",
+	}, {
+		in:   []int{1001},
+		want: "1001",
+	}, {
+		in:   []int{1001, 1002, 1003, 1010, 1012, 1013},
+		want: "1001-1003,1010,1012-1013",
+	}}
+	for _, c := range cases {
+		got := framesToString(c.in)
+		if got != c.want {
+			t.Fatalf("TestFramesToString(%v): 얻은 값 %v, 원하는 값 %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestLinkSeqFrames(t *testing.T) {
+	src, err := ioutil.TempDir("", "seq")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+	pattern := filepath.Join(src, "shot.%04d.exr")
+	// 1001, 1004 프레임만 존재한다.
+	for _, f := range []int{1002, 1003, 1005} {
+		err = ioutil.WriteFile(seqFramePath(pattern, f), []byte{byte(f)}, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	missing := missingFrames(pattern, 1001, 1005)
+	if framesToString(missing) != "1001,1004" {
+		t.Fatalf("TestLinkSeqFrames: 얻은 빠진 프레임 %v", missing)
+	}
+	dst, err := ioutil.TempDir("", "seqlink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dst)
+	linkPattern, err := linkSeqFrames(pattern, 1001, 1005, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 빠진 첫 프레임은 다음 프레임으로, 중간에 빠진 프레임은 앞 프레임으로 채워진다.
+	want := []int{1002, 1002, 1003, 1003, 1005}
+	for i, f := range want {
+		target, err := os.Readlink(seqFramePath(linkPattern, i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if target != seqFramePath(pattern, f) {
+			t.Fatalf("TestLinkSeqFrames(%d): 얻은 값 %v, 원하는 값 %v", i, target, seqFramePath(pattern, f))
+		}
+	}
+}
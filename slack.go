@@ -7,15 +7,29 @@ import (
 	"gopkg.in/mgo.v2"
 )
 
+// slacklog 함수는 프로젝트 로그 메시지를 message 이벤트로 발행한다.
+// Slack 전송은 이벤트 버스의 구독자인 slackSubscriber가 한다.
 func slacklog(session *mgo.Session, project, logString string) error {
-	p, err := getProject(session, project)
+	publishEvent(session, Event{Type: EventMessage, Project: project, Message: logString})
+	return nil
+}
+
+// slackSubscriber 는 message 이벤트를 프로젝트의 Slack 채널로 보내는 이벤트 구독자이다.
+type slackSubscriber struct{}
+
+// Notify 메소드는 message 이벤트를 프로젝트에 설정된 Slack Webhook URL로 보낸다.
+func (slackSubscriber) Notify(session *mgo.Session, e Event) error {
+	if e.Type != EventMessage {
+		return nil
+	}
+	p, err := getProject(session, e.Project)
 	if err != nil {
 		return err
 	}
 	if p.SlackWebhookURL != "" {
 		payload := slack.Payload{
-			Text:    logString,
-			Channel: "#" + project,
+			Text:    e.Message,
+			Channel: "#" + e.Project,
 		}
 		err := slack.Send(p.SlackWebhookURL, "", payload)
 		if len(err) > 0 {
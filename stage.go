@@ -13,6 +13,9 @@ type Stage struct {
 	InitStage   bool    `json:"initstage"`   // 아이템 생성시 최초 설정되는 ReviewStage 값
 	NextStage   string  `json:"nextstage"`   // Approve 되면 바뀔 stage
 	NextStatus  string  `json:"nextstatus"`  // Approve 되면 바뀔 status
+	// 최종 Stage에서 approve 되면 아이템 Task 상태, Task mov, Publish 상태에 결과가 반영된다.
+	Final             bool   `json:"final"`             // 최종 Stage 여부
+	ApproveTaskStatus string `json:"approvetaskstatus"` // 최종 Stage에서 approve 되면 바뀔 Task 상태(StatusV2). 빈 문자열이면 바꾸지 않는다.
 }
 
 // CheckError 메소드는 Stage 자료구조의 에러를 체크한다.
@@ -12,6 +12,7 @@ type Status struct {
 	Order       float64 `json:"order"`       // Status 우선순위
 	DefaultOn   bool    `json:"defaulton"`   // 검색바 기본선택 여부
 	InitStatus  bool    `json:"initstatus"`  // 아이템 생성시 최초 설정되는 Status 설정값
+	Complete    bool    `json:"complete"`    // 작업이 끝난 상태인지 여부. 의존관계에서 블로커를 판단할 때 사용한다.
 }
 
 // CheckError 메소드는 Status 자료구조의 에러를 체크한다.
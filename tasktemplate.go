@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TaskTemplate 자료구조는 아이템이 만들어질 때 생성할 Task 묶음이다.
+// 프로젝트와 아이템 타입(shot, asset), shottype 마다 다른 템플릿을 지정할 수 있다.
+type TaskTemplate struct {
+	ID          string             `json:"id"`          // 템플릿 ID. 예) feature3d
+	Name        string             `json:"name"`        // 템플릿 표기명
+	Description string             `json:"description"` // 설명
+	Tasks       []TaskTemplateTask `json:"tasks"`       // 생성할 Task 리스트
+	Updatetime  string             `json:"updatetime"`  // 업데이트 시간 RFC3339
+}
+
+// TaskTemplateTask 자료구조는 템플릿으로 생성할 Task와 기본값이다.
+type TaskTemplateTask struct {
+	ID        string    `json:"id"`        // Tasksetting ID
+	ExpectDay int       `json:"expectday"` // 기본 예측 맨데이
+	TaskLevel TaskLevel `json:"tasklevel"` // 기본 난이도
+}
+
+// TaskTemplateRule 자료구조는 프로젝트에서 아이템 타입, shottype 별로 사용할 템플릿이다.
+type TaskTemplateRule struct {
+	ItemType string `json:"itemtype"` // shot, asset
+	Shottype string `json:"shottype"` // "", 2d, 3d. 빈 문자열이면 모든 shottype에 적용된다.
+	Template string `json:"template"` // TaskTemplate ID
+}
+
+// TaskTemplateDiff 자료구조는 아이템에 템플릿을 적용할 때 추가되거나 삭제되는 Task 리스트이다.
+type TaskTemplateDiff struct {
+	ID       string   `json:"id"`       // 아이템 ID
+	Template string   `json:"template"` // 적용할 TaskTemplate ID
+	Add      []string `json:"add"`      // 추가되는 Task
+	Remove   []string `json:"remove"`   // 템플릿에 없는 Task. remove 옵션을 사용하면 삭제된다.
+	Error    string   `json:"error"`
+}
+
+// CheckError 메소드는 TaskTemplate 자료구조의 에러를 체크한다. settings는 전체 Tasksetting 리스트이다.
+func (t TaskTemplate) CheckError(settings []Tasksetting) error {
+	if !regexpTask.MatchString(t.ID) {
+		return errors.New("템플릿 id는 영문 소문자, 숫자, _ 로 작성해주세요")
+	}
+	ids := make(map[string]bool)
+	for _, s := range settings {
+		ids[s.ID] = true
+	}
+	used := make(map[string]bool)
+	for _, task := range t.Tasks {
+		if !ids[task.ID] {
+			return fmt.Errorf("%s Tasksetting이 존재하지 않습니다", task.ID)
+		}
+		if used[task.ID] {
+			return fmt.Errorf("%s Task가 중복되었습니다", task.ID)
+		}
+		used[task.ID] = true
+		if task.ExpectDay < 0 {
+			return fmt.Errorf("%s Task의 expectday는 0 이상이어야 합니다", task.ID)
+		}
+		if task.TaskLevel < TaskLevel0 || task.TaskLevel > TaskLevel5 {
+			return fmt.Errorf("%s Task의 tasklevel은 0~5 사이여야 합니다", task.ID)
+		}
+	}
+	return nil
+}
+
+// parseTaskTemplateTasks 함수는 "comp:5:2,light" 형태(Tasksetting ID:예측맨데이:난이도)의 문자열을 Task 리스트로 바꾼다.
+func parseTaskTemplateTasks(str string) ([]TaskTemplateTask, error) {
+	tasks := []TaskTemplateTask{}
+	for _, s := range Str2List(str) {
+		values := strings.Split(s, ":")
+		if len(values) > 3 {
+			return nil, fmt.Errorf("%s 는 id:expectday:tasklevel 형태가 아닙니다", s)
+		}
+		task := TaskTemplateTask{ID: values[0]}
+		if len(values) > 1 && values[1] != "" {
+			n, err := strconv.Atoi(values[1])
+			if err != nil {
+				return nil, fmt.Errorf("%s 의 expectday는 숫자여야 합니다", s)
+			}
+			task.ExpectDay = n
+		}
+		if len(values) > 2 && values[2] != "" {
+			n, err := strconv.Atoi(values[2])
+			if err != nil {
+				return nil, fmt.Errorf("%s 의 tasklevel은 숫자여야 합니다", s)
+			}
+			task.TaskLevel = TaskLevel(n)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// taskTemplateItemType 함수는 아이템 타입을 템플릿 규칙에서 사용하는 타입으로 바꾼다. 샷, 에셋이 아니면 빈 문자열이다.
+func taskTemplateItemType(typ string) string {
+	switch typ {
+	case "org", "left":
+		return "shot"
+	case "asset":
+		return "asset"
+	}
+	return ""
+}
+
+// TaskTemplateID 메소드는 아이템에 사용할 템플릿 ID를 반환한다. shottype이 같은 규칙을 먼저 사용하고 없으면 shottype이 빈 규칙을 사용한다.
+func (p Project) TaskTemplateID(i Item) string {
+	itemType := taskTemplateItemType(i.Type)
+	if itemType == "" {
+		return ""
+	}
+	fallback := ""
+	for _, rule := range p.TaskTemplates {
+		if rule.ItemType != itemType {
+			continue
+		}
+		if rule.Shottype == i.Shottype && rule.Shottype != "" {
+			return rule.Template
+		}
+		if rule.Shottype == "" {
+			fallback = rule.Template
+		}
+	}
+	return fallback
+}
+
+// SetTaskTemplateRule 메소드는 프로젝트의 템플릿 규칙을 설정한다. template이 빈 문자열이면 규칙을 삭제한다.
+func (p *Project) SetTaskTemplateRule(rule TaskTemplateRule) error {
+	if rule.ItemType != "shot" && rule.ItemType != "asset" {
+		return errors.New("itemtype은 shot 또는 asset 이어야 합니다")
+	}
+	if rule.Shottype != "" && rule.Shottype != "2d" && rule.Shottype != "3d" {
+		return errors.New("shottype은 빈 문자열, 2d, 3d 중 하나여야 합니다")
+	}
+	rules := []TaskTemplateRule{}
+	for _, r := range p.TaskTemplates {
+		if r.ItemType == rule.ItemType && r.Shottype == rule.Shottype {
+			continue
+		}
+		rules = append(rules, r)
+	}
+	if rule.Template != "" {
+		rules = append(rules, rule)
+	}
+	p.TaskTemplates = rules
+	return nil
+}
+
+// taskTemplateTasks 함수는 템플릿으로 생성할 Task 맵을 반환한다. 아이템의 Task 키는 Tasksetting의 Name이다.
+func taskTemplateTasks(t TaskTemplate, settings []Tasksetting, initStatusID string) map[string]Task {
+	names := make(map[string]string)
+	for _, s := range settings {
+		names[s.ID] = s.Name
+	}
+	tasks := make(map[string]Task)
+	for _, task := range t.Tasks {
+		name, ok := names[task.ID]
+		if !ok {
+			continue // 삭제된 Tasksetting은 생성하지 않는다.
+		}
+		tasks[name] = Task{
+			Title:     name,
+			Status:    ASSIGN, // legacy
+			StatusV2:  initStatusID,
+			ExpectDay: task.ExpectDay,
+			TaskLevel: task.TaskLevel,
+		}
+	}
+	return tasks
+}
+
+// taskTemplateDiff 함수는 아이템에 템플릿 Task를 적용할 때 추가되는 Task와 템플릿에 없는 Task를 정렬해서 반환한다.
+func taskTemplateDiff(i Item, tasks map[string]Task) ([]string, []string) {
+	add := []string{}
+	remove := []string{}
+	for name := range tasks {
+		if _, ok := i.Tasks[name]; !ok {
+			add = append(add, name)
+		}
+	}
+	for name := range i.Tasks {
+		if _, ok := tasks[name]; !ok {
+			remove = append(remove, name)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
+
+// applyTaskTemplate 함수는 아이템에 템플릿 Task를 적용한다. 이미 있는 Task는 유지하고 remove가 true이면 템플릿에 없는 Task를 삭제한다.
+func applyTaskTemplate(i *Item, tasks map[string]Task, remove bool) TaskTemplateDiff {
+	add, rm := taskTemplateDiff(*i, tasks)
+	if i.Tasks == nil {
+		i.Tasks = make(map[string]Task)
+	}
+	for _, name := range add {
+		i.Tasks[name] = tasks[name]
+	}
+	if remove {
+		for _, name := range rm {
+			delete(i.Tasks, name)
+		}
+	}
+	return TaskTemplateDiff{ID: i.ID, Add: add, Remove: rm}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTaskTemplateID(t *testing.T) {
+	p := Project{
+		TaskTemplates: []TaskTemplateRule{
+			{ItemType: "shot", Shottype: "3d", Template: "shot3d"},
+			{ItemType: "shot", Shottype: "", Template: "shot"},
+			{ItemType: "asset", Shottype: "", Template: "asset"},
+		},
+	}
+	cases := []struct {
+		item Item
+		want string
+	}{{
+		item: Item{Type: "org", Shottype: "3d"},
+		want: "shot3d",
+	}, {
+		item: Item{Type: "left", Shottype: "2d"},
+		want: "shot",
+	}, {
+		item: Item{Type: "asset"},
+		want: "asset",
+	}, {
+		item: Item{Type: "src"},
+		want: "",
+	}}
+	for _, c := range cases {
+		got := p.TaskTemplateID(c.item)
+		if got != c.want {
+			t.Fatalf("TestTaskTemplateID(%v): 얻은 값 %v, 원하는 값 %v", c.item, got, c.want)
+		}
+	}
+}
+
+func TestParseTaskTemplateTasks(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []TaskTemplateTask
+		err  bool
+	}{{
+		in:   "comp:5:2,fx",
+		want: []TaskTemplateTask{{ID: "comp", ExpectDay: 5, TaskLevel: TaskLevel2}, {ID: "fx"}},
+	}, {
+		in:  "comp:five",
+		err: true,
+	}, {
+		in:  "comp:1:2:3",
+		err: true,
+	}}
+	for _, c := range cases {
+		got, err := parseTaskTemplateTasks(c.in)
+		if c.err != (err != nil) {
+			t.Fatalf("TestParseTaskTemplateTasks(%v): 얻은 에러 %v", c.in, err)
+		}
+		if !c.err && !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("TestParseTaskTemplateTasks(%v): 얻은 값 %v, 원하는 값 %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestApplyTaskTemplate(t *testing.T) {
+	i := Item{ID: "SS_0010_org", Tasks: map[string]Task{"comp": {Title: "comp", User: "kim"}, "matte": {Title: "matte"}}}
+	tasks := map[string]Task{"comp": {Title: "comp"}, "fx": {Title: "fx"}}
+	diff := applyTaskTemplate(&i, tasks, false)
+	if !reflect.DeepEqual(diff.Add, []string{"fx"}) || !reflect.DeepEqual(diff.Remove, []string{"matte"}) {
+		t.Fatalf("TestApplyTaskTemplate(): 얻은 값 %v, 원하는 값 add [fx] remove [matte]", diff)
+	}
+	if i.Tasks["comp"].User != "kim" || len(i.Tasks) != 3 {
+		t.Fatalf("TestApplyTaskTemplate(): 얻은 값 %v, 원하는 값 기존 Task 유지", i.Tasks)
+	}
+	applyTaskTemplate(&i, tasks, true)
+	if _, ok := i.Tasks["matte"]; ok {
+		t.Fatalf("TestApplyTaskTemplate(remove): 얻은 값 %v, 원하는 값 matte 삭제", i.Tasks)
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// WorkHoursPerDay 는 하루(1 맨데이) 작업시간이다. 타임시트 시간을 ResultDay로 바꿀 때 사용한다.
+const WorkHoursPerDay = 8.0
+
+// TimeLog 자료구조는 아티스트가 하루 동안 Task에 사용한 작업시간이다.
+type TimeLog struct {
+	ID          bson.ObjectId `json:"id" bson:"_id,omitempty"`
+	Project     string        `json:"project"`     // 프로젝트
+	Item        string        `json:"item"`        // 아이템 ID. 예) SS_0010_org
+	Task        string        `json:"task"`        // Task 이름
+	UserID      string        `json:"userid"`      // 작업한 아티스트 ID
+	Date        string        `json:"date"`        // 작업일. 2006-01-02
+	Hours       float64       `json:"hours"`       // 작업시간
+	Note        string        `json:"note"`        // 작업내용
+	Status      string        `json:"status"`      // pending, approved, rejected
+	Approver    string        `json:"approver"`    // 승인, 반려한 사용자
+	Approvetime string        `json:"approvetime"` // 승인, 반려시간 RFC3339
+	Createtime  string        `json:"createtime"`  // 생성시간 RFC3339
+}
+
+// TimesheetReportRow 자료구조는 타임시트 리포트의 한 행이다. 예측 맨데이와 실제 맨데이를 비교한다.
+type TimesheetReportRow struct {
+	Key       string  `json:"key"`       // task, user, team 이름
+	ExpectDay float64 `json:"expectday"` // 예측 맨데이
+	Hours     float64 `json:"hours"`     // 타임시트 작업시간
+	ResultDay float64 `json:"resultday"` // 실제 맨데이(작업시간 / WorkHoursPerDay)
+	Diff      float64 `json:"diff"`      // 실제 맨데이 - 예측 맨데이. 양수이면 예측보다 오래 걸렸다.
+}
+
+// CheckError 메소드는 TimeLog 자료구조의 에러를 체크한다.
+func (l TimeLog) CheckError() error {
+	if l.Project == "" {
+		return errors.New("project를 설정해주세요")
+	}
+	if l.Item == "" {
+		return errors.New("id(SS_0010_org)를 설정해주세요")
+	}
+	if l.Task == "" {
+		return errors.New("task를 설정해주세요")
+	}
+	if l.UserID == "" {
+		return errors.New("userid를 설정해주세요")
+	}
+	if _, err := time.Parse("2006-01-02", l.Date); err != nil {
+		return errors.New("date는 2006-01-02 형식이어야 합니다")
+	}
+	if l.Hours <= 0 || l.Hours > 24 {
+		return errors.New("hours는 0보다 크고 24 이하여야 합니다")
+	}
+	return nil
+}
+
+// weekRange 함수는 날짜(2006-01-02)가 속한 주의 월요일과 일요일을 반환한다.
+func weekRange(date string) (string, string, error) {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", "", fmt.Errorf("%s 는 2006-01-02 형식이 아닙니다", date)
+	}
+	offset := (int(t.Weekday()) + 6) % 7 // 월요일이 0이 된다.
+	monday := t.AddDate(0, 0, -offset)
+	return monday.Format("2006-01-02"), monday.AddDate(0, 0, 6).Format("2006-01-02"), nil
+}
+
+// hoursToDay 함수는 작업시간을 맨데이로 바꾼다. 반나절 이상 작업했다면 하루로 계산한다.
+func hoursToDay(hours float64) int {
+	return int(math.Round(hours / WorkHoursPerDay))
+}
+
+// timeLogHours 함수는 반려되지 않은 타임시트의 작업시간을 아티스트별로 합산한다.
+func timeLogHours(logs []TimeLog) map[string]float64 {
+	hours := make(map[string]float64)
+	for _, l := range logs {
+		if l.Status == "rejected" {
+			continue
+		}
+		hours[l.UserID] += l.Hours
+	}
+	return hours
+}
+
+// applyTimeLogs 메소드는 타임시트 작업시간으로 Task의 ResultDay를 계산한다.
+// 여러 아티스트가 작업하는 Task라면 아티스트별 ResultDay를 계산하고 합산한다.
+func (t *Task) applyTimeLogs(logs []TimeLog) {
+	hours := timeLogHours(logs)
+	if len(t.Assignees) == 0 {
+		var total float64
+		for _, h := range hours {
+			total += h
+		}
+		t.ResultDay = hoursToDay(total)
+		return
+	}
+	for n, a := range t.Assignees {
+		t.Assignees[n].ResultDay = hoursToDay(hours[assigneeID(a.User)])
+	}
+	t.syncAssignees()
+	// Task에 배정되지 않은 아티스트가 기록한 시간도 ResultDay에 포함한다.
+	var extra float64
+	for user, h := range hours {
+		if _, ok := t.Assignee(user); !ok {
+			extra += h
+		}
+	}
+	t.ResultDay += hoursToDay(extra)
+}
+
+// expectDayShares 함수는 Task의 예측 맨데이를 아티스트별로 나눈다. 분담률이 없다면 같은 비율로 나눈다.
+func expectDayShares(t Task) map[string]float64 {
+	shares := make(map[string]float64)
+	if len(t.Assignees) == 0 {
+		users := t.Users()
+		for _, u := range users {
+			shares[assigneeID(u)] += float64(t.ExpectDay) / float64(len(users))
+		}
+		return shares
+	}
+	var total float64
+	for _, a := range t.Assignees {
+		total += a.Share
+	}
+	for _, a := range t.Assignees {
+		if total == 0 {
+			shares[assigneeID(a.User)] += float64(t.ExpectDay) / float64(len(t.Assignees))
+			continue
+		}
+		shares[assigneeID(a.User)] += float64(t.ExpectDay) * a.Share / 100
+	}
+	return shares
+}
+
+// timesheetReport 함수는 아이템의 예측 맨데이와 타임시트 작업시간을 groupby(task, user, team) 기준으로 비교한다.
+// teams는 아티스트 ID:팀 이름 맵이다. 팀이 없는 아티스트는 "none" 으로 분류한다.
+func timesheetReport(items []Item, logs []TimeLog, groupby string, teams map[string]string) ([]TimesheetReportRow, error) {
+	key := func(task, user string) string {
+		switch groupby {
+		case "user":
+			return user
+		case "team":
+			if team, ok := teams[user]; ok && team != "" {
+				return team
+			}
+			return "none"
+		}
+		return task
+	}
+	if groupby != "task" && groupby != "user" && groupby != "team" {
+		return nil, errors.New("groupby는 task, user, team 중 하나여야 합니다")
+	}
+	rows := make(map[string]*TimesheetReportRow)
+	row := func(k string) *TimesheetReportRow {
+		if _, ok := rows[k]; !ok {
+			rows[k] = &TimesheetReportRow{Key: k}
+		}
+		return rows[k]
+	}
+	for _, i := range items {
+		for name, t := range i.Tasks {
+			if groupby == "task" {
+				row(name).ExpectDay += float64(t.ExpectDay)
+				continue
+			}
+			for user, day := range expectDayShares(t) {
+				row(key(name, user)).ExpectDay += day
+			}
+		}
+	}
+	for _, l := range logs {
+		if l.Status == "rejected" {
+			continue
+		}
+		row(key(l.Task, l.UserID)).Hours += l.Hours
+	}
+	results := []TimesheetReportRow{}
+	for _, r := range rows {
+		r.ResultDay = r.Hours / WorkHoursPerDay
+		r.Diff = r.ResultDay - r.ExpectDay
+		results = append(results, *r)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Key < results[j].Key
+	})
+	return results, nil
+}
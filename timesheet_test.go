@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestWeekRange(t *testing.T) {
+	cases := []struct {
+		date  string
+		start string
+		end   string
+	}{{
+		date:  "2020-11-04", // 수요일
+		start: "2020-11-02",
+		end:   "2020-11-08",
+	}, {
+		date:  "2020-11-08", // 일요일
+		start: "2020-11-02",
+		end:   "2020-11-08",
+	}, {
+		date:  "2020-11-02", // 월요일
+		start: "2020-11-02",
+		end:   "2020-11-08",
+	}}
+	for _, c := range cases {
+		start, end, err := weekRange(c.date)
+		if err != nil || start != c.start || end != c.end {
+			t.Fatalf("TestWeekRange(%v): 얻은 값 %v~%v, 원하는 값 %v~%v", c.date, start, end, c.start, c.end)
+		}
+	}
+}
+
+func TestApplyTimeLogs(t *testing.T) {
+	logs := []TimeLog{
+		{UserID: "kim", Hours: 8},
+		{UserID: "kim", Hours: 8},
+		{UserID: "lee", Hours: 8},
+		{UserID: "lee", Hours: 8, Status: "rejected"},
+	}
+	task := Task{User: "kim"}
+	task.applyTimeLogs(logs)
+	if task.ResultDay != 3 {
+		t.Fatalf("TestApplyTimeLogs(): 얻은 값 %v, 원하는 값 %v", task.ResultDay, 3)
+	}
+	task = Task{Assignees: []Assignee{{User: "kim(김,2D1)", Role: "lead"}, {User: "lee", Role: "support"}}}
+	task.applyTimeLogs(logs)
+	if task.Assignees[0].ResultDay != 2 || task.Assignees[1].ResultDay != 1 || task.ResultDay != 3 {
+		t.Fatalf("TestApplyTimeLogs(assignees): 얻은 값 %v, 원하는 값 kim 2, lee 1, 합계 3", task)
+	}
+}
+
+func TestTimesheetReport(t *testing.T) {
+	items := []Item{{Tasks: map[string]Task{
+		"comp": {ExpectDay: 4, Assignees: []Assignee{{User: "kim", Share: 50}, {User: "lee", Share: 50}}},
+	}}}
+	logs := []TimeLog{{Task: "comp", UserID: "kim", Hours: 24}}
+	teams := map[string]string{"kim": "comp1", "lee": "comp1"}
+	rows, err := timesheetReport(items, logs, "team", teams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].ExpectDay != 4 || rows[0].ResultDay != 3 || rows[0].Diff != -1 {
+		t.Fatalf("TestTimesheetReport(team): 얻은 값 %v", rows)
+	}
+	rows, _ = timesheetReport(items, logs, "user", teams)
+	if len(rows) != 2 || rows[0].Key != "kim" || rows[0].ExpectDay != 2 {
+		t.Fatalf("TestTimesheetReport(user): 얻은 값 %v", rows)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TranscodeProfile 자료구조는 리뷰 데이터를 렌더링할 때 사용하는 FFmpeg 설정 자료구조이다.
+type TranscodeProfile struct {
+	ID           string  `json:"id"`           // 프로파일 이름
+	Description  string  `json:"description"`  // 설명
+	Codec        string  `json:"codec"`        // FFmpeg 비디오 코덱. 예) libx264, libx265, libtheora, libvpx-vp9
+	Ext          string  `json:"ext"`          // 렌더링 결과 확장자. 예) .mp4, .ogg, .webm
+	Bitrate      string  `json:"bitrate"`      // 비디오 Bitrate. 예) 8M. 설정되면 CRF, Qscale보다 우선한다.
+	CRF          int     `json:"crf"`          // Constant Rate Factor. 0이면 사용하지 않는다.
+	Qscale       int     `json:"qscale"`       // 비디오 qscale. Bitrate, CRF가 설정되지 않았을 때 사용한다.
+	PixFmt       string  `json:"pixfmt"`       // 픽셀포멧. 예) yuv420p 이 옵션이 없다면 Prores로 동영상을 만들때 크롬에서만 재생된다.
+	MovFormat    string  `json:"movformat"`    // 프로젝트 Mov 포멧 사용여부: ""(프로파일 설정), outputmov, editmov
+	Width        int     `json:"width"`        // 가로 사이즈. 0이면 원본 사이즈를 사용한다.
+	Height       int     `json:"height"`       // 세로 사이즈. 0이면 원본 사이즈를 사용한다.
+	Fps          float64 `json:"fps"`          // 출력 FPS. 0이면 원본 FPS를 사용한다.
+	BurnIn       bool    `json:"burnin"`       // 프로젝트 BurnIn 설정으로 슬레이트 버전을 추가로 생성할지 여부
+	Audio        string  `json:"audio"`        // 오디오 처리방법: none, copy, aac
+	AudioBitrate string  `json:"audiobitrate"` // 오디오 Bitrate. 예) 192k
+}
+
+// FFmpegOptions 자료구조는 FFmpeg 명령어를 만들 때 프로파일 외에 필요한 값을 담는 자료구조이다.
+type FFmpegOptions struct {
+	Input         string   // 입력 경로
+	InputArgs     []string // 입력 경로 앞에 붙는 옵션. 예) -framerate 24 -start_number 0
+	ExtraInputs   []string // FilterComplex에서 사용할 추가 입력 경로
+	FilterComplex string   // 여러 입력을 합성하는 필터그래프. 설정되면 -vf 대신 -filter_complex를 사용한다. 출력 패드는 라벨을 붙이지 않는다.
+	Output        string   // 출력 경로
+	ColorFilters  []string // 스케일 이전에 적용될 컬러 변환 필터. 예) lut3d
+	Overlays      []string // 스케일 이후에 적용될 비디오 필터. 예) drawtext
+	Threads       int      // FFmpeg 연산 Thread 수
+}
+
+// defaultTranscodeProfile 함수는 프로젝트에 프로파일이 설정되어 있지 않을 때 사용하는 기본 프로파일을 반환한다.
+func defaultTranscodeProfile() TranscodeProfile {
+	return TranscodeProfile{
+		ID:          "default",
+		Description: "기본 리뷰 프로파일",
+		Codec:       "libx264",
+		Ext:         ".mp4",
+		Qscale:      7,
+		PixFmt:      "yuv420p",
+		Audio:       "none",
+	}
+}
+
+// CheckError 메소드는 TranscodeProfile 자료구조의 에러를 체크한다.
+func (t *TranscodeProfile) CheckError() error {
+	if t.ID == "" {
+		return errors.New("ID가 빈 문자열 입니다")
+	}
+	if t.Codec == "" {
+		return errors.New("Codec이 빈 문자열 입니다")
+	}
+	if !strings.HasPrefix(t.Ext, ".") {
+		return errors.New("Ext는 .mp4 형태로 입력해주세요")
+	}
+	if t.CRF < 0 || t.CRF > 51 {
+		return errors.New("CRF는 0~51 사이의 값이어야 합니다")
+	}
+	if t.Width < 0 || t.Height < 0 {
+		return errors.New("Width, Height는 0보다 작을 수 없습니다")
+	}
+	if t.Width%2 != 0 || t.Height%2 != 0 {
+		return errors.New("Width, Height는 짝수여야 합니다")
+	}
+	if t.Fps < 0 {
+		return errors.New("Fps는 0보다 작을 수 없습니다")
+	}
+	switch t.MovFormat {
+	case "", "outputmov", "editmov":
+	default:
+		return errors.New("MovFormat은 outputmov, editmov 값만 사용가능합니다")
+	}
+	switch t.Audio {
+	case "", "none", "copy", "aac":
+	default:
+		return errors.New("Audio는 none, copy, aac 값만 사용가능합니다")
+	}
+	return nil
+}
+
+// ResolveMov 메소드는 MovFormat 설정에 따라 프로젝트의 Mov 포멧에서 사이즈와 FPS를 가지고 온다.
+func (t TranscodeProfile) ResolveMov(p Project) TranscodeProfile {
+	var mov Mov
+	switch t.MovFormat {
+	case "outputmov":
+		mov = p.OutputMov
+	case "editmov":
+		mov = p.EditMov
+	default:
+		return t
+	}
+	if mov.Width > 0 && mov.Height > 0 {
+		t.Width = mov.Width
+		t.Height = mov.Height
+	}
+	if mov.Fps > 0 {
+		t.Fps = mov.Fps
+	}
+	return t
+}
+
+// FFmpegArgs 메소드는 프로파일과 옵션을 이용해서 FFmpeg 인수 리스트를 만든다.
+func (t TranscodeProfile) FFmpegArgs(opt FFmpegOptions) []string {
+	args := append([]string{"-y"}, opt.InputArgs...)
+	args = append(args, "-i", opt.Input)
+	for _, input := range opt.ExtraInputs {
+		args = append(args, "-i", input)
+	}
+	filters := append([]string{}, opt.ColorFilters...)
+	if t.Width > 0 && t.Height > 0 {
+		filters = append(filters, fmt.Sprintf("scale=%d:%d", t.Width, t.Height))
+	}
+	filters = append(filters, opt.Overlays...)
+	if opt.FilterComplex != "" {
+		graph := opt.FilterComplex
+		if len(filters) > 0 {
+			graph += "," + strings.Join(filters, ",")
+		}
+		args = append(args, "-filter_complex", graph)
+	} else if len(filters) > 0 {
+		args = append(args, "-vf", strings.Join(filters, ","))
+	}
+	args = append(args, "-c:v", t.Codec)
+	switch {
+	case t.Bitrate != "":
+		args = append(args, "-b:v", t.Bitrate)
+	case t.CRF > 0:
+		args = append(args, "-crf", strconv.Itoa(t.CRF))
+	case t.Qscale > 0:
+		args = append(args, "-qscale:v", strconv.Itoa(t.Qscale))
+	}
+	if t.Fps > 0 {
+		args = append(args, "-r", strconv.FormatFloat(t.Fps, 'f', -1, 64))
+	}
+	switch t.Audio {
+	case "copy":
+		args = append(args, "-c:a", "copy")
+	case "aac":
+		args = append(args, "-c:a", "aac")
+		if t.AudioBitrate != "" {
+			args = append(args, "-b:a", t.AudioBitrate)
+		}
+	default:
+		args = append(args, "-an")
+	}
+	if t.PixFmt != "" {
+		args = append(args, "-pix_fmt", t.PixFmt)
+	}
+	if opt.Threads > 0 {
+		args = append(args, "-threads", strconv.Itoa(opt.Threads)) // 웹서버의 부하를 줄이기 위해서 서버수가 적다면 쓰레드 1개만 사용한다.
+	}
+	args = append(args, opt.Output)
+	return args
+}
+
+// drawtextFilter 함수는 문자열을 영상의 x, y 위치에 새기는 FFmpeg drawtext 필터를 반환한다.
+// text 는 drawtext 문법을 따르며 %{frame_num} 같은 확장 문법을 사용할 수 있다. 일반 문자열은 escapeDrawtext 함수를 거쳐서 전달한다.
+func drawtextFilter(text, x, y string, fontsize int, fontfile string) string {
+	args := "text=" + escapeFFmpeg(text, `\':`)
+	if fontfile != "" {
+		args += ":fontfile=" + escapeFFmpeg(fontfile, `\':`)
+	}
+	args += fmt.Sprintf(":x=%s:y=%s:fontsize=%d:fontcolor=white:box=1:boxcolor=black@0.5", x, y, fontsize)
+	return "drawtext=" + escapeFFmpeg(args, `\'[],;`)
+}
+
+// escapeDrawtext 함수는 drawtext 확장 문법에서 특수문자로 사용되는 문자를 이스케이프 한다.
+func escapeDrawtext(text string) string {
+	return escapeFFmpeg(text, `\%`)
+}
+
+// escapeFFmpeg 함수는 special에 포함된 문자 앞에 \ 문자를 붙인다.
+// FFmpeg 필터는 필터그래프, 옵션값 단계에서 각각 이스케이프를 처리하기 때문에 단계별로 호출해야 한다.
+func escapeFFmpeg(s, special string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(special, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFFmpegArgs(t *testing.T) {
+	opt := FFmpegOptions{
+		Input:   "/show/in.mov",
+		Output:  "/review/id.mp4",
+		Threads: 1,
+	}
+	cases := []struct {
+		name    string
+		profile TranscodeProfile
+		opt     FFmpegOptions
+		want    []string
+	}{{
+		name:    "default",
+		profile: defaultTranscodeProfile(),
+		opt:     opt,
+		want:    []string{"-y", "-i", "/show/in.mov", "-c:v", "libx264", "-qscale:v", "7", "-an", "-pix_fmt", "yuv420p", "-threads", "1", "/review/id.mp4"},
+	}, {
+		name:    "crf scale fps aac",
+		profile: TranscodeProfile{Codec: "libx265", CRF: 23, Qscale: 7, Width: 1920, Height: 1080, Fps: 23.976, Audio: "aac", AudioBitrate: "192k"},
+		opt:     FFmpegOptions{Input: "in.mov", Output: "out.mp4"},
+		want:    []string{"-y", "-i", "in.mov", "-vf", "scale=1920:1080", "-c:v", "libx265", "-crf", "23", "-r", "23.976", "-c:a", "aac", "-b:a", "192k", "out.mp4"},
+	}, {
+		name:    "bitrate overlay copy",
+		profile: TranscodeProfile{Codec: "libvpx-vp9", Bitrate: "8M", CRF: 23, Audio: "copy"},
+		opt:     FFmpegOptions{Input: "in.mov", Output: "out.webm", Overlays: []string{"drawtext=text=A"}},
+		want:    []string{"-y", "-i", "in.mov", "-vf", "drawtext=text=A", "-c:v", "libvpx-vp9", "-b:v", "8M", "-c:a", "copy", "out.webm"},
+	}}
+	for _, c := range cases {
+		got := c.profile.FFmpegArgs(c.opt)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("TestFFmpegArgs(%v): 얻은 값 %v, 원하는 값 %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestDrawtextFilter(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{{
+		in:   "SS_0010 comp v001",
+		want: `drawtext=text=SS_0010 comp v001:x=10:y=h-th-10:fontsize=24:fontcolor=white:box=1:boxcolor=black@0.5`,
+	}, {
+		in:   "a:b'c",
+		want: `drawtext=text=a\\:b\\\'c:x=10:y=h-th-10:fontsize=24:fontcolor=white:box=1:boxcolor=black@0.5`,
+	}, {
+		in:   escapeDrawtext("100%"),
+		want: `drawtext=text=100\\\\%:x=10:y=h-th-10:fontsize=24:fontcolor=white:box=1:boxcolor=black@0.5`,
+	}, {
+		in:   "[a],b;c",
+		want: `drawtext=text=\[a\]\,b\;c:x=10:y=h-th-10:fontsize=24:fontcolor=white:box=1:boxcolor=black@0.5`,
+	}}
+	for _, c := range cases {
+		got := drawtextFilter(c.in, "10", "h-th-10", 24, "")
+		if got != c.want {
+			t.Fatalf("TestDrawtextFilter(%v): 얻은 값 %v, 원하는 값 %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestResolveMov(t *testing.T) {
+	p := Project{OutputMov: Mov{Width: 2048, Height: 858, Fps: 24}}
+	got := TranscodeProfile{MovFormat: "outputmov", Width: 1280, Height: 720}.ResolveMov(p)
+	if got.Width != 2048 || got.Height != 858 || got.Fps != 24 {
+		t.Fatalf("TestResolveMov: 얻은 값 %v", got)
+	}
+	got = TranscodeProfile{MovFormat: "editmov", Width: 1280, Height: 720}.ResolveMov(p)
+	if got.Width != 1280 || got.Height != 720 {
+		t.Fatalf("TestResolveMov: EditMov가 비어있을 때 프로파일 값을 유지해야 합니다. 얻은 값 %v", got)
+	}
+}
@@ -27,6 +27,12 @@ func usage() {
 	fmt.Fprintf(os.Stderr, "샷 추가:\n")
 	fmt.Fprintf(os.Stderr, "$ csi3 -add item -project [projectName] -name [SS_0010] -type [org|left]\n")
 	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "재스캔, 소스 플레이트 추가:\n")
+	fmt.Fprintf(os.Stderr, "$ csi3 -add item -project [projectName] -name [SS_0010] -type [org1|left1|src1] (-updateparent)\n")
+	fmt.Fprintf(os.Stderr, "\n")
+	fmt.Fprintf(os.Stderr, "재스캔, 소스 아이템을 플레이트 버전으로 옮기기:\n")
+	fmt.Fprintf(os.Stderr, "# csi3 -migrateplates -project [projectName]\n")
+	fmt.Fprintf(os.Stderr, "\n")
 	fmt.Fprintf(os.Stderr, "에셋 추가:\n")
 	fmt.Fprintf(os.Stderr, "$ csi3 -add item -project [projectName] -name [assetName] -type asset\n")
 	fmt.Fprintf(os.Stderr, "  -assettype [char|env|global|prop|comp|plant|vehicle|group] -assettags [component|assembly]\n")